@@ -0,0 +1,87 @@
+package main
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+
+	"github.com/modelcontextprotocol/go-sdk/auth"
+)
+
+// apiKeyHeader carries a static API key on inbound requests. It is separate
+// from Authorization so that, in client-provided auth mode, the caller's
+// Collibra credentials can still be forwarded untouched.
+const apiKeyHeader = "X-API-Key"
+
+// newAuthMiddleware wraps the MCP handler with inbound authentication. A
+// request is let through when it carries a configured API key in X-API-Key,
+// or, if JWT validation is configured, a valid bearer JWT in Authorization.
+// Everything else is rejected with 401 before it reaches the MCP handler.
+// It returns nil when no inbound authentication is configured.
+func newAuthMiddleware(config HttpAuthConfig) (func(http.Handler) http.Handler, error) {
+	if !config.Enabled() {
+		return nil, nil
+	}
+
+	var requireJWT func(http.Handler) http.Handler
+	if config.JWT.JWKSFile != "" {
+		verifier, err := newJWTVerifier(config.JWT)
+		if err != nil {
+			return nil, err
+		}
+		requireJWT = auth.RequireBearerToken(verifier.Verify, &auth.RequireBearerTokenOptions{
+			Scopes: config.JWT.RequiredScopes,
+		})
+	}
+
+	// Compare fixed-length digests so neither the key contents nor their
+	// lengths leak through timing.
+	keyDigests := make([][32]byte, len(config.ApiKeys))
+	for i, key := range config.ApiKeys {
+		keyDigests[i] = sha256.Sum256([]byte(key))
+	}
+	validAPIKey := func(key string) bool {
+		digest := sha256.Sum256([]byte(key))
+		valid := 0
+		for _, d := range keyDigests {
+			valid |= subtle.ConstantTimeCompare(digest[:], d[:])
+		}
+		return valid == 1
+	}
+
+	return func(next http.Handler) http.Handler {
+		var jwtHandler http.Handler
+		if requireJWT != nil {
+			jwtHandler = requireJWT(next)
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if key := r.Header.Get(apiKeyHeader); key != "" {
+				if len(keyDigests) > 0 && validAPIKey(key) {
+					next.ServeHTTP(w, r)
+					return
+				}
+				slog.WarnContext(r.Context(), "Rejected request with an invalid API key", "remote_addr", r.RemoteAddr)
+				http.Error(w, "invalid API key", http.StatusUnauthorized)
+				return
+			}
+			if jwtHandler != nil {
+				jwtHandler.ServeHTTP(w, r)
+				return
+			}
+			http.Error(w, fmt.Sprintf("missing %s header", apiKeyHeader), http.StatusUnauthorized)
+		})
+	}, nil
+}
+
+// isLoopbackAddress reports whether host only accepts connections from the
+// local machine.
+func isLoopbackAddress(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/auth"
+)
+
+func writeJWKS(t *testing.T, keys ...map[string]string) string {
+	t.Helper()
+	data, err := json.Marshal(map[string]any{"keys": keys})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func rsaJWK(kid string, key *rsa.PublicKey) map[string]string {
+	return map[string]string{
+		"kty": "RSA",
+		"kid": kid,
+		"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+func ecJWK(kid string, key *ecdsa.PublicKey) map[string]string {
+	return map[string]string{
+		"kty": "EC",
+		"kid": kid,
+		"crv": "P-256",
+		"x":   base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, 32))),
+		"y":   base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, 32))),
+	}
+}
+
+func signJWT(t *testing.T, alg, kid string, key crypto.Signer, claims map[string]any) string {
+	t.Helper()
+	header, _ := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signed))
+
+	var signature []byte
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		sig, err := rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		signature = sig
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, k, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		signature = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestJWTVerifier(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	otherKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	jwks := writeJWKS(t, rsaJWK("rsa", &rsaKey.PublicKey), ecJWK("ec", &ecKey.PublicKey))
+
+	verifier, err := newJWTVerifier(JWTAuthConfig{JWKSFile: jwks, Issuer: "https://idp", Audience: "chip"})
+	if err != nil {
+		t.Fatalf("newJWTVerifier: %v", err)
+	}
+
+	exp := time.Now().Add(time.Hour).Unix()
+	valid := map[string]any{"iss": "https://idp", "aud": "chip", "sub": "alice", "exp": exp, "scope": "read write"}
+
+	cases := []struct {
+		name  string
+		token string
+		ok    bool
+	}{
+		{"RS256", signJWT(t, "RS256", "rsa", rsaKey, valid), true},
+		{"ES256", signJWT(t, "ES256", "ec", ecKey, valid), true},
+		{"audience array", signJWT(t, "RS256", "rsa", rsaKey, map[string]any{"iss": "https://idp", "aud": []string{"other", "chip"}, "exp": exp}), true},
+		{"wrong signing key", signJWT(t, "RS256", "rsa", otherKey, valid), false},
+		{"unknown kid", signJWT(t, "RS256", "nope", rsaKey, valid), false},
+		{"no kid", signJWT(t, "RS256", "", rsaKey, valid), false},
+		{"algorithm of another key type", signJWT(t, "ES256", "rsa", ecKey, valid), false},
+		{"no audience", signJWT(t, "RS256", "rsa", rsaKey, map[string]any{"iss": "https://idp", "exp": exp}), false},
+		{"wrong issuer", signJWT(t, "RS256", "rsa", rsaKey, map[string]any{"iss": "https://evil", "aud": "chip", "exp": exp}), false},
+		{"wrong audience", signJWT(t, "RS256", "rsa", rsaKey, map[string]any{"iss": "https://idp", "aud": "other", "exp": exp}), false},
+		{"expired", signJWT(t, "RS256", "rsa", rsaKey, map[string]any{"iss": "https://idp", "aud": "chip", "exp": time.Now().Add(-time.Hour).Unix()}), false},
+		{"missing expiration", signJWT(t, "RS256", "rsa", rsaKey, map[string]any{"iss": "https://idp", "aud": "chip"}), false},
+		{"malformed", "not-a-jwt", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			info, err := verifier.Verify(t.Context(), tc.token, nil)
+			if tc.ok {
+				if err != nil {
+					t.Fatalf("expected token to verify, got %v", err)
+				}
+				if tc.name == "RS256" && (info.UserID != "alice" || len(info.Scopes) != 2) {
+					t.Fatalf("unexpected token info: %+v", info)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("expected verification to fail")
+			}
+		})
+	}
+}
+
+func TestNewJWTVerifier_RejectsWeakOrAmbiguousKeys(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	smallKey, _ := rsa.GenerateKey(rand.Reader, 1024)
+	evenExponent := rsaJWK("rsa", &rsaKey.PublicKey)
+	evenExponent["e"] = base64.RawURLEncoding.EncodeToString(big.NewInt(65536).Bytes())
+
+	cases := []struct {
+		name   string
+		config JWTAuthConfig
+	}{
+		{"no audience", JWTAuthConfig{JWKSFile: writeJWKS(t, rsaJWK("rsa", &rsaKey.PublicKey))}},
+		{"RSA key under 2048 bits", JWTAuthConfig{JWKSFile: writeJWKS(t, rsaJWK("small", &smallKey.PublicKey)), Audience: "chip"}},
+		{"even RSA exponent", JWTAuthConfig{JWKSFile: writeJWKS(t, evenExponent), Audience: "chip"}},
+		{"key without kid", JWTAuthConfig{JWKSFile: writeJWKS(t, rsaJWK("", &rsaKey.PublicKey)), Audience: "chip"}},
+		{"duplicate kid", JWTAuthConfig{JWKSFile: writeJWKS(t, rsaJWK("rsa", &rsaKey.PublicKey), rsaJWK("rsa", &rsaKey.PublicKey)), Audience: "chip"}},
+		{"symmetric key", JWTAuthConfig{JWKSFile: writeJWKS(t, map[string]string{"kty": "oct", "kid": "hmac", "k": "c2VjcmV0"}), Audience: "chip"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := newJWTVerifier(tc.config); err == nil {
+				t.Error("expected the configuration to be rejected")
+			}
+		})
+	}
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	jwks := writeJWKS(t, rsaJWK("rsa", &rsaKey.PublicKey))
+	middleware, err := newAuthMiddleware(HttpAuthConfig{
+		ApiKeys: []string{"secret-key"},
+		JWT:     JWTAuthConfig{JWKSFile: jwks, Audience: "chip"},
+	})
+	if err != nil {
+		t.Fatalf("newAuthMiddleware: %v", err)
+	}
+
+	var reached *http.Request
+	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reached = r
+	}))
+	token := signJWT(t, "RS256", "rsa", rsaKey, map[string]any{"sub": "bob", "aud": "chip", "exp": time.Now().Add(time.Hour).Unix()})
+
+	cases := []struct {
+		name   string
+		header string
+		value  string
+		status int
+	}{
+		{"valid API key", apiKeyHeader, "secret-key", http.StatusOK},
+		{"invalid API key", apiKeyHeader, "wrong", http.StatusUnauthorized},
+		{"valid JWT", "Authorization", "Bearer " + token, http.StatusOK},
+		{"invalid JWT", "Authorization", "Bearer " + token + "x", http.StatusUnauthorized},
+		{"basic auth only", "Authorization", "Basic dXNlcjpwYXNz", http.StatusUnauthorized},
+		{"no credentials", "", "", http.StatusUnauthorized},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reached = nil
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			if tc.header != "" {
+				req.Header.Set(tc.header, tc.value)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tc.status {
+				t.Fatalf("status = %d, want %d", rec.Code, tc.status)
+			}
+			if tc.status != http.StatusOK && reached != nil {
+				t.Fatal("rejected request reached the MCP handler")
+			}
+			if tc.name == "valid JWT" {
+				if info := auth.TokenInfoFromContext(reached.Context()); info == nil || info.UserID != "bob" {
+					t.Fatalf("expected token info for bob on the request context, got %+v", info)
+				}
+			}
+		})
+	}
+}
+
+func TestNewAuthMiddleware_DisabledWithoutConfig(t *testing.T) {
+	middleware, err := newAuthMiddleware(HttpAuthConfig{})
+	if err != nil {
+		t.Fatalf("newAuthMiddleware: %v", err)
+	}
+	if middleware != nil {
+		t.Fatal("expected no middleware when inbound auth is not configured")
+	}
+}
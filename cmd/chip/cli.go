@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/collibra/chip/pkg/chip"
+	"github.com/collibra/chip/pkg/dryrun"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/spf13/pflag"
+)
+
+// Commands that call chip's tools from the command line instead of serving
+// them over MCP.
+const (
+	callCommand        = "call"
+	toolsListCommand   = "tools list"
+	toolsSchemaCommand = "tools schema"
+)
+
+// errToolFailed reports a tool call whose result is an error. The error
+// itself has already been printed.
+var errToolFailed = errors.New("tool call failed")
+
+// cliCommand returns the command chip is started with, or "" to serve MCP.
+// The command words are removed from os.Args, so the flags around them are
+// parsed as usual.
+func cliCommand() string {
+	if len(os.Args) < 2 {
+		return ""
+	}
+	command := ""
+	words := 1
+	switch os.Args[1] {
+	case callCommand:
+		command = callCommand
+	case "tools":
+		if len(os.Args) > 2 && (os.Args[2] == "list" || os.Args[2] == "schema") {
+			command, words = "tools "+os.Args[2], 2
+		}
+	}
+	if command != "" {
+		os.Args = append(os.Args[:1], os.Args[1+words:]...)
+	}
+	return command
+}
+
+// callFlags defines the flags of 'chip call' on a flag set of their own. They
+// are added to chip's flags for that command only, since it reads chip's
+// configuration as well.
+func callFlags(input *string) *pflag.FlagSet {
+	flags := pflag.NewFlagSet(callCommand, pflag.ContinueOnError)
+	flags.StringVar(input, "input", *input, "JSON object with the arguments of the tool, or - to read it from stdin")
+	return flags
+}
+
+// runCommand runs command against the tools registered on server, connecting
+// an in-process MCP client so calls go through the same validation and
+// middleware chain as calls from an agent. Results are written to stdout as
+// JSON; failures of the tool go to stderr.
+func runCommand(ctx context.Context, server *chip.Server, command string, args []string, input string, stdout, stderr io.Writer) error {
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = serverSession.Close() }()
+	client := mcp.NewClient(&mcp.Implementation{Name: "chip-cli", Version: chip.Version}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = session.Close() }()
+
+	switch command {
+	case callCommand:
+		if len(args) != 1 {
+			return fmt.Errorf("usage: chip call <tool> [--input '{...}']")
+		}
+		return callTool(ctx, session, args[0], input, stdout, stderr)
+	case toolsListCommand:
+		var list []map[string]any
+		for tool, err := range session.Tools(ctx, nil) {
+			if err != nil {
+				return err
+			}
+			list = append(list, map[string]any{
+				"name":        tool.Name,
+				"title":       tool.Title,
+				"description": tool.Description,
+				"readOnly":    tool.Annotations != nil && tool.Annotations.ReadOnlyHint,
+			})
+		}
+		return writeJSONTo(stdout, list)
+	case toolsSchemaCommand:
+		if len(args) != 1 {
+			return fmt.Errorf("usage: chip tools schema <tool>")
+		}
+		for tool, err := range session.Tools(ctx, nil) {
+			if err != nil {
+				return err
+			}
+			if tool.Name == args[0] {
+				return writeJSONTo(stdout, map[string]any{
+					"name":         tool.Name,
+					"inputSchema":  tool.InputSchema,
+					"outputSchema": tool.OutputSchema,
+				})
+			}
+		}
+		return fmt.Errorf("unknown tool: %s", args[0])
+	}
+	return fmt.Errorf("unknown command: %s", command)
+}
+
+// callTool calls the tool with the JSON object input, "-" reading it from
+// stdin, and prints its structured output.
+func callTool(ctx context.Context, session *mcp.ClientSession, name, input string, stdout, stderr io.Writer) error {
+	if input == "-" {
+		stdin, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("failed to read the input: %w", err)
+		}
+		input = string(stdin)
+	}
+	var arguments map[string]any
+	if err := json.Unmarshal([]byte(input), &arguments); err != nil {
+		return fmt.Errorf("the input must be a JSON object: %w", err)
+	}
+	res, err := session.CallTool(ctx, &mcp.CallToolParams{Name: name, Arguments: arguments})
+	if err != nil {
+		return err
+	}
+	if res.IsError {
+		for _, content := range res.Content {
+			if text, ok := content.(*mcp.TextContent); ok {
+				_, _ = fmt.Fprintln(stderr, text.Text)
+			}
+		}
+		return errToolFailed
+	}
+	if _, ok := res.Meta[dryrun.MetaKey]; ok && len(res.Content) > 0 {
+		if notice, ok := res.Content[len(res.Content)-1].(*mcp.TextContent); ok {
+			_, _ = fmt.Fprintln(stderr, notice.Text)
+		}
+	}
+	return writeJSONTo(stdout, res.StructuredContent)
+}
+
+func writeJSONTo(w io.Writer, value any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/collibra/chip/pkg/chip"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/spf13/pflag"
+)
+
+type echoInput struct {
+	Name string `json:"name"`
+}
+
+type echoOutput struct {
+	Greeting string `json:"greeting"`
+}
+
+func cliServer() *chip.Server {
+	server := chip.NewServer()
+	chip.RegisterTool(server, &chip.Tool[echoInput, echoOutput]{
+		Name:        "greet",
+		Description: "Greets.",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true},
+		Handler: func(_ context.Context, input echoInput) (echoOutput, error) {
+			if input.Name == "" {
+				return echoOutput{}, errors.New("no name")
+			}
+			return echoOutput{Greeting: "hello " + input.Name}, nil
+		},
+	})
+	return server
+}
+
+func TestRunCommand(t *testing.T) {
+	tests := []struct {
+		command, input string
+		args           []string
+		stdout, stderr string
+		err            error
+	}{
+		{command: callCommand, args: []string{"greet"}, input: `{"name":"ada"}`, stdout: `"greeting": "hello ada"`},
+		{command: callCommand, args: []string{"greet"}, input: `{"name":""}`, stderr: "[internal] no name", err: errToolFailed},
+		{command: toolsListCommand, stdout: `"readOnly": true`},
+		{command: toolsSchemaCommand, args: []string{"greet"}, stdout: `"outputSchema"`},
+	}
+	for _, tt := range tests {
+		var stdout, stderr bytes.Buffer
+		err := runCommand(t.Context(), cliServer(), tt.command, tt.args, tt.input, &stdout, &stderr)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("%s: expected %v, got %v", tt.command, tt.err, err)
+		}
+		if !strings.Contains(stdout.String(), tt.stdout) || !strings.Contains(stderr.String(), tt.stderr) {
+			t.Errorf("%s: unexpected output %q, errors %q", tt.command, stdout.String(), stderr.String())
+		}
+	}
+
+	err := runCommand(t.Context(), cliServer(), callCommand, []string{"greet"}, `["ada"]`, &bytes.Buffer{}, &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "JSON object") {
+		t.Errorf("expected the input to be rejected, got %v", err)
+	}
+}
+
+func TestCliCommand_RemovesTheCommandWords(t *testing.T) {
+	defer func(args []string) { os.Args = args }(os.Args)
+	os.Args = []string{"chip", "tools", "schema", "greet", "--api-url", "https://example.com"}
+
+	if command := cliCommand(); command != toolsSchemaCommand {
+		t.Errorf("expected %q, got %q", toolsSchemaCommand, command)
+	}
+	if strings.Join(os.Args, " ") != "chip greet --api-url https://example.com" {
+		t.Errorf("unexpected arguments %v", os.Args)
+	}
+}
+
+func TestCallFlags(t *testing.T) {
+	if pflag.Lookup("input") != nil {
+		t.Error("expected --input not to be one of chip's own flags")
+	}
+	input := "{}"
+	if err := callFlags(&input).Parse([]string{"--input", "-"}); err != nil {
+		t.Fatal(err)
+	}
+	if input != "-" {
+		t.Errorf("expected --input to be parsed, got %q", input)
+	}
+}
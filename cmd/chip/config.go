@@ -3,10 +3,18 @@ package main
 import (
 	"fmt"
 	"log/slog"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/collibra/chip/pkg/audit"
 	"github.com/collibra/chip/pkg/chip"
+	"github.com/collibra/chip/pkg/clients"
+	"github.com/collibra/chip/pkg/ratelimit"
+	"github.com/collibra/chip/pkg/tracing"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
@@ -65,12 +73,69 @@ func initConfigOptions() {
 	_ = viper.BindPFlag("api.skip-tls-verify", pflag.Lookup("skip-tls-verify"))
 	viper.SetDefault("api.skip-tls-verify", false)
 
+	pflag.String("api-oauth2-token-url", "", "OAuth2 token endpoint for the client-credentials grant; when set, chip obtains and refreshes bearer tokens itself (env: COLLIBRA_MCP_API_OAUTH2_TOKEN_URL)")
+	_ = viper.BindEnv("api.oauth2.token-url", "COLLIBRA_MCP_API_OAUTH2_TOKEN_URL")
+	_ = viper.BindPFlag("api.oauth2.token-url", pflag.Lookup("api-oauth2-token-url"))
+
+	pflag.String("api-oauth2-client-id", "", "OAuth2 client id (env: COLLIBRA_MCP_API_OAUTH2_CLIENT_ID)")
+	_ = viper.BindEnv("api.oauth2.client-id", "COLLIBRA_MCP_API_OAUTH2_CLIENT_ID")
+	_ = viper.BindPFlag("api.oauth2.client-id", pflag.Lookup("api-oauth2-client-id"))
+
+	pflag.String("api-oauth2-client-secret", "", "OAuth2 client secret (env: COLLIBRA_MCP_API_OAUTH2_CLIENT_SECRET)")
+	_ = viper.BindEnv("api.oauth2.client-secret", "COLLIBRA_MCP_API_OAUTH2_CLIENT_SECRET")
+	_ = viper.BindPFlag("api.oauth2.client-secret", pflag.Lookup("api-oauth2-client-secret"))
+
+	pflag.StringSlice("api-oauth2-scopes", []string{}, "Optional comma-separated list of OAuth2 scopes to request (env: COLLIBRA_MCP_API_OAUTH2_SCOPES)")
+	_ = viper.BindEnv("api.oauth2.scopes", "COLLIBRA_MCP_API_OAUTH2_SCOPES")
+	_ = viper.BindPFlag("api.oauth2.scopes", pflag.Lookup("api-oauth2-scopes"))
+
 	pflag.String("api-proxy", "", "HTTP proxy URL for API requests (env: COLLIBRA_MCP_API_PROXY, HTTP_PROXY, HTTPS_PROXY)")
 	_ = viper.BindEnv("api.proxy", "COLLIBRA_MCP_API_PROXY")
 	_ = viper.BindEnv("api.proxy", "HTTP_PROXY")  // For compatibility with DefaultTransport
 	_ = viper.BindEnv("api.proxy", "HTTPS_PROXY") // For compatibility with DefaultTransport
 	_ = viper.BindPFlag("api.proxy", pflag.Lookup("api-proxy"))
 
+	pflag.String("default-instance", "", "Collibra instance used by tool calls that do not select one; defaults to the api section (env: COLLIBRA_MCP_DEFAULT_INSTANCE)")
+	_ = viper.BindEnv("default-instance", "COLLIBRA_MCP_DEFAULT_INSTANCE")
+	_ = viper.BindPFlag("default-instance", pflag.Lookup("default-instance"))
+
+	pflag.Int("api-retry-max-attempts", clients.DefaultRetryPolicy.MaxAttempts, "Maximum attempts for idempotent Collibra requests failing with a transport error, 429 or 5xx; 1 disables retries (env: COLLIBRA_MCP_API_RETRY_MAX_ATTEMPTS)")
+	_ = viper.BindEnv("api.retry.max-attempts", "COLLIBRA_MCP_API_RETRY_MAX_ATTEMPTS")
+	_ = viper.BindPFlag("api.retry.max-attempts", pflag.Lookup("api-retry-max-attempts"))
+	viper.SetDefault("api.retry.max-attempts", clients.DefaultRetryPolicy.MaxAttempts)
+
+	pflag.Duration("api-retry-initial-backoff", clients.DefaultRetryPolicy.InitialBackoff, "Upper bound of the first jittered retry delay, doubled on each further attempt (env: COLLIBRA_MCP_API_RETRY_INITIAL_BACKOFF)")
+	_ = viper.BindEnv("api.retry.initial-backoff", "COLLIBRA_MCP_API_RETRY_INITIAL_BACKOFF")
+	_ = viper.BindPFlag("api.retry.initial-backoff", pflag.Lookup("api-retry-initial-backoff"))
+	viper.SetDefault("api.retry.initial-backoff", clients.DefaultRetryPolicy.InitialBackoff)
+
+	pflag.Duration("api-retry-max-backoff", clients.DefaultRetryPolicy.MaxBackoff, "Maximum delay between two attempts; a longer Retry-After is not waited for (env: COLLIBRA_MCP_API_RETRY_MAX_BACKOFF)")
+	_ = viper.BindEnv("api.retry.max-backoff", "COLLIBRA_MCP_API_RETRY_MAX_BACKOFF")
+	_ = viper.BindPFlag("api.retry.max-backoff", pflag.Lookup("api-retry-max-backoff"))
+	viper.SetDefault("api.retry.max-backoff", clients.DefaultRetryPolicy.MaxBackoff)
+
+	pflag.Float64("api-rate-limit", 0, "Maximum requests per second sent to Collibra across all tools, 0 means unlimited (env: COLLIBRA_MCP_API_RATE_LIMIT_REQUESTS_PER_SECOND)")
+	_ = viper.BindEnv("api.rate-limit.requests-per-second", "COLLIBRA_MCP_API_RATE_LIMIT_REQUESTS_PER_SECOND")
+	_ = viper.BindPFlag("api.rate-limit.requests-per-second", pflag.Lookup("api-rate-limit"))
+
+	pflag.Int("api-rate-limit-burst", 0, "Requests that may be sent at once before the rate limit applies, defaults to one second's worth (env: COLLIBRA_MCP_API_RATE_LIMIT_BURST)")
+	_ = viper.BindEnv("api.rate-limit.burst", "COLLIBRA_MCP_API_RATE_LIMIT_BURST")
+	_ = viper.BindPFlag("api.rate-limit.burst", pflag.Lookup("api-rate-limit-burst"))
+
+	pflag.Int("api-max-in-flight", 0, "Maximum concurrent requests to Collibra across all tools, 0 means unlimited (env: COLLIBRA_MCP_API_RATE_LIMIT_MAX_IN_FLIGHT)")
+	_ = viper.BindEnv("api.rate-limit.max-in-flight", "COLLIBRA_MCP_API_RATE_LIMIT_MAX_IN_FLIGHT")
+	_ = viper.BindPFlag("api.rate-limit.max-in-flight", pflag.Lookup("api-max-in-flight"))
+
+	pflag.Duration("api-metamodel-cache-ttl", clients.DefaultMetamodelCacheConfig.TTL, "How long metamodel lookups (statuses, roles, asset/domain/attribute/relation types) are cached, 0 disables the cache (env: COLLIBRA_MCP_API_METAMODEL_CACHE_TTL)")
+	_ = viper.BindEnv("api.metamodel-cache.ttl", "COLLIBRA_MCP_API_METAMODEL_CACHE_TTL")
+	_ = viper.BindPFlag("api.metamodel-cache.ttl", pflag.Lookup("api-metamodel-cache-ttl"))
+	viper.SetDefault("api.metamodel-cache.ttl", clients.DefaultMetamodelCacheConfig.TTL)
+
+	pflag.Int("api-metamodel-cache-max-entries", clients.DefaultMetamodelCacheConfig.MaxEntries, "Maximum number of cached metamodel lookups, 0 means unbounded (env: COLLIBRA_MCP_API_METAMODEL_CACHE_MAX_ENTRIES)")
+	_ = viper.BindEnv("api.metamodel-cache.max-entries", "COLLIBRA_MCP_API_METAMODEL_CACHE_MAX_ENTRIES")
+	_ = viper.BindPFlag("api.metamodel-cache.max-entries", pflag.Lookup("api-metamodel-cache-max-entries"))
+	viper.SetDefault("api.metamodel-cache.max-entries", clients.DefaultMetamodelCacheConfig.MaxEntries)
+
 	pflag.String("mode", "stdio", "MCP server mode: 'stdio', 'http', 'http-sse', or 'http-streamable' (env: COLLIBRA_MCP_MODE)")
 	_ = viper.BindEnv("mcp.mode", "COLLIBRA_MCP_MODE")
 	_ = viper.BindPFlag("mcp.mode", pflag.Lookup("mode"))
@@ -81,11 +146,99 @@ func initConfigOptions() {
 	_ = viper.BindPFlag("mcp.http.port", pflag.Lookup("port"))
 	viper.SetDefault("mcp.http.port", 8080)
 
-	pflag.StringSlice("enabled-tools", []string{}, "Optional comma-separated list of tool names to enable instead of enabling all tools (cannot be used with disabled-tools) (env: COLLIBRA_MCP_ENABLED_TOOLS)")
+	pflag.String("bind-address", "localhost", "HTTP server bind address (only used in http mode); a non-loopback address requires inbound authentication (env: COLLIBRA_MCP_HTTP_BIND_ADDRESS)")
+	_ = viper.BindEnv("mcp.http.bind-address", "COLLIBRA_MCP_HTTP_BIND_ADDRESS")
+	_ = viper.BindPFlag("mcp.http.bind-address", pflag.Lookup("bind-address"))
+	viper.SetDefault("mcp.http.bind-address", "localhost")
+
+	pflag.Duration("shutdown-timeout", 30*time.Second, "How long in-flight requests may take to finish on SIGTERM before the HTTP server exits (only used in http mode) (env: COLLIBRA_MCP_HTTP_SHUTDOWN_TIMEOUT)")
+	_ = viper.BindEnv("mcp.http.shutdown-timeout", "COLLIBRA_MCP_HTTP_SHUTDOWN_TIMEOUT")
+	_ = viper.BindPFlag("mcp.http.shutdown-timeout", pflag.Lookup("shutdown-timeout"))
+	viper.SetDefault("mcp.http.shutdown-timeout", 30*time.Second)
+
+	pflag.Bool("stateful", false, "Keep a session per client across requests in http mode, identified by the Mcp-Session-Id header, instead of serving each request on its own (env: COLLIBRA_MCP_HTTP_STATEFUL)")
+	_ = viper.BindEnv("mcp.http.stateful", "COLLIBRA_MCP_HTTP_STATEFUL")
+	_ = viper.BindPFlag("mcp.http.stateful", pflag.Lookup("stateful"))
+	viper.SetDefault("mcp.http.stateful", false)
+
+	pflag.Duration("session-timeout", 30*time.Minute, "End HTTP sessions idle for this long, 0 keeps them until the client ends them (only used with --stateful and in http-sse mode) (env: COLLIBRA_MCP_HTTP_SESSION_TIMEOUT)")
+	_ = viper.BindEnv("mcp.http.session-timeout", "COLLIBRA_MCP_HTTP_SESSION_TIMEOUT")
+	_ = viper.BindPFlag("mcp.http.session-timeout", pflag.Lookup("session-timeout"))
+	viper.SetDefault("mcp.http.session-timeout", 30*time.Minute)
+
+	pflag.String("tls-cert", "", "Path to a PEM certificate; when set together with --tls-key, the HTTP server terminates TLS itself (env: COLLIBRA_MCP_HTTP_TLS_CERT)")
+	_ = viper.BindEnv("mcp.http.tls.cert", "COLLIBRA_MCP_HTTP_TLS_CERT")
+	_ = viper.BindPFlag("mcp.http.tls.cert", pflag.Lookup("tls-cert"))
+
+	pflag.String("tls-key", "", "Path to the PEM private key for --tls-cert (env: COLLIBRA_MCP_HTTP_TLS_KEY)")
+	_ = viper.BindEnv("mcp.http.tls.key", "COLLIBRA_MCP_HTTP_TLS_KEY")
+	_ = viper.BindPFlag("mcp.http.tls.key", pflag.Lookup("tls-key"))
+
+	pflag.String("tls-client-ca", "", "Optional path to a PEM CA bundle; when set, clients must present a certificate signed by it (mutual TLS) (env: COLLIBRA_MCP_HTTP_TLS_CLIENT_CA)")
+	_ = viper.BindEnv("mcp.http.tls.client-ca", "COLLIBRA_MCP_HTTP_TLS_CLIENT_CA")
+	_ = viper.BindPFlag("mcp.http.tls.client-ca", pflag.Lookup("tls-client-ca"))
+
+	// API keys are deliberately not exposed as a flag so they never show up
+	// in process listings.
+	_ = viper.BindEnv("mcp.http.auth.api-keys", "COLLIBRA_MCP_HTTP_AUTH_API_KEYS")
+
+	pflag.String("http-auth-jwks-file", "", "Path to a JWKS file used to validate bearer JWTs on inbound HTTP requests (env: COLLIBRA_MCP_HTTP_AUTH_JWKS_FILE)")
+	_ = viper.BindEnv("mcp.http.auth.jwt.jwks-file", "COLLIBRA_MCP_HTTP_AUTH_JWKS_FILE")
+	_ = viper.BindPFlag("mcp.http.auth.jwt.jwks-file", pflag.Lookup("http-auth-jwks-file"))
+
+	pflag.String("http-auth-jwt-issuer", "", "Required issuer (iss) of inbound bearer JWTs (env: COLLIBRA_MCP_HTTP_AUTH_JWT_ISSUER)")
+	_ = viper.BindEnv("mcp.http.auth.jwt.issuer", "COLLIBRA_MCP_HTTP_AUTH_JWT_ISSUER")
+	_ = viper.BindPFlag("mcp.http.auth.jwt.issuer", pflag.Lookup("http-auth-jwt-issuer"))
+
+	pflag.String("http-auth-jwt-audience", "", "Required audience (aud) of inbound bearer JWTs (env: COLLIBRA_MCP_HTTP_AUTH_JWT_AUDIENCE)")
+	_ = viper.BindEnv("mcp.http.auth.jwt.audience", "COLLIBRA_MCP_HTTP_AUTH_JWT_AUDIENCE")
+	_ = viper.BindPFlag("mcp.http.auth.jwt.audience", pflag.Lookup("http-auth-jwt-audience"))
+
+	pflag.Bool("metrics", false, "Collect Prometheus metrics and serve them at /metrics in http mode (env: COLLIBRA_MCP_METRICS_ENABLED)")
+	_ = viper.BindEnv("mcp.metrics.enabled", "COLLIBRA_MCP_METRICS_ENABLED")
+	_ = viper.BindPFlag("mcp.metrics.enabled", pflag.Lookup("metrics"))
+	viper.SetDefault("mcp.metrics.enabled", false)
+
+	pflag.String("metrics-listen", "", "Optional address (e.g. localhost:9090) of a dedicated listener serving /metrics; use this to expose metrics in stdio mode. Implies --metrics (env: COLLIBRA_MCP_METRICS_LISTEN)")
+	_ = viper.BindEnv("mcp.metrics.listen", "COLLIBRA_MCP_METRICS_LISTEN")
+	_ = viper.BindPFlag("mcp.metrics.listen", pflag.Lookup("metrics-listen"))
+
+	pflag.String("tracing-exporter", "none", "OpenTelemetry trace exporter: 'none', 'otlp' or 'file' (env: COLLIBRA_MCP_TRACING_EXPORTER)")
+	_ = viper.BindEnv("mcp.tracing.exporter", "COLLIBRA_MCP_TRACING_EXPORTER")
+	_ = viper.BindPFlag("mcp.tracing.exporter", pflag.Lookup("tracing-exporter"))
+	viper.SetDefault("mcp.tracing.exporter", "none")
+
+	pflag.String("tracing-endpoint", "", "OTLP/HTTP traces URL, e.g. http://localhost:4318/v1/traces; defaults to the OTEL_EXPORTER_OTLP_* environment variables (env: COLLIBRA_MCP_TRACING_ENDPOINT)")
+	_ = viper.BindEnv("mcp.tracing.endpoint", "COLLIBRA_MCP_TRACING_ENDPOINT")
+	_ = viper.BindPFlag("mcp.tracing.endpoint", pflag.Lookup("tracing-endpoint"))
+
+	pflag.String("tracing-file", "", "File that spans are appended to as JSON with --tracing-exporter=file (env: COLLIBRA_MCP_TRACING_FILE)")
+	_ = viper.BindEnv("mcp.tracing.file", "COLLIBRA_MCP_TRACING_FILE")
+	_ = viper.BindPFlag("mcp.tracing.file", pflag.Lookup("tracing-file"))
+
+	pflag.String("audit-output", "", "Record calls to write tools as JSON lines in this file, or 'stdout' in http mode (env: COLLIBRA_MCP_AUDIT_OUTPUT)")
+	_ = viper.BindEnv("mcp.audit.output", "COLLIBRA_MCP_AUDIT_OUTPUT")
+	_ = viper.BindPFlag("mcp.audit.output", pflag.Lookup("audit-output"))
+
+	pflag.Int("audit-max-size-mb", 100, "Rotate the audit file once it reaches this size in megabytes, 0 disables rotation (env: COLLIBRA_MCP_AUDIT_MAX_SIZE_MB)")
+	_ = viper.BindEnv("mcp.audit.max-size-mb", "COLLIBRA_MCP_AUDIT_MAX_SIZE_MB")
+	_ = viper.BindPFlag("mcp.audit.max-size-mb", pflag.Lookup("audit-max-size-mb"))
+	viper.SetDefault("mcp.audit.max-size-mb", 100)
+
+	pflag.Int("audit-max-backups", 5, "Number of rotated audit files to keep (env: COLLIBRA_MCP_AUDIT_MAX_BACKUPS)")
+	_ = viper.BindEnv("mcp.audit.max-backups", "COLLIBRA_MCP_AUDIT_MAX_BACKUPS")
+	_ = viper.BindPFlag("mcp.audit.max-backups", pflag.Lookup("audit-max-backups"))
+	viper.SetDefault("mcp.audit.max-backups", 5)
+
+	pflag.StringSlice("audit-redact", []string{}, "Optional comma-separated list of argument field names whose values are redacted in audit records (env: COLLIBRA_MCP_AUDIT_REDACT)")
+	_ = viper.BindEnv("mcp.audit.redact", "COLLIBRA_MCP_AUDIT_REDACT")
+	_ = viper.BindPFlag("mcp.audit.redact", pflag.Lookup("audit-redact"))
+
+	pflag.StringSlice("enabled-tools", []string{}, "Optional comma-separated list of tool names, tool groups or glob patterns to enable instead of enabling all tools (cannot be used with disabled-tools) (env: COLLIBRA_MCP_ENABLED_TOOLS). See TOOL GROUPS below.")
 	_ = viper.BindEnv("mcp.enabled-tools", "COLLIBRA_MCP_ENABLED_TOOLS")
 	_ = viper.BindPFlag("mcp.enabled-tools", pflag.Lookup("enabled-tools"))
 
-	pflag.StringSlice("disabled-tools", []string{}, "Optional comma-separated list of tool names to disable while enabling the remaining tools (cannot be used with enabled-tools) (env: COLLIBRA_MCP_DISABLED_TOOLS)")
+	pflag.StringSlice("disabled-tools", []string{}, "Optional comma-separated list of tool names, tool groups or glob patterns to disable while enabling the remaining tools (cannot be used with enabled-tools) (env: COLLIBRA_MCP_DISABLED_TOOLS). See TOOL GROUPS below.")
 	_ = viper.BindEnv("mcp.disabled-tools", "COLLIBRA_MCP_DISABLED_TOOLS")
 	_ = viper.BindPFlag("mcp.disabled-tools", pflag.Lookup("disabled-tools"))
 
@@ -94,6 +247,26 @@ func initConfigOptions() {
 	_ = viper.BindPFlag("mcp.enable-debug-tools", pflag.Lookup("enable-debug-tools"))
 	viper.SetDefault("mcp.enable-debug-tools", false)
 
+	pflag.Bool("read-only", false, "Only register read-only tools and refuse upstream requests that could change the catalog; takes effect on restart (env: COLLIBRA_MCP_READ_ONLY)")
+	_ = viper.BindEnv("mcp.read-only", "COLLIBRA_MCP_READ_ONLY")
+	_ = viper.BindPFlag("mcp.read-only", pflag.Lookup("read-only"))
+	viper.SetDefault("mcp.read-only", false)
+
+	pflag.Bool("dry-run", false, "Do not send requests that could change the catalog: answer them with a synthetic success and list them in the tool result (env: COLLIBRA_MCP_DRY_RUN)")
+	_ = viper.BindEnv("mcp.dry-run", "COLLIBRA_MCP_DRY_RUN")
+	_ = viper.BindPFlag("mcp.dry-run", pflag.Lookup("dry-run"))
+	viper.SetDefault("mcp.dry-run", false)
+
+	pflag.Bool("check-permissions", false, "Check the caller's Collibra global permissions before calling a tool, and only list the tools the caller can use in stdio, SSE and stateful http sessions (env: COLLIBRA_MCP_CHECK_PERMISSIONS)")
+	_ = viper.BindEnv("mcp.check-permissions", "COLLIBRA_MCP_CHECK_PERMISSIONS")
+	_ = viper.BindPFlag("mcp.check-permissions", pflag.Lookup("check-permissions"))
+	viper.SetDefault("mcp.check-permissions", false)
+
+	pflag.Bool("watch-config", true, "Apply changes to the tool settings of the config file (enabled-tools, disabled-tools, enable-debug-tools, experimental, skills-dir) without a restart (env: COLLIBRA_MCP_WATCH_CONFIG)")
+	_ = viper.BindEnv("mcp.watch-config", "COLLIBRA_MCP_WATCH_CONFIG")
+	_ = viper.BindPFlag("mcp.watch-config", pflag.Lookup("watch-config"))
+	viper.SetDefault("mcp.watch-config", true)
+
 	pflag.StringSlice("experimental", []string{}, "Comma-separated list of opt-in experimental features to enable (env: COLLIBRA_MCP_EXPERIMENTAL). See EXPERIMENTAL FEATURES below for valid names.")
 	_ = viper.BindEnv("mcp.experimental", "COLLIBRA_MCP_EXPERIMENTAL")
 	_ = viper.BindPFlag("mcp.experimental", pflag.Lookup("experimental"))
@@ -109,7 +282,21 @@ func printUsage(version string) {
 A Model Context Protocol (MCP) server that provides tools for interacting with Collibra.
 
 USAGE:
-  %s [flags]
+  %[2]s [flags]
+  %[2]s call <tool> [--input '{...}'] [flags]
+  %[2]s tools list [flags]
+  %[2]s tools schema <tool> [flags]
+
+COMMANDS:
+  Without a command, chip serves its tools over MCP. The commands use the same
+  configuration to run the tools from scripts and CI, and print JSON to stdout.
+  call <tool>          Call a tool with the arguments given by --input and print
+                       its output; exits with 1 when the call fails
+  tools list           List the enabled tools
+  tools schema <tool>  Print the input and output schema of a tool
+  fake-collibra        Serve an in-memory Collibra for local testing, seeded
+                       from --fixture (default: a small built-in catalog) on
+                       --listen (default: localhost:8081)
 
 FLAGS:
 `, version, os.Args[0])
@@ -119,18 +306,60 @@ ENVIRONMENT VARIABLES:
   COLLIBRA_MCP_API_URL          Collibra API URL
   COLLIBRA_MCP_API_USR          Collibra API username
   COLLIBRA_MCP_API_PWD          Collibra API password
+  COLLIBRA_MCP_API_OAUTH2_TOKEN_URL      OAuth2 token endpoint (client-credentials grant)
+  COLLIBRA_MCP_API_OAUTH2_CLIENT_ID      OAuth2 client id
+  COLLIBRA_MCP_API_OAUTH2_CLIENT_SECRET  OAuth2 client secret
+  COLLIBRA_MCP_API_OAUTH2_SCOPES         Optional comma-separated list of OAuth2 scopes
   COLLIBRA_MCP_API_SKIP_TLS_VERIFY  Skip TLS certificate verification (default: false)
   COLLIBRA_MCP_API_PROXY        HTTP proxy URL for API requests
   HTTP_PROXY                    HTTP proxy URL (alternative to COLLIBRA_MCP_API_PROXY)
   HTTPS_PROXY                   HTTPS proxy URL (alternative to COLLIBRA_MCP_API_PROXY)
+  COLLIBRA_MCP_DEFAULT_INSTANCE Collibra instance used by tool calls that do not select one (default: the api section)
+  COLLIBRA_MCP_API_RETRY_MAX_ATTEMPTS  Maximum attempts for idempotent requests failing with a transport error, 429 or 5xx (default: 3)
+  COLLIBRA_MCP_API_RETRY_INITIAL_BACKOFF  Upper bound of the first jittered retry delay (default: 500ms)
+  COLLIBRA_MCP_API_RETRY_MAX_BACKOFF  Maximum delay between attempts, also caps Retry-After (default: 10s)
+  COLLIBRA_MCP_API_RATE_LIMIT_REQUESTS_PER_SECOND  Maximum requests per second sent to Collibra (default: unlimited)
+  COLLIBRA_MCP_API_RATE_LIMIT_BURST  Requests that may be sent at once before the rate limit applies
+  COLLIBRA_MCP_API_RATE_LIMIT_MAX_IN_FLIGHT  Maximum concurrent requests to Collibra (default: unlimited)
+  COLLIBRA_MCP_API_METAMODEL_CACHE_TTL  How long metamodel lookups are cached, 0 disables the cache (default: 5m)
+  COLLIBRA_MCP_API_METAMODEL_CACHE_MAX_ENTRIES  Maximum number of cached metamodel lookups (default: 1000)
   COLLIBRA_MCP_MODE             Server mode: 'stdio', 'http', 'http-sse', or 'http-streamable' (default: stdio)
   COLLIBRA_MCP_HTTP_PORT        HTTP server port (default: 8080)
-  COLLIBRA_MCP_ENABLED_TOOLS    Optional comma-separated list of tool names to enable instead of enabling all tools, cannot be used with disabled-tools
-  COLLIBRA_MCP_DISABLED_TOOLS   Optional comma-separated list of tool names to disable while enabling the remaining tools, cannot be used with enabled-tools
+  COLLIBRA_MCP_HTTP_BIND_ADDRESS  HTTP server bind address (default: localhost); non-loopback addresses require inbound authentication
+  COLLIBRA_MCP_HTTP_SHUTDOWN_TIMEOUT  How long in-flight requests may take to finish on SIGTERM (default: 30s)
+  COLLIBRA_MCP_HTTP_STATEFUL    Keep a session per client across requests in http mode (default: false)
+  COLLIBRA_MCP_HTTP_SESSION_TIMEOUT  Close HTTP sessions idle for this long, 0 never closes them (default: 30m)
+  COLLIBRA_MCP_HTTP_TLS_CERT    Path to a PEM certificate for serving HTTPS (requires COLLIBRA_MCP_HTTP_TLS_KEY)
+  COLLIBRA_MCP_HTTP_TLS_KEY     Path to the PEM private key for the TLS certificate
+  COLLIBRA_MCP_HTTP_TLS_CLIENT_CA  Optional PEM CA bundle; when set, clients must present a certificate signed by it
+  COLLIBRA_MCP_HTTP_AUTH_API_KEYS  Comma-separated list of API keys accepted in the X-API-Key header of inbound HTTP requests
+  COLLIBRA_MCP_HTTP_AUTH_JWKS_FILE  Path to a JWKS file used to validate inbound bearer JWTs
+  COLLIBRA_MCP_HTTP_AUTH_JWT_ISSUER  Required issuer of inbound bearer JWTs
+  COLLIBRA_MCP_HTTP_AUTH_JWT_AUDIENCE  Required audience of inbound bearer JWTs
+  COLLIBRA_MCP_METRICS_ENABLED  Collect Prometheus metrics and serve them at /metrics in http mode (default: false)
+  COLLIBRA_MCP_METRICS_LISTEN   Optional address of a dedicated listener serving /metrics (e.g. localhost:9090), also usable in stdio mode
+  COLLIBRA_MCP_TRACING_EXPORTER OpenTelemetry trace exporter: 'none', 'otlp' or 'file' (default: none)
+  COLLIBRA_MCP_TRACING_ENDPOINT OTLP/HTTP traces URL (default: from OTEL_EXPORTER_OTLP_* variables)
+  COLLIBRA_MCP_TRACING_FILE     File that spans are appended to with the 'file' exporter
+  COLLIBRA_MCP_AUDIT_OUTPUT     Record calls to write tools as JSON lines in this file, or 'stdout' in http mode
+  COLLIBRA_MCP_AUDIT_MAX_SIZE_MB  Rotate the audit file once it reaches this size (default: 100)
+  COLLIBRA_MCP_AUDIT_MAX_BACKUPS  Number of rotated audit files to keep (default: 5)
+  COLLIBRA_MCP_AUDIT_REDACT     Comma-separated list of argument field names redacted in audit records
+  COLLIBRA_MCP_ENABLED_TOOLS    Optional comma-separated list of tool names, tool groups or glob patterns to enable instead of enabling all tools, cannot be used with disabled-tools
+  COLLIBRA_MCP_DISABLED_TOOLS   Optional comma-separated list of tool names, tool groups or glob patterns to disable while enabling the remaining tools, cannot be used with enabled-tools
   COLLIBRA_MCP_ENABLE_DEBUG_TOOLS  Enable debug tools (default: false)
+  COLLIBRA_MCP_READ_ONLY        Only expose read-only tools and refuse requests that could change the catalog (default: false)
+  COLLIBRA_MCP_DRY_RUN          Do not send requests that could change the catalog, report them in the tool result instead (default: false)
+  COLLIBRA_MCP_CHECK_PERMISSIONS  Check the caller's Collibra global permissions before calling a tool (default: false)
+  COLLIBRA_MCP_WATCH_CONFIG     Apply changes to the tool settings of the config file without a restart (default: true)
   COLLIBRA_MCP_EXPERIMENTAL     Comma-separated list of opt-in experimental features to enable (see EXPERIMENTAL FEATURES below)
   COLLIBRA_MCP_SKILLS_DIR       Optional path to an external skills directory merged on top of the embedded catalog (requires the 'skills' experimental feature)
 
+TOOL GROUPS:
+  Usable in enabled-tools and disabled-tools next to tool names and glob
+  patterns such as get_lineage_*.
+
+%s
 EXPERIMENTAL FEATURES:
   Opt-in via --experimental, COLLIBRA_MCP_EXPERIMENTAL, or mcp.experimental
   in the YAML config. Off by default. Unknown names log a warning but do
@@ -149,23 +378,86 @@ CONFIGURATION FILE EXAMPLE:
     url: "https://your-collibra-instance.com"
     username: "your-username"
     password: "your-password"
+    # oauth2:  # Optional: client-credentials grant instead of username/password
+    #   token-url: "https://your-collibra-instance.com/rest/oauth/v2/token"
+    #   client-id: "your-client-id"
+    #   client-secret: "your-client-secret"
+    #   scopes: []
     skip-tls-verify: false
     proxy: "http://proxy.example.com:8080"
+    # retry:  # Optional: retries of idempotent requests on transport errors, 429 and 5xx
+    #   max-attempts: 3  # 1 disables retries
+    #   initial-backoff: "500ms"
+    #   max-backoff: "10s"  # also the longest Retry-After that is honored
+    # rate-limit:  # Optional: client-side throttling, globally and per API path prefix
+    #   requests-per-second: 20
+    #   max-in-flight: 8
+    #   paths:
+    #     - prefix: "/rest/dq"
+    #       requests-per-second: 5
+    #       max-in-flight: 2
+    # metamodel-cache:  # Optional: cache of statuses, roles and type lookups per host and caller
+    #   ttl: "5m"  # 0 disables the cache
+    #   max-entries: 1000
+  # instances:  # Optional: further Collibra instances, selected per tool call (see docs/CONFIG.md)
+  #   staging:
+  #     url: "https://staging.your-collibra-instance.com"
+  #     skip-tls-verify: true
+  #   prod:
+  #     url: "https://prod.your-collibra-instance.com"
+  #     oauth2:
+  #       token-url: "https://prod.your-collibra-instance.com/rest/oauth/v2/token"
+  #       client-id: "your-client-id"
+  #       client-secret: "your-client-secret"
+  #     proxy: "http://proxy.example.com:8080"
+  # default-instance: "staging"  # Optional: defaults to the api section ("default")
   mcp:
     mode: "http"  # or "stdio", "http-sse", "http-streamable"
     http:
       port: 8080
-    enabled-tools:  # Optional: list of tools to enable (cannot be used with disabled-tools)
-      - "tool1"
-      - "tool2"
-    # disabled-tools:  # Optional: list of tools to disable (cannot be used with enabled-tools)
-    #   - "tool3"
+      # bind-address: "0.0.0.0"  # Optional: default localhost; non-loopback requires auth below (or tls.client-ca)
+      # shutdown-timeout: "30s"  # Optional: time in-flight requests get to finish on SIGTERM
+      # stateful: true  # Optional: keep a session per client across requests (default: false)
+      # session-timeout: "30m"  # Optional: close sessions idle for this long, 0 never closes them
+      # tls:  # Optional: serve HTTPS; files are reloaded when they change
+      #   cert: "/etc/collibra/tls.crt"
+      #   key: "/etc/collibra/tls.key"
+      #   client-ca: "/etc/collibra/clients-ca.crt"  # Optional: require client certificates (mTLS)
+      # auth:  # Optional: inbound authentication for the HTTP endpoint
+      #   api-keys:
+      #     - "a-long-random-key"
+      #   jwt:
+      #     jwks-file: "/etc/collibra/jwks.json"
+      #     issuer: "https://idp.example.com/"
+      #     audience: "chip"
+    # metrics:  # Optional: Prometheus metrics
+    #   enabled: true  # serve /metrics in http mode
+    #   listen: "localhost:9090"  # dedicated listener, also works in stdio mode
+    # tracing:  # Optional: OpenTelemetry tracing
+    #   exporter: "otlp"  # or "file", "none" (default)
+    #   endpoint: "http://localhost:4318/v1/traces"
+    #   file: "/var/log/chip/traces.jsonl"  # with exporter "file"
+    # audit:  # Optional: JSONL trail of calls to write tools
+    #   output: "/var/log/chip/audit.jsonl"  # or "stdout" in http mode
+    #   max-size-mb: 100
+    #   max-backups: 5
+    #   redact:
+    #     - "manifest"
+    enabled-tools:  # Optional: list of tools, tool groups or glob patterns to enable (cannot be used with disabled-tools)
+      - "discovery"
+      - "get_lineage_*"
+    # disabled-tools:  # Optional: list of tools, tool groups or glob patterns to disable (cannot be used with enabled-tools)
+    #   - "writes"
     #   - "tool4"
     enable-debug-tools: false  # Optional: enable debug tools (default: false)
+    # read-only: true  # Optional: only expose read-only tools and refuse requests that could change the catalog (default: false)
+    # dry-run: true  # Optional: do not send requests that could change the catalog, report them in the tool result instead (default: false)
+    # check-permissions: true  # Optional: check the caller's Collibra global permissions before calling a tool (default: false)
+    # watch-config: true  # Optional: apply changes to the tool settings above, experimental and skills-dir without a restart
     # experimental:  # Optional: opt-in experimental features (off by default)
     #   - "skills"
     # skills-dir: "/path/to/skills"  # Optional: external skills dir (requires the 'skills' experimental feature)
-`, formatExperimentalForHelp())
+`, formatToolGroupsForHelp(), formatExperimentalForHelp())
 }
 
 func validateConfigFile(config Config) {
@@ -179,9 +471,156 @@ func validateConfigFile(config Config) {
 		os.Exit(1)
 	}
 
+	validateInstances(config)
+	validateRetryConfig(config.Api.Retry)
+	validateRateLimitConfig(config.Api.RateLimit)
+	if config.Api.MetamodelCache.TTL < 0 || config.Api.MetamodelCache.MaxEntries < 0 {
+		slog.Error("Invalid api.metamodel-cache: ttl and max-entries must not be negative")
+		os.Exit(1)
+	}
+	validateHttpConfig(config.Mcp)
+	validateTracingConfig(config.Mcp.Tracing)
+	validateAuditConfig(config.Mcp)
 	validateExperimental(config.Mcp.Experimental)
 }
 
+func validateRetryConfig(retry RetryConfig) {
+	if retry.MaxAttempts < 1 {
+		slog.Error(fmt.Sprintf("Invalid api.retry.max-attempts: %d (must be at least 1)", retry.MaxAttempts))
+		os.Exit(1)
+	}
+	if retry.InitialBackoff < 0 || retry.MaxBackoff < retry.InitialBackoff {
+		slog.Error(fmt.Sprintf("Invalid api.retry backoff: initial-backoff %s and max-backoff %s must be non-negative with max-backoff >= initial-backoff", retry.InitialBackoff, retry.MaxBackoff))
+		os.Exit(1)
+	}
+}
+
+func validateRateLimitConfig(rateLimit RateLimitConfig) {
+	check := func(name string, limit RateLimit) {
+		if limit.RequestsPerSecond < 0 || limit.Burst < 0 || limit.MaxInFlight < 0 {
+			slog.Error(fmt.Sprintf("Invalid %s: requests-per-second, burst and max-in-flight must not be negative", name))
+			os.Exit(1)
+		}
+	}
+	check("api.rate-limit", rateLimit.RateLimit)
+	for _, p := range rateLimit.Paths {
+		if !strings.HasPrefix(p.Prefix, "/") {
+			slog.Error(fmt.Sprintf("Invalid api.rate-limit.paths prefix %q (must start with '/', e.g. '/rest/2.0')", p.Prefix))
+			os.Exit(1)
+		}
+		check(fmt.Sprintf("api.rate-limit.paths[%s]", p.Prefix), p.RateLimit)
+	}
+}
+
+func validateInstances(config Config) {
+	instances := config.collibraInstances()
+	if len(instances) == 0 {
+		slog.Error("Missing Api url")
+		os.Exit(1)
+	}
+	if _, ok := config.Instances[apiInstance]; ok && config.Api.Url != "" {
+		slog.Error(fmt.Sprintf("The instance name %q is reserved for the api section, rename instances.%s", apiInstance, apiInstance))
+		os.Exit(1)
+	}
+	validateOAuth2Config("api", config.Api.InstanceConfig)
+	for name, instance := range config.Instances {
+		key := "instances." + name
+		if instance.Url == "" {
+			slog.Error(fmt.Sprintf("Missing url for Collibra instance %s (%s.url)", name, key))
+			os.Exit(1)
+		}
+		validateOAuth2Config(key, instance)
+	}
+	defaultInstance := config.defaultCollibraInstance()
+	if defaultInstance == "" {
+		slog.Error("Several Collibra instances are configured without a default, set default-instance")
+		os.Exit(1)
+	}
+	if _, ok := instances[defaultInstance]; !ok {
+		slog.Error(fmt.Sprintf("Invalid default-instance: %s (must be one of %s)", defaultInstance, strings.Join(slices.Sorted(maps.Keys(instances)), ", ")))
+		os.Exit(1)
+	}
+}
+
+func validateOAuth2Config(key string, instance InstanceConfig) {
+	if !instance.OAuth2.Enabled() {
+		if instance.OAuth2.ClientID != "" || instance.OAuth2.ClientSecret != "" {
+			slog.Error(fmt.Sprintf("OAuth2 client id/secret configured without a token URL (%s.oauth2.token-url)", key))
+			os.Exit(1)
+		}
+		return
+	}
+	if instance.OAuth2.ClientID == "" || instance.OAuth2.ClientSecret == "" {
+		slog.Error(fmt.Sprintf("OAuth2 requires both a client id (%[1]s.oauth2.client-id) and a client secret (%[1]s.oauth2.client-secret)", key))
+		os.Exit(1)
+	}
+	if instance.Username != "" || instance.Password != "" {
+		slog.Error(fmt.Sprintf("Cannot specify both %s username/password and OAuth2 client credentials, only one can be specified", key))
+		os.Exit(1)
+	}
+}
+
+func validateHttpConfig(mcp McpConfig) {
+	if mcp.Mode == "stdio" {
+		return
+	}
+	if mcp.Http.ShutdownTimeout < 0 {
+		slog.Error(fmt.Sprintf("Invalid mcp.http.shutdown-timeout: %s (must not be negative)", mcp.Http.ShutdownTimeout))
+		os.Exit(1)
+	}
+	if mcp.Http.SessionTimeout < 0 {
+		slog.Error(fmt.Sprintf("Invalid mcp.http.session-timeout: %s (must not be negative)", mcp.Http.SessionTimeout))
+		os.Exit(1)
+	}
+	tlsConfig := mcp.Http.TLS
+	if (tlsConfig.Cert == "") != (tlsConfig.Key == "") {
+		slog.Error("TLS requires both a certificate (mcp.http.tls.cert) and a private key (mcp.http.tls.key)")
+		os.Exit(1)
+	}
+	if tlsConfig.ClientCA != "" && !tlsConfig.Enabled() {
+		slog.Error("Client certificate verification (mcp.http.tls.client-ca) requires TLS to be enabled (mcp.http.tls.cert and mcp.http.tls.key)")
+		os.Exit(1)
+	}
+	if !isLoopbackAddress(mcp.Http.BindAddress) && !mcp.Http.Auth.Enabled() && tlsConfig.ClientCA == "" {
+		slog.Error(fmt.Sprintf("Refusing to bind to %q without inbound authentication; configure mcp.http.auth (api-keys or jwt), mcp.http.tls.client-ca, or bind to localhost", mcp.Http.BindAddress))
+		os.Exit(1)
+	}
+	jwt := mcp.Http.Auth.JWT
+	if jwt.JWKSFile == "" && (jwt.Issuer != "" || jwt.Audience != "" || len(jwt.RequiredScopes) > 0) {
+		slog.Error("JWT issuer/audience/scopes configured without a JWKS file (mcp.http.auth.jwt.jwks-file)")
+		os.Exit(1)
+	}
+	if jwt.JWKSFile != "" && jwt.Audience == "" {
+		slog.Error("JWT authentication requires an audience (mcp.http.auth.jwt.audience), so tokens issued for other applications by the same identity provider are rejected")
+		os.Exit(1)
+	}
+}
+
+func validateTracingConfig(tracingConfig TracingConfig) {
+	switch tracingConfig.Exporter {
+	case tracing.ExporterNone, tracing.ExporterOTLP:
+	case tracing.ExporterFile:
+		if tracingConfig.File == "" {
+			slog.Error("The 'file' trace exporter requires a file path (mcp.tracing.file)")
+			os.Exit(1)
+		}
+	default:
+		slog.Error(fmt.Sprintf("Invalid trace exporter: %s (must be 'none', 'otlp' or 'file')", tracingConfig.Exporter))
+		os.Exit(1)
+	}
+}
+
+func validateAuditConfig(mcpConfig McpConfig) {
+	if mcpConfig.Audit.Output == audit.OutputStdout && mcpConfig.Mode == "stdio" {
+		slog.Error("The audit log cannot be written to stdout in stdio mode, where stdout carries the MCP protocol; set mcp.audit.output to a file")
+		os.Exit(1)
+	}
+	if mcpConfig.Audit.MaxSizeMB < 0 || mcpConfig.Audit.MaxBackups < 0 {
+		slog.Error("Invalid mcp.audit: max-size-mb and max-backups must not be negative")
+		os.Exit(1)
+	}
+}
+
 func readConfigFile() Config {
 	if err := viper.ReadInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
@@ -204,33 +643,232 @@ func readConfigFile() Config {
 
 type Config struct {
 	Api CollibraApiConfig `mapstructure:"api"`
-	Mcp McpConfig         `mapstructure:"mcp"`
+	// Instances holds further named Collibra instances that tool calls can be
+	// routed to. Viper lowercases the names.
+	Instances       map[string]InstanceConfig `mapstructure:"instances"`
+	DefaultInstance string                    `mapstructure:"default-instance"`
+	Mcp             McpConfig                 `mapstructure:"mcp"`
+}
+
+// apiInstance names the instance configured by the connection settings of
+// the api section.
+const apiInstance = "default"
+
+// collibraInstances returns the Collibra instances tool calls can be routed
+// to by name: the one configured in the api section, if it has a URL, and
+// those under instances.
+func (c *Config) collibraInstances() map[string]InstanceConfig {
+	instances := make(map[string]InstanceConfig, len(c.Instances)+1)
+	for name, instance := range c.Instances {
+		instances[strings.ToLower(name)] = instance
+	}
+	if c.Api.Url != "" {
+		instances[apiInstance] = c.Api.InstanceConfig
+	}
+	return instances
+}
+
+// defaultCollibraInstance returns the instance used by tool calls that do not
+// select one: default-instance when set, else the api section, else the only
+// configured instance.
+func (c *Config) defaultCollibraInstance() string {
+	if c.DefaultInstance != "" {
+		return strings.ToLower(c.DefaultInstance)
+	}
+	instances := c.collibraInstances()
+	if _, ok := instances[apiInstance]; ok {
+		return apiInstance
+	}
+	if len(instances) == 1 {
+		for name := range instances {
+			return name
+		}
+	}
+	return ""
 }
 
 // CollibraConfig holds Collibra-specific configuration
 type CollibraApiConfig struct {
-	Url           string `mapstructure:"url"`
-	Username      string `mapstructure:"username"`
-	Password      string `mapstructure:"password"`
-	SkipTLSVerify bool   `mapstructure:"skip-tls-verify"`
-	Proxy         string `mapstructure:"proxy"`
+	InstanceConfig `mapstructure:",squash"`
+	Retry          RetryConfig          `mapstructure:"retry"`
+	RateLimit      RateLimitConfig      `mapstructure:"rate-limit"`
+	MetamodelCache MetamodelCacheConfig `mapstructure:"metamodel-cache"`
+}
+
+// InstanceConfig holds the connection settings of one Collibra instance.
+type InstanceConfig struct {
+	Url           string       `mapstructure:"url"`
+	Username      string       `mapstructure:"username"`
+	Password      string       `mapstructure:"password"`
+	SkipTLSVerify bool         `mapstructure:"skip-tls-verify"`
+	Proxy         string       `mapstructure:"proxy"`
+	OAuth2        OAuth2Config `mapstructure:"oauth2"`
+}
+
+// serverCredentials reports whether chip authenticates to the instance with
+// OAuth2 client credentials or a username and password, instead of
+// forwarding the caller's Authorization header.
+func (c InstanceConfig) serverCredentials() bool {
+	return c.OAuth2.Enabled() || (c.Username != "" && c.Password != "")
+}
+
+// RateLimitConfig throttles requests to Collibra. The top-level limits apply
+// to all requests together; each entry in Paths adds a limit for the requests
+// under its path prefix.
+type RateLimitConfig struct {
+	RateLimit `mapstructure:",squash"`
+	Paths     []PathRateLimit `mapstructure:"paths"`
+}
+
+// limits converts the configuration into the form used by pkg/ratelimit.
+func (c RateLimitConfig) limits() ratelimit.Config {
+	config := ratelimit.Config{Global: c.RateLimit.limit()}
+	for _, p := range c.Paths {
+		config.Paths = append(config.Paths, ratelimit.PathLimit{Prefix: p.Prefix, Limit: p.limit()})
+	}
+	return config
+}
+
+type RateLimit struct {
+	RequestsPerSecond float64 `mapstructure:"requests-per-second"`
+	Burst             int     `mapstructure:"burst"`
+	MaxInFlight       int     `mapstructure:"max-in-flight"`
+}
+
+func (l RateLimit) limit() ratelimit.Limit {
+	return ratelimit.Limit{RequestsPerSecond: l.RequestsPerSecond, Burst: l.Burst, MaxInFlight: l.MaxInFlight}
+}
+
+type PathRateLimit struct {
+	Prefix    string `mapstructure:"prefix"`
+	RateLimit `mapstructure:",squash"`
+}
+
+// MetamodelCacheConfig controls the cache of metamodel lookups shared by the
+// write tools.
+type MetamodelCacheConfig struct {
+	TTL        time.Duration `mapstructure:"ttl"`
+	MaxEntries int           `mapstructure:"max-entries"`
+}
+
+// RetryConfig controls retries of idempotent Collibra requests that fail with
+// a transport error, 429 or 5xx.
+type RetryConfig struct {
+	MaxAttempts    int           `mapstructure:"max-attempts"`
+	InitialBackoff time.Duration `mapstructure:"initial-backoff"`
+	MaxBackoff     time.Duration `mapstructure:"max-backoff"`
+}
+
+// OAuth2Config holds the client-credentials grant settings used to obtain
+// bearer tokens for the Collibra API on behalf of a service account.
+type OAuth2Config struct {
+	TokenURL     string   `mapstructure:"token-url"`
+	ClientID     string   `mapstructure:"client-id"`
+	ClientSecret string   `mapstructure:"client-secret"`
+	Scopes       []string `mapstructure:"scopes"`
+}
+
+// Enabled reports whether chip should authenticate with OAuth2 client
+// credentials instead of basic auth or the caller's Authorization header.
+func (c OAuth2Config) Enabled() bool {
+	return c.TokenURL != ""
 }
 
 // ServerConfig holds server configuration
 type McpConfig struct {
-	Mode          string      `mapstructure:"mode"` // "stdio", "http", "http-sse", or "http-streamable"
-	Http          HttpConfig  `mapstructure:"http"`
-	Stdio         StdioConfig `mapstructure:"stdio"`
-	EnabledTools  []string    `mapstructure:"enabled-tools"`
-	DisabledTools []string    `mapstructure:"disabled-tools"`
-	EnableDebugTools bool     `mapstructure:"enable-debug-tools"`
-	Experimental  []string    `mapstructure:"experimental"`
-	SkillsDir     string      `mapstructure:"skills-dir"`
+	Mode             string        `mapstructure:"mode"` // "stdio", "http", "http-sse", or "http-streamable"
+	Http             HttpConfig    `mapstructure:"http"`
+	Stdio            StdioConfig   `mapstructure:"stdio"`
+	EnabledTools     []string      `mapstructure:"enabled-tools"`
+	DisabledTools    []string      `mapstructure:"disabled-tools"`
+	EnableDebugTools bool          `mapstructure:"enable-debug-tools"`
+	ReadOnly         bool          `mapstructure:"read-only"`
+	DryRun           bool          `mapstructure:"dry-run"`
+	CheckPermissions bool          `mapstructure:"check-permissions"`
+	WatchConfig      bool          `mapstructure:"watch-config"`
+	Metrics          MetricsConfig `mapstructure:"metrics"`
+	Tracing          TracingConfig `mapstructure:"tracing"`
+	Audit            AuditConfig   `mapstructure:"audit"`
+	Experimental     []string      `mapstructure:"experimental"`
+	SkillsDir        string        `mapstructure:"skills-dir"`
 }
 
 type HttpConfig struct {
-	Port int `mapstructure:"port"`
+	Port        int            `mapstructure:"port"`
+	BindAddress string         `mapstructure:"bind-address"`
+	Auth        HttpAuthConfig `mapstructure:"auth"`
+	TLS         TLSConfig      `mapstructure:"tls"`
+	// ShutdownTimeout bounds how long in-flight requests may take to finish
+	// after SIGTERM.
+	ShutdownTimeout time.Duration `mapstructure:"shutdown-timeout"`
+	// Stateful keeps a streamable HTTP session per client across requests
+	// instead of serving every request with a session of its own.
+	Stateful bool `mapstructure:"stateful"`
+	// SessionTimeout closes sessions that received no request for this long.
+	SessionTimeout time.Duration `mapstructure:"session-timeout"`
+}
+
+// TLSConfig enables HTTPS on the HTTP endpoint. When ClientCA is set, clients
+// must present a certificate signed by one of its CAs (mutual TLS).
+type TLSConfig struct {
+	Cert     string `mapstructure:"cert"`
+	Key      string `mapstructure:"key"`
+	ClientCA string `mapstructure:"client-ca"`
+}
+
+// Enabled reports whether the HTTP server should terminate TLS itself.
+func (c TLSConfig) Enabled() bool {
+	return c.Cert != "" && c.Key != ""
+}
+
+// HttpAuthConfig holds inbound authentication settings for the HTTP
+// endpoint. Requests must present either one of the API keys or a bearer JWT
+// that validates against the JWT settings.
+type HttpAuthConfig struct {
+	ApiKeys []string      `mapstructure:"api-keys"`
+	JWT     JWTAuthConfig `mapstructure:"jwt"`
+}
+
+// Enabled reports whether any inbound authentication method is configured.
+func (c HttpAuthConfig) Enabled() bool {
+	return len(c.ApiKeys) > 0 || c.JWT.JWKSFile != ""
+}
+
+// JWTAuthConfig validates bearer JWTs against a local JWKS file. Audience is
+// required; issuer is checked only when set.
+type JWTAuthConfig struct {
+	JWKSFile       string   `mapstructure:"jwks-file"`
+	Issuer         string   `mapstructure:"issuer"`
+	Audience       string   `mapstructure:"audience"`
+	RequiredScopes []string `mapstructure:"required-scopes"`
 }
 
 type StdioConfig struct {
 }
+
+// MetricsConfig controls the Prometheus metrics endpoint. Metrics are
+// collected when Enabled is set or a dedicated Listen address is given.
+type MetricsConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Listen  string `mapstructure:"listen"`
+}
+
+func (c MetricsConfig) IsEnabled() bool {
+	return c.Enabled || c.Listen != ""
+}
+
+// AuditConfig enables the audit log of calls to tools that are not
+// annotated as read-only.
+type AuditConfig struct {
+	Output     string   `mapstructure:"output"` // file path or "stdout"
+	MaxSizeMB  int      `mapstructure:"max-size-mb"`
+	MaxBackups int      `mapstructure:"max-backups"`
+	Redact     []string `mapstructure:"redact"`
+}
+
+// TracingConfig selects where OpenTelemetry spans are exported.
+type TracingConfig struct {
+	Exporter string `mapstructure:"exporter"` // "none", "otlp", or "file"
+	Endpoint string `mapstructure:"endpoint"`
+	File     string `mapstructure:"file"`
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+
+	"github.com/collibra/chip/pkg/fakecollibra"
+	"github.com/spf13/pflag"
+)
+
+const fakeCollibraCommand = "fake-collibra"
+
+// runFakeCollibra serves an in-memory Collibra seeded from a fixture, so chip
+// can be pointed at it with COLLIBRA_MCP_API_URL and run without network.
+// It has its own flags and does not read chip's configuration.
+func runFakeCollibra(args []string) error {
+	flags := pflag.NewFlagSet(fakeCollibraCommand, pflag.ContinueOnError)
+	fixturePath := flags.String("fixture", "", "YAML or JSON fixture describing the catalog (default: a small built-in catalog)")
+	listen := flags.String("listen", "localhost:8081", "Address to listen on")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	fixture, err := fakecollibra.ParseFixture(fakecollibra.DefaultFixture)
+	if *fixturePath != "" {
+		fixture, err = fakecollibra.LoadFixture(*fixturePath)
+	}
+	if err != nil {
+		return err
+	}
+	server, err := fakecollibra.New(fixture)
+	if err != nil {
+		return err
+	}
+	slog.Info(fmt.Sprintf("Serving a fake Collibra on http://%s", *listen))
+	return http.ListenAndServe(*listen, server)
+}
+
+// fakeCollibraRequested reports whether chip is started as a fake Collibra.
+func fakeCollibraRequested() bool {
+	return len(os.Args) > 1 && os.Args[1] == fakeCollibraCommand
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/collibra/chip/pkg/chip"
+)
+
+const (
+	// readinessPath is a lightweight Collibra endpoint that requires
+	// authentication: it returns the user the credentials belong to.
+	readinessPath = "/rest/2.0/users/current"
+	// readinessCacheTTL bounds how often probes reach Collibra.
+	readinessCacheTTL = 10 * time.Second
+	readinessTimeout  = 5 * time.Second
+)
+
+// healthz reports that the process is alive and serving HTTP.
+func healthz(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// readiness answers /readyz with the outcome of checking every configured
+// Collibra instance: "ok" or "unavailable", the failures themselves are only
+// logged. The outcome is cached for readinessCacheTTL, so frequent probes do
+// not load Collibra, and probes arriving during a check wait for it instead
+// of starting another one.
+type readiness struct {
+	router *instanceRouter
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu        sync.Mutex
+	checkedAt time.Time
+	ready     bool
+	instances map[string]string
+	// checking is closed when the check in progress, if any, completes.
+	checking chan struct{}
+}
+
+func newReadiness(router *instanceRouter) *readiness {
+	return &readiness{router: router, ttl: readinessCacheTTL, now: time.Now}
+}
+
+func (r *readiness) ServeHTTP(w http.ResponseWriter, request *http.Request) {
+	ready, instances := r.check(request.Context())
+	status, code := "ready", http.StatusOK
+	if !ready {
+		status, code = "not ready", http.StatusServiceUnavailable
+	}
+	writeJSON(w, code, map[string]any{"status": status, "instances": instances})
+}
+
+// check returns whether every instance answered, and "ok" or "unavailable"
+// per instance. The instances are probed without holding the lock.
+func (r *readiness) check(ctx context.Context) (bool, map[string]string) {
+	r.mu.Lock()
+	if r.instances != nil && r.now().Sub(r.checkedAt) < r.ttl {
+		defer r.mu.Unlock()
+		return r.ready, r.instances
+	}
+	if checking := r.checking; checking != nil {
+		r.mu.Unlock()
+		<-checking
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		return r.ready, r.instances
+	}
+	checking := make(chan struct{})
+	r.checking = checking
+	r.mu.Unlock()
+
+	ready, instances := r.probe(ctx)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checkedAt, r.ready, r.instances, r.checking = r.now(), ready, instances, nil
+	close(checking)
+	return ready, instances
+}
+
+// probe checks every instance concurrently.
+func (r *readiness) probe(ctx context.Context) (bool, map[string]string) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), readinessTimeout)
+	defer cancel()
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	ready, instances := true, make(map[string]string, len(r.router.clients))
+	for name, client := range r.router.clients {
+		wg.Go(func() {
+			result := "ok"
+			if err := client.probe(ctx); err != nil {
+				slog.Warn(fmt.Sprintf("Collibra instance %s is not reachable: %v", name, err))
+				result = "unavailable"
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			instances[name] = result
+			ready = ready && result == "ok"
+		})
+	}
+	wg.Wait()
+	return ready, instances
+}
+
+// probe sends a lightweight call to the instance, authenticated with the
+// server-wide credentials when configured. Without them, callers bring their
+// own credentials, so any answer but a server error shows Collibra is up.
+func (c *collibraClient) probe(ctx context.Context) error {
+	probeURL, err := url.JoinPath(c.instance.Url, readinessPath)
+	if err != nil {
+		return fmt.Errorf("invalid API URL configuration: %w", err)
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, probeURL, nil)
+	if err != nil {
+		return err
+	}
+	authenticated := true
+	switch {
+	case c.oauth2 != nil:
+		tok, err := c.oauth2.token()
+		if err != nil {
+			return err
+		}
+		tok.SetAuthHeader(request)
+	case c.instance.Username != "" && c.instance.Password != "":
+		request.SetBasicAuth(c.instance.Username, c.instance.Password)
+	default:
+		authenticated = false
+	}
+	response, err := c.next.RoundTrip(request)
+	if err != nil {
+		return err
+	}
+	_ = response.Body.Close()
+	if response.StatusCode >= http.StatusInternalServerError || (authenticated && response.StatusCode >= http.StatusBadRequest) {
+		return fmt.Errorf("%s answered %s", readinessPath, response.Status)
+	}
+	return nil
+}
+
+// versionHandler reports the chip version with the tools and experimental
+// features currently enabled, and whether chip runs in read-only mode.
+func versionHandler(server *chip.Server, reloader *toolConfigReloader) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		experimental := reloader.current().Experimental
+		if experimental == nil {
+			experimental = []string{}
+		}
+		writeJSON(w, http.StatusOK, map[string]any{
+			"version":      chip.Version,
+			"tools":        server.ToolNames(),
+			"experimental": experimental,
+			"read_only":    server.ReadOnly(),
+		})
+	}
+}
+
+func writeJSON(w http.ResponseWriter, code int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
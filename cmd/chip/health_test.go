@@ -0,0 +1,141 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/collibra/chip/pkg/chip"
+	"github.com/collibra/chip/pkg/tools"
+)
+
+func probeServer(t *testing.T, calls *atomic.Int32) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.URL.Path != readinessPath {
+			t.Errorf("unexpected probe path %s", r.URL.Path)
+		}
+		if user, password, ok := r.BasicAuth(); !ok || user != "svc" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func getReadiness(t *testing.T, r *readiness) (int, map[string]any) {
+	t.Helper()
+	recorder := httptest.NewRecorder()
+	r.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	var body map[string]any
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	return recorder.Code, body
+}
+
+func TestReadiness_ChecksEveryInstanceAndCachesTheOutcome(t *testing.T) {
+	var calls atomic.Int32
+	collibra := probeServer(t, &calls)
+	config := &Config{
+		Api: CollibraApiConfig{InstanceConfig: InstanceConfig{Url: collibra.URL, Username: "svc", Password: "secret"}},
+		Instances: map[string]InstanceConfig{
+			// Without server-wide credentials a 401 still shows Collibra is up.
+			"caller-auth": {Url: collibra.URL},
+		},
+	}
+	r := newReadiness(newInstanceRouter(config))
+	now := time.Now()
+	r.now = func() time.Time { return now }
+
+	code, body := getReadiness(t, r)
+	if code != http.StatusOK || body["status"] != "ready" {
+		t.Fatalf("expected ready, got %d %v", code, body)
+	}
+	_, _ = getReadiness(t, r)
+	if calls.Load() != 2 {
+		t.Errorf("expected the outcome to be cached, got %d probes", calls.Load())
+	}
+
+	r.router.clients["default"].instance.Password = "wrong"
+	now = now.Add(readinessCacheTTL)
+	code, body = getReadiness(t, r)
+	if code != http.StatusServiceUnavailable || body["status"] != "not ready" {
+		t.Fatalf("expected rejected credentials to make chip not ready, got %d %v", code, body)
+	}
+	if instances := body["instances"].(map[string]any); instances["caller-auth"] != "ok" || instances["default"] != "unavailable" {
+		t.Errorf("unexpected per-instance outcome %v", instances)
+	}
+}
+
+func TestReadiness_ProbesOnceWithoutHoldingTheLock(t *testing.T) {
+	var calls atomic.Int32
+	probing, release := make(chan struct{}), make(chan struct{})
+	collibra := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		if calls.Add(1) == 1 {
+			close(probing)
+		}
+		<-release
+	}))
+	t.Cleanup(collibra.Close)
+	r := newReadiness(newInstanceRouter(&Config{Api: CollibraApiConfig{InstanceConfig: InstanceConfig{Url: collibra.URL}}}))
+
+	var wg sync.WaitGroup
+	codes := make([]int, 2)
+	serve := func(i int) {
+		recorder := httptest.NewRecorder()
+		r.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		codes[i] = recorder.Code
+	}
+	wg.Go(func() { serve(0) })
+	<-probing
+	if !r.mu.TryLock() {
+		t.Fatal("expected the lock to be free while probing")
+	}
+	r.mu.Unlock()
+	wg.Go(func() { serve(1) })
+	close(release)
+	wg.Wait()
+
+	if calls.Load() != 1 || codes[0] != http.StatusOK || codes[1] != http.StatusOK {
+		t.Errorf("expected one probe answering both requests, got %d probes and %v", calls.Load(), codes)
+	}
+}
+
+func TestReadiness_UnreachableInstance(t *testing.T) {
+	collibra := httptest.NewServer(http.NotFoundHandler())
+	collibra.Close()
+	r := newReadiness(newInstanceRouter(&Config{Api: CollibraApiConfig{InstanceConfig: InstanceConfig{Url: collibra.URL}}}))
+	code, body := getReadiness(t, r)
+	if code != http.StatusServiceUnavailable {
+		t.Errorf("expected an unreachable instance to make chip not ready, got %d", code)
+	}
+	if instances := body["instances"].(map[string]any); instances["default"] != "unavailable" {
+		t.Errorf("expected only the status of the instance, got %v", instances)
+	}
+}
+
+func TestVersionHandler(t *testing.T) {
+	toolConfig := &chip.ServerToolConfig{EnabledTools: []string{"search_asset_keyword"}, Experimental: []string{tools.ContextSpecificationsFeature}}
+	reloader := newTestReloader(t, toolConfig)
+
+	recorder := httptest.NewRecorder()
+	versionHandler(reloader.server, reloader)(recorder, httptest.NewRequest(http.MethodGet, "/version", nil))
+	var body struct {
+		Version      string   `json:"version"`
+		Tools        []string `json:"tools"`
+		Experimental []string `json:"experimental"`
+		ReadOnly     bool     `json:"read_only"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Version != chip.Version || len(body.Tools) != 1 || body.Tools[0] != "search_asset_keyword" || len(body.Experimental) != 1 || body.ReadOnly {
+		t.Errorf("unexpected version response %+v", body)
+	}
+}
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"crypto/rand"
 	"crypto/tls"
 	"fmt"
 	"log/slog"
@@ -16,12 +15,30 @@ import (
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
+// collibraClient sends the tools' requests to one Collibra instance, with
+// the instance's credentials, proxy and TLS settings.
 type collibraClient struct {
-	config *Config
-	next   http.RoundTripper
+	instance InstanceConfig
+	next     http.RoundTripper
+	oauth2   *oauth2TokenSource
 }
 
-func newCollibraClient(config *Config) *http.Client {
+// transportMiddleware wraps the Collibra transport, e.g. to record metrics
+// or trace upstream requests.
+type transportMiddleware func(next http.RoundTripper) http.RoundTripper
+
+// newCollibraClient builds the HTTP client the tools use to call Collibra.
+// Middlewares wrap the transport in order, so the first one is outermost and
+// sees each request exactly as the tools issued it.
+func newCollibraClient(router *instanceRouter, middlewares ...transportMiddleware) *http.Client {
+	var transport http.RoundTripper = router
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		transport = middlewares[i](transport)
+	}
+	return &http.Client{Transport: transport}
+}
+
+func newInstanceClient(name string, instance InstanceConfig) *collibraClient {
 	baseTransport := &http.Transport{
 		DialContext: (&net.Dialer{
 			Timeout:   60 * time.Second,
@@ -34,34 +51,67 @@ func newCollibraClient(config *Config) *http.Client {
 		ExpectContinueTimeout: 10 * time.Second,
 	}
 
-	if config.Api.SkipTLSVerify {
-		slog.Warn(fmt.Sprintf("Skipping TLS certificate verification for %s", config.Api.Url))
-		baseTransport.TLSClientConfig = &tls.Config{InsecureSkipVerify: config.Api.SkipTLSVerify}
+	if instance.SkipTLSVerify {
+		slog.Warn(fmt.Sprintf("Skipping TLS certificate verification for %s", instance.Url))
+		baseTransport.TLSClientConfig = &tls.Config{InsecureSkipVerify: instance.SkipTLSVerify}
 	}
 
-	if config.Api.Proxy != "" {
-		proxyURL, err := url.Parse(config.Api.Proxy)
+	if instance.Proxy != "" {
+		proxyURL, err := url.Parse(instance.Proxy)
 		if err != nil {
-			slog.Error(fmt.Sprintf("Invalid proxy URL: %s", err))
+			slog.Error(fmt.Sprintf("Invalid proxy URL for Collibra instance %s: %s", name, err))
 			os.Exit(1)
 		}
-		slog.Info(fmt.Sprintf("Using proxy URL: %s", proxyURL))
+		slog.Info(fmt.Sprintf("Using proxy URL for Collibra instance %s: %s", name, proxyURL))
 		baseTransport.Proxy = http.ProxyURL(proxyURL)
 	}
 
-	return &http.Client{
-		Transport: &collibraClient{
-			config: config,
-			next:   chip.NewCollibraClient(baseTransport),
-		},
+	client := &collibraClient{
+		instance: instance,
+		next:     chip.NewCollibraClient(baseTransport),
+	}
+	if instance.OAuth2.Enabled() {
+		slog.Info(fmt.Sprintf("Using OAuth2 client credentials from %s for Collibra instance %s", instance.OAuth2.TokenURL, name))
+		client.oauth2 = newOAuth2TokenSource(instance.OAuth2, baseTransport)
+	}
+	return client
+}
+
+// instanceRouter hands each request to the client of the Collibra instance
+// its tool call was routed to by selectInstance.
+type instanceRouter struct {
+	clients         map[string]*collibraClient
+	defaultInstance string
+}
+
+func newInstanceRouter(config *Config) *instanceRouter {
+	router := &instanceRouter{
+		clients:         map[string]*collibraClient{},
+		defaultInstance: config.defaultCollibraInstance(),
+	}
+	for name, instance := range config.collibraInstances() {
+		router.clients[name] = newInstanceClient(name, instance)
+	}
+	return router
+}
+
+func (r *instanceRouter) RoundTrip(request *http.Request) (*http.Response, error) {
+	name, ok := chip.GetCollibraInstance(request.Context())
+	if !ok {
+		name = r.defaultInstance
+	}
+	client, ok := r.clients[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown Collibra instance %q", name)
 	}
+	return client.RoundTrip(request)
 }
 
 func (c *collibraClient) RoundTrip(request *http.Request) (*http.Response, error) {
-	if c.config.Api.Url == "" {
+	if c.instance.Url == "" {
 		return nil, fmt.Errorf("API URL is not configured")
 	}
-	baseURL, err := url.Parse(c.config.Api.Url)
+	baseURL, err := url.Parse(c.instance.Url)
 	if err != nil {
 		return nil, fmt.Errorf("invalid API URL configuration: %w", err)
 	}
@@ -70,28 +120,58 @@ func (c *collibraClient) RoundTrip(request *http.Request) (*http.Response, error
 	if !ok {
 		return nil, fmt.Errorf("toolRequest not found in ctx")
 	}
-	if c.config.Api.Username != "" && c.config.Api.Password != "" {
-		reqClone.SetBasicAuth(c.config.Api.Username, c.config.Api.Password)
-	} else {
-		copyHeader(toolRequest, reqClone, "Authorization")
-	}
 	reqClone.Header.Set("X-MCP-Session-Id", chip.GetSessionId(reqClone.Context()))
 	reqClone.Header.Set("X-MCP-Tool-Name", toolRequest.Params.Name)
-	reqClone.Header.Set("traceparent", generateTraceParent())
 	reqClone.URL.Scheme = baseURL.Scheme
 	reqClone.URL.Host = baseURL.Host
 	reqClone.URL.Path = path.Join(baseURL.Path, request.URL.Path)
+
+	if c.oauth2 != nil {
+		return c.roundTripOAuth2(reqClone)
+	}
+	if c.instance.Username != "" && c.instance.Password != "" {
+		reqClone.SetBasicAuth(c.instance.Username, c.instance.Password)
+	} else {
+		copyHeader(toolRequest, reqClone, "Authorization")
+	}
 	return c.next.RoundTrip(reqClone)
 }
 
-func generateTraceParent() string {
-	traceID := make([]byte, 16)
-	spanID := make([]byte, 8)
-
-	_, _ = rand.Read(traceID)
-	_, _ = rand.Read(spanID)
+// roundTripOAuth2 sends the request with a bearer token from the OAuth2
+// client-credentials grant. When Collibra answers 401 the cached token is
+// discarded and the request is retried once with a freshly issued token, as
+// long as its body can be replayed.
+func (c *collibraClient) roundTripOAuth2(request *http.Request) (*http.Response, error) {
+	tok, err := c.oauth2.token()
+	if err != nil {
+		return nil, err
+	}
+	tok.SetAuthHeader(request)
+	response, err := c.next.RoundTrip(request)
+	if err != nil || response.StatusCode != http.StatusUnauthorized {
+		return response, err
+	}
+	if request.Body != nil && request.GetBody == nil {
+		return response, nil
+	}
 
-	return fmt.Sprintf("00-%x-%x-01", traceID, spanID)
+	retry := request.Clone(request.Context())
+	if request.GetBody != nil {
+		body, err := request.GetBody()
+		if err != nil {
+			return response, nil
+		}
+		retry.Body = body
+	}
+	c.oauth2.invalidate(tok)
+	tok, err = c.oauth2.token()
+	if err != nil {
+		return response, nil
+	}
+	_ = response.Body.Close()
+	slog.WarnContext(request.Context(), "Collibra rejected the OAuth2 token, retrying with a fresh token", "path", request.URL.Path)
+	tok.SetAuthHeader(retry)
+	return c.next.RoundTrip(retry)
 }
 
 func copyHeader(toolRequest *mcp.CallToolRequest, httpRequest *http.Request, header string) {
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/collibra/chip/pkg/chip"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// A tool call selects the Collibra instance it is routed to with, by
+// precedence, the instance argument, the _meta field or the HTTP header.
+const (
+	instanceArgument = "instance"
+	instanceMetaKey  = "collibra.com/instance"
+	instanceHeader   = "X-Collibra-Instance"
+)
+
+// instanceArgumentSchema describes the instance argument added to every tool
+// when several Collibra instances are configured.
+func instanceArgumentSchema(instances map[string]InstanceConfig, defaultInstance string) *jsonschema.Schema {
+	var names []any
+	for _, name := range slices.Sorted(maps.Keys(instances)) {
+		names = append(names, name)
+	}
+	return &jsonschema.Schema{
+		Type:        "string",
+		Description: fmt.Sprintf("Optional Collibra instance to run the tool against, defaults to %s.", defaultInstance),
+		Enum:        names,
+	}
+}
+
+// selectInstance routes each tool call to the Collibra instance it selects,
+// or to the default one, and rejects calls selecting an unknown instance.
+func selectInstance(instances map[string]InstanceConfig, defaultInstance string) chip.ToolMiddlewareFunc {
+	return func(ctx context.Context, toolRequest *mcp.CallToolRequest, next chip.CallToolFunc) (*mcp.CallToolResult, error) {
+		name := requestedInstance(toolRequest)
+		if name == "" {
+			name = defaultInstance
+		}
+		instance, ok := instances[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown Collibra instance %q (must be one of %s)", name, strings.Join(slices.Sorted(maps.Keys(instances)), ", "))
+		}
+		ctx = chip.SetCollibraInstance(ctx, name)
+		ctx = chip.SetCollibraHost(ctx, instance.Url)
+		ctx = chip.SetServerCredentials(ctx, instance.serverCredentials())
+		slog.InfoContext(ctx, fmt.Sprintf("Calling tool: %s", toolRequest.Params.Name), "tool_name", toolRequest.Params.Name, "instance", name)
+		return next(ctx, toolRequest)
+	}
+}
+
+// requestedInstance returns the lowercased name of the instance the tool call
+// selects, or "" when it selects none.
+func requestedInstance(toolRequest *mcp.CallToolRequest) string {
+	var arguments map[string]any
+	if len(toolRequest.Params.Arguments) > 0 && json.Unmarshal(toolRequest.Params.Arguments, &arguments) == nil {
+		if name, ok := arguments[instanceArgument].(string); ok && name != "" {
+			return strings.ToLower(name)
+		}
+	}
+	if name, ok := toolRequest.Params.GetMeta()[instanceMetaKey].(string); ok && name != "" {
+		return strings.ToLower(name)
+	}
+	if extra := toolRequest.GetExtra(); extra != nil {
+		if name := extra.Header.Get(instanceHeader); name != "" {
+			return strings.ToLower(name)
+		}
+	}
+	return ""
+}
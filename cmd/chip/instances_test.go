@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/collibra/chip/pkg/chip"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func instanceToolRequest(arguments string, meta mcp.Meta, header http.Header) *mcp.CallToolRequest {
+	return &mcp.CallToolRequest{
+		Params: &mcp.CallToolParamsRaw{Name: "search_asset_keyword", Arguments: json.RawMessage(arguments), Meta: meta},
+		Extra:  &mcp.RequestExtra{Header: header},
+	}
+}
+
+func TestRequestedInstance(t *testing.T) {
+	header := http.Header{instanceHeader: {"Staging"}}
+	meta := mcp.Meta{instanceMetaKey: "dev"}
+	tests := []struct {
+		name    string
+		request *mcp.CallToolRequest
+		want    string
+	}{
+		{"argument wins", instanceToolRequest(`{"instance":"prod"}`, meta, header), "prod"},
+		{"meta over header", instanceToolRequest(`{}`, meta, header), "dev"},
+		{"header", instanceToolRequest(`{}`, nil, header), "staging"},
+		{"none", instanceToolRequest(``, nil, nil), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := requestedInstance(tt.request); got != tt.want {
+				t.Errorf("requestedInstance = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectInstance(t *testing.T) {
+	instances := map[string]InstanceConfig{
+		"default": {Url: "https://dev.collibra.com"},
+		"prod":    {Url: "https://prod.collibra.com", Username: "chip", Password: "secret"},
+	}
+	middleware := selectInstance(instances, "default")
+
+	var instance, host string
+	var serverCredentials bool
+	next := func(ctx context.Context, _ *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		instance, _ = chip.GetCollibraInstance(ctx)
+		host, _ = chip.GetCollibraHost(ctx)
+		serverCredentials = chip.HasServerCredentials(ctx)
+		return &mcp.CallToolResult{}, nil
+	}
+
+	if _, err := middleware(t.Context(), instanceToolRequest(`{}`, nil, nil), next); err != nil {
+		t.Fatal(err)
+	}
+	if instance != "default" || host != "https://dev.collibra.com" || serverCredentials {
+		t.Errorf("expected the default instance with the caller's credentials, got %s at %s (server credentials: %t)", instance, host, serverCredentials)
+	}
+
+	if _, err := middleware(t.Context(), instanceToolRequest(`{}`, mcp.Meta{instanceMetaKey: "PROD"}, nil), next); err != nil {
+		t.Fatal(err)
+	}
+	if instance != "prod" || host != "https://prod.collibra.com" || !serverCredentials {
+		t.Errorf("expected the selected instance with its own credentials, got %s at %s (server credentials: %t)", instance, host, serverCredentials)
+	}
+
+	if _, err := middleware(t.Context(), instanceToolRequest(`{}`, nil, http.Header{instanceHeader: {"qa"}}), next); err == nil {
+		t.Error("expected an unknown instance to be rejected")
+	}
+}
+
+func TestInstanceRouter_UsesTheSelectedInstanceCredentials(t *testing.T) {
+	newAPI := func(wantUser string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if user, _, _ := r.BasicAuth(); user != wantUser {
+				t.Errorf("%s received credentials of %q", wantUser, user)
+			}
+		}))
+	}
+	dev, prod := newAPI("dev-user"), newAPI("prod-user")
+	defer dev.Close()
+	defer prod.Close()
+
+	config := &Config{
+		Api: CollibraApiConfig{InstanceConfig: InstanceConfig{Url: dev.URL, Username: "dev-user", Password: "secret"}},
+		Instances: map[string]InstanceConfig{
+			"prod": {Url: prod.URL, Username: "prod-user", Password: "secret"},
+		},
+	}
+	client := newCollibraClient(newInstanceRouter(config))
+
+	for _, instance := range []string{"", "prod"} {
+		toolRequest := instanceToolRequest(`{}`, nil, nil)
+		toolRequest.Session = &mcp.ServerSession{}
+		ctx := chip.SetCallToolRequest(t.Context(), toolRequest)
+		if instance != "" {
+			ctx = chip.SetCollibraInstance(ctx, instance)
+		}
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/rest/2.0/assets", nil)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("request to %q: %v", instance, err)
+		}
+		_ = resp.Body.Close()
+	}
+}
+
+func TestDefaultCollibraInstance(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		want   string
+	}{
+		{"api section", Config{Api: CollibraApiConfig{InstanceConfig: InstanceConfig{Url: "https://dev"}}, Instances: map[string]InstanceConfig{"prod": {}}}, "default"},
+		{"explicit", Config{Api: CollibraApiConfig{InstanceConfig: InstanceConfig{Url: "https://dev"}}, DefaultInstance: "Prod"}, "prod"},
+		{"only instance", Config{Instances: map[string]InstanceConfig{"prod": {}}}, "prod"},
+		{"ambiguous", Config{Instances: map[string]InstanceConfig{"prod": {}, "staging": {}}}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.config.defaultCollibraInstance(); got != tt.want {
+				t.Errorf("defaultCollibraInstance = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/modelcontextprotocol/go-sdk/auth"
+)
+
+// jwtClockSkew is the tolerance applied to the exp/nbf/iat claims so tokens
+// issued by an IdP whose clock drifts slightly are not rejected.
+const jwtClockSkew = time.Minute
+
+// minRSAKeyBits is the smallest RSA modulus accepted in the JWKS file.
+const minRSAKeyBits = 2048
+
+// jwtAlgorithms are the signing algorithms accepted on inbound JWTs. Only
+// asymmetric algorithms are listed so a leaked chip config never allows
+// tokens to be minted.
+var jwtAlgorithms = []jose.SignatureAlgorithm{
+	jose.RS256, jose.RS384, jose.RS512,
+	jose.PS256, jose.PS384, jose.PS512,
+	jose.ES256, jose.ES384, jose.ES512,
+	jose.EdDSA,
+}
+
+// jwtVerifier validates bearer JWTs against a local JWKS file and the
+// configured issuer and audience.
+type jwtVerifier struct {
+	keys     map[string]jose.JSONWebKey
+	issuer   string
+	audience string
+	now      func() time.Time
+}
+
+type jwtClaims struct {
+	jwt.Claims
+	Scope string   `json:"scope"`
+	Scp   []string `json:"scp"`
+}
+
+func newJWTVerifier(config JWTAuthConfig) (*jwtVerifier, error) {
+	if config.Audience == "" {
+		return nil, errors.New("JWT authentication requires an audience")
+	}
+	data, err := os.ReadFile(config.JWKSFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWKS file: %w", err)
+	}
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse JWKS file %s: %w", config.JWKSFile, err)
+	}
+	return &jwtVerifier{
+		keys:     keys,
+		issuer:   config.Issuer,
+		audience: config.Audience,
+		now:      time.Now,
+	}, nil
+}
+
+// parseJWKS returns the signing keys of a JWKS by key id. Every key needs a
+// distinct kid, so a token is only ever checked against the key it names.
+func parseJWKS(data []byte) (map[string]jose.JSONWebKey, error) {
+	var set jose.JSONWebKeySet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+	keys := make(map[string]jose.JSONWebKey, len(set.Keys))
+	for _, key := range set.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+		if key.KeyID == "" {
+			return nil, errors.New("every signing key needs a key id (kid)")
+		}
+		if _, ok := keys[key.KeyID]; ok {
+			return nil, fmt.Errorf("key %q: duplicate key id", key.KeyID)
+		}
+		if err := checkPublicKey(key.Key); err != nil {
+			return nil, fmt.Errorf("key %q: %w", key.KeyID, err)
+		}
+		keys[key.KeyID] = key
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no signing keys found")
+	}
+	return keys, nil
+}
+
+// checkPublicKey accepts RSA keys of at least minRSAKeyBits with a sane
+// exponent, and EC and Ed25519 public keys.
+func checkPublicKey(key any) error {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		if k.N.BitLen() < minRSAKeyBits {
+			return fmt.Errorf("RSA key of %d bits, at least %d are required", k.N.BitLen(), minRSAKeyBits)
+		}
+		if k.E < 3 || k.E%2 == 0 {
+			return fmt.Errorf("invalid RSA exponent %d", k.E)
+		}
+		return nil
+	case *ecdsa.PublicKey, ed25519.PublicKey:
+		return nil
+	default:
+		return fmt.Errorf("unsupported key type %T, expected an RSA, EC or Ed25519 public key", key)
+	}
+}
+
+// Verify implements auth.TokenVerifier. Every failure wraps
+// auth.ErrInvalidToken so the SDK middleware answers 401.
+func (v *jwtVerifier) Verify(_ context.Context, token string, _ *http.Request) (*auth.TokenInfo, error) {
+	claims, err := v.verify(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", auth.ErrInvalidToken, err)
+	}
+	info := &auth.TokenInfo{
+		UserID: claims.Subject,
+		Scopes: claims.Scp,
+		Extra:  map[string]any{"iss": claims.Issuer},
+	}
+	if claims.Scope != "" {
+		info.Scopes = strings.Fields(claims.Scope)
+	}
+	if claims.Expiry != nil {
+		info.Expiration = claims.Expiry.Time()
+	}
+	return info, nil
+}
+
+func (v *jwtVerifier) verify(token string) (*jwtClaims, error) {
+	parsed, err := jwt.ParseSigned(token, jwtAlgorithms)
+	if err != nil {
+		return nil, fmt.Errorf("malformed token: %w", err)
+	}
+	kid := parsed.Headers[0].KeyID
+	key, ok := v.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+	var claims jwtClaims
+	if err := parsed.Claims(key.Key, &claims); err != nil {
+		return nil, err
+	}
+	if claims.Expiry == nil {
+		return nil, errors.New("token has no expiration")
+	}
+	expected := jwt.Expected{Issuer: v.issuer, AnyAudience: jwt.Audience{v.audience}, Time: v.now()}
+	if err := claims.ValidateWithLeeway(expected, jwtClockSkew); err != nil {
+		return nil, err
+	}
+	return &claims, nil
+}
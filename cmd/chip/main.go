@@ -2,44 +2,182 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"strconv"
 	"strings"
+	"syscall"
 
+	"github.com/collibra/chip/pkg/audit"
 	"github.com/collibra/chip/pkg/chip"
+	"github.com/collibra/chip/pkg/clients"
+	"github.com/collibra/chip/pkg/dryrun"
+	"github.com/collibra/chip/pkg/metrics"
+	"github.com/collibra/chip/pkg/permissions"
+	"github.com/collibra/chip/pkg/ratelimit"
+	"github.com/collibra/chip/pkg/readonly"
+	"github.com/collibra/chip/pkg/resources"
 	"github.com/collibra/chip/pkg/skills"
 	"github.com/collibra/chip/pkg/tools"
+	"github.com/collibra/chip/pkg/tracing"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
 )
 
 func main() {
+	exitCode := 0
+	// Registered first so that it runs after the other deferred cleanups.
+	defer func() {
+		if exitCode != 0 {
+			os.Exit(exitCode)
+		}
+	}()
+
+	if fakeCollibraRequested() {
+		if err := runFakeCollibra(os.Args[2:]); err != nil {
+			slog.Error(fmt.Sprintf("Failed to serve the fake Collibra: %v", err))
+			exitCode = 1
+		}
+		return
+	}
+
+	command := cliCommand()
+	if command != "" {
+		// Keep stderr for the tool's errors.
+		slog.SetLogLoggerLevel(slog.LevelWarn)
+	}
+	input := "{}"
+	if command == callCommand {
+		pflag.CommandLine.AddFlagSet(callFlags(&input))
+	}
 	config := Init()
+	if command != "" && config.Mcp.Audit.Output == audit.OutputStdout {
+		slog.Error("The audit log cannot be written to stdout when calling tools from the command line, where stdout carries the tool output; set mcp.audit.output to a file")
+		os.Exit(1)
+	}
 
 	slog.Info(fmt.Sprintf("Starting Collibra MCP server (version: %s)...", chip.Version))
 
-	if config.Api.Url == "" {
-		slog.Error("Missing Api url")
-		os.Exit(1)
+	instances := config.collibraInstances()
+	defaultInstance := config.defaultCollibraInstance()
+	for _, instance := range instances {
+		if instance.Username != "" && instance.Password != "" {
+			slog.Warn("Using a single basic auth header for all requests is not recommended as it will result in all actions being attributed to the same account. Consider setting an appropriate basic auth header for each request.")
+			break
+		}
 	}
 
-	if config.Api.Username != "" && config.Api.Password != "" {
-		slog.Warn("Using a single basic auth header for all requests is not recommended as it will result in all actions being attributed to the same account. Consider setting an appropriate basic auth header for each request.")
+	chipTracing, err := tracing.New(context.Background(), tracing.Config{
+		Exporter: config.Mcp.Tracing.Exporter,
+		Endpoint: config.Mcp.Tracing.Endpoint,
+		File:     config.Mcp.Tracing.File,
+	})
+	if err != nil {
+		slog.Error(fmt.Sprintf("Failed to set up tracing: %v", err))
+		os.Exit(1)
 	}
+	defer func() {
+		if err := chipTracing.Shutdown(context.Background()); err != nil {
+			slog.Warn(fmt.Sprintf("Failed to flush traces: %v", err))
+		}
+	}()
 
-	client := newCollibraClient(config)
+	clients.SetRetryPolicy(clients.RetryPolicy{
+		MaxAttempts:    config.Api.Retry.MaxAttempts,
+		InitialBackoff: config.Api.Retry.InitialBackoff,
+		MaxBackoff:     config.Api.Retry.MaxBackoff,
+	})
+	clients.ConfigureMetamodelCache(clients.MetamodelCacheConfig{
+		TTL:        config.Api.MetamodelCache.TTL,
+		MaxEntries: config.Api.MetamodelCache.MaxEntries,
+	})
 
-	toolConfig := &chip.ServerToolConfig{
-		EnabledTools:     config.Mcp.EnabledTools,
-		DisabledTools:    config.Mcp.DisabledTools,
-		EnableDebugTools: config.Mcp.EnableDebugTools,
-		Experimental:  config.Mcp.Experimental,
-		SkillsDir:     config.Mcp.SkillsDir,
+	var chipMetrics *metrics.Metrics
+	var transportMiddlewares []transportMiddleware
+	if config.Mcp.ReadOnly {
+		// Outermost, so that refused requests take no rate limit tokens and
+		// are not reported as upstream calls.
+		transportMiddlewares = append(transportMiddlewares, readonly.Transport)
+	}
+	if config.Mcp.DryRun {
+		// Requests that are not sent are not rate limited, measured or traced.
+		transportMiddlewares = append(transportMiddlewares, dryrun.Transport)
+	}
+	if rateLimit := config.Api.RateLimit.limits(); !rateLimit.IsZero() {
+		// Before metrics and tracing, so that time spent queueing is not reported as
+		// upstream latency in metrics and traces.
+		transportMiddlewares = append(transportMiddlewares, ratelimit.New(rateLimit).Transport)
 	}
+	if config.Mcp.Metrics.IsEnabled() {
+		chipMetrics = metrics.New()
+		transportMiddlewares = append(transportMiddlewares, chipMetrics.Transport)
+	}
+	transportMiddlewares = append(transportMiddlewares, chipTracing.Transport)
+	router := newInstanceRouter(config)
+	client := newCollibraClient(router, transportMiddlewares...)
+
+	toolConfig := newToolConfig(config.Mcp)
 
-	serverOpts := []chip.ServerOption{
-		chip.WithToolMiddleware(chip.ToolMiddlewareFunc(setCollibraHost(config.Api.Url))),
+	var serverOpts []chip.ServerOption
+	if statefulSessions(config.Mcp) {
+		// The stdio session lasts as long as the process.
+		idleTimeout := config.Mcp.Http.SessionTimeout
+		if config.Mcp.Mode == "stdio" {
+			idleTimeout = 0
+		}
+		serverOpts = append(serverOpts, chip.WithSessionStore(chip.NewMemorySessionStore(idleTimeout)))
+	}
+	if config.Mcp.ReadOnly {
+		slog.Info("Read-only mode: only read-only tools are registered")
+		serverOpts = append(serverOpts, chip.WithReadOnly())
+	}
+	if chipMetrics != nil {
+		serverOpts = append(serverOpts, chip.WithToolMiddleware(chipMetrics.ToolMiddleware()))
+	}
+	serverOpts = append(serverOpts, chip.WithToolMiddleware(chipTracing.ToolMiddleware()))
+	routeToInstance := selectInstance(instances, defaultInstance)
+	serverOpts = append(serverOpts, chip.WithToolMiddleware(routeToInstance))
+	serverOpts = append(serverOpts, chip.WithToolMiddleware(resources.ToolMiddleware()))
+	if len(instances) > 1 {
+		slog.Info(fmt.Sprintf("Routing tool calls to %d Collibra instances (default: %s)", len(instances), defaultInstance))
+		serverOpts = append(serverOpts, chip.WithToolArgument(instanceArgument, instanceArgumentSchema(instances, defaultInstance)))
+	}
+	if config.Mcp.Audit.Output != "" {
+		instanceCallers := make(map[string]string, len(instances))
+		for name, instance := range instances {
+			instanceCallers[name] = serverWideCaller(instance)
+		}
+		auditLog, err := audit.New(audit.Config{
+			Output:          config.Mcp.Audit.Output,
+			MaxSizeMB:       config.Mcp.Audit.MaxSizeMB,
+			MaxBackups:      config.Mcp.Audit.MaxBackups,
+			Redact:          config.Mcp.Audit.Redact,
+			DefaultCaller:   instanceCallers[defaultInstance],
+			InstanceCallers: instanceCallers,
+		})
+		if err != nil {
+			slog.Error(fmt.Sprintf("Failed to set up the audit log: %v", err))
+			os.Exit(1)
+		}
+		defer func() { _ = auditLog.Close() }()
+		serverOpts = append(serverOpts, chip.WithToolMiddleware(auditLog.ToolMiddleware()))
+	}
+	if config.Mcp.DryRun {
+		// Inside the audit log, so that dry runs are recorded as such.
+		slog.Warn("Dry-run mode: requests that could change the catalog are not sent to Collibra")
+		serverOpts = append(serverOpts, chip.WithToolMiddleware(dryrun.ToolMiddleware()))
+	}
+	var permissionChecker *permissions.Checker
+	if config.Mcp.CheckPermissions {
+		// Innermost, so that refused calls are still recorded in the audit log.
+		permissionChecker = permissions.New(client, permissions.DefaultTTL)
+		serverOpts = append(serverOpts, chip.WithToolMiddleware(permissionChecker.ToolMiddleware()))
 	}
 	if skills.Enabled(toolConfig) {
 		slog.Info("Experimental feature enabled: skills")
@@ -49,16 +187,54 @@ func main() {
 		slog.Info("Experimental feature enabled: context-specifications")
 	}
 	server := chip.NewServer(serverOpts...)
+	if permissionChecker != nil && statefulSessions(config.Mcp) {
+		slog.Info("Listing only the tools the caller has the Collibra permissions for")
+		server.AddReceivingMiddleware(permissionChecker.FilterTools(server, routeToInstance))
+	}
 
 	if err := tools.RegisterAll(server, client, toolConfig); err != nil {
 		slog.Error(fmt.Sprintf("Failed to register tools: %v", err))
 		os.Exit(1)
 	}
+	if command != "" {
+		if err := runCommand(context.Background(), server, command, pflag.Args(), input, os.Stdout, os.Stderr); err != nil {
+			if !errors.Is(err, errToolFailed) {
+				slog.Error(err.Error())
+			}
+			exitCode = 1
+		}
+		return
+	}
+	reloader := &toolConfigReloader{server: server, client: client, toolConfig: toolConfig}
+	if config.Mcp.WatchConfig && viper.ConfigFileUsed() != "" {
+		reloader.watch()
+	}
+	hangups := make(chan os.Signal, 1)
+	signal.Notify(hangups, syscall.SIGHUP)
+	go dropMetamodelCacheOn(hangups)
+
+	// routes holds the auxiliary endpoints served next to the MCP handler in
+	// http mode, behind the same inbound authentication. probes are served
+	// without authentication so an orchestrator can reach them.
+	routes := http.NewServeMux()
+	routes.Handle("/version", versionHandler(server, reloader))
+	routes.HandleFunc("DELETE /metamodel-cache", metamodelCacheHandler)
+	probes := http.NewServeMux()
+	probes.HandleFunc("/healthz", healthz)
+	probes.Handle("/readyz", newReadiness(router))
+	if chipMetrics != nil {
+		if config.Mcp.Metrics.Listen != "" {
+			go serveMetrics(config.Mcp.Metrics.Listen, chipMetrics.Handler())
+		}
+		if config.Mcp.Metrics.Enabled {
+			routes.Handle("/metrics", chipMetrics.Handler())
+		}
+	}
 
 	if config.Mcp.Mode == "stdio" {
 		runStdioServer(server)
 	} else if strings.HasPrefix(config.Mcp.Mode, "http") {
-		runHttpServer(config.Mcp.Mode, server, config.Mcp.Http.Port)
+		runHttpServer(config.Mcp.Mode, server, config.Mcp.Http, routes, probes)
 	} else {
 		slog.Error(fmt.Sprintf("Invalid server mode: '%s'", config.Mcp.Mode))
 		os.Exit(1)
@@ -73,44 +249,128 @@ func runStdioServer(server *chip.Server) {
 	}
 }
 
-func runHttpServer(mode string, server *chip.Server, port int) {
-	var handler http.Handler
+func runHttpServer(mode string, server *chip.Server, httpConfig HttpConfig, routes, probes *http.ServeMux) {
+	var mcpHandler http.Handler
 
 	switch mode {
 	case "http", "http-streamable":
-		slog.Info("Using streamable http handler")
-		handler = mcp.NewStreamableHTTPHandler(func(req *http.Request) *mcp.Server {
+		if httpConfig.Stateful {
+			slog.Info(fmt.Sprintf("Using streamable http handler with sessions (idle timeout: %s)", httpConfig.SessionTimeout))
+		} else {
+			slog.Info("Using streamable http handler")
+		}
+		mcpHandler = mcp.NewStreamableHTTPHandler(func(req *http.Request) *mcp.Server {
 			return &server.Server
 		}, &mcp.StreamableHTTPOptions{
-			Stateless: true,
+			Stateless:      !httpConfig.Stateful,
+			SessionTimeout: httpConfig.SessionTimeout,
 		})
 	case "http-sse":
 		slog.Info("Using SSE http handler")
-		handler = mcp.NewSSEHandler(func(req *http.Request) *mcp.Server {
+		mcpHandler = mcp.NewSSEHandler(func(req *http.Request) *mcp.Server {
 			return &server.Server
 		}, &mcp.SSEOptions{})
 	default:
 		slog.Error(fmt.Sprintf("Invalid HTTP mode: %s (must be 'http', 'http-sse' or 'http-streamable')", mode))
 		os.Exit(1)
 	}
+	routes.Handle("/", mcpHandler)
+	var handler http.Handler = routes
+
+	authMiddleware, err := newAuthMiddleware(httpConfig.Auth)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Failed to configure inbound authentication: %v", err))
+		os.Exit(1)
+	}
+	if authMiddleware != nil {
+		slog.Info("Inbound authentication enabled for the HTTP endpoint")
+		handler = authMiddleware(handler)
+	} else if httpConfig.TLS.ClientCA == "" {
+		slog.Warn("HTTP server has no inbound authentication and is only listening on localhost for security reasons.")
+	}
 
+	probes.Handle("/", handler)
+
+	addr := net.JoinHostPort(httpConfig.BindAddress, strconv.Itoa(httpConfig.Port))
 	httpServer := &http.Server{
-		Addr:    fmt.Sprintf("localhost:%d", port),
-		Handler: handler,
+		Addr:    addr,
+		Handler: probes,
+	}
+	var serve func() error
+
+	if httpConfig.TLS.Enabled() {
+		reloader, err := newTLSReloader(httpConfig.TLS)
+		if err != nil {
+			slog.Error(err.Error())
+			os.Exit(1)
+		}
+		watcher, err := reloader.watch()
+		if err != nil {
+			slog.Warn(fmt.Sprintf("TLS certificates will not be reloaded on change: %v", err))
+		} else {
+			defer func() { _ = watcher.Close() }()
+		}
+		httpServer.TLSConfig = reloader.tlsConfig()
+		if httpConfig.TLS.ClientCA != "" {
+			slog.Info("Requiring client certificates (mutual TLS)")
+		}
+		slog.Info(fmt.Sprintf("Listening on %s (TLS)", addr))
+		serve = func() error { return httpServer.ListenAndServeTLS("", "") }
+	} else {
+		slog.Info(fmt.Sprintf("Listening on %s", addr))
+		serve = httpServer.ListenAndServe
 	}
 
-	slog.Warn("HTTP server is only listening on localhost for security reasons.")
-	slog.Info(fmt.Sprintf("Listening on localhost:%d", port))
-	if err := httpServer.ListenAndServe(); err != nil {
+	shutdown, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
+	served := make(chan error, 1)
+	go func() { served <- serve() }()
+	select {
+	case err := <-served:
 		slog.Error(fmt.Sprintf("Failed to start HTTP server: %v", err))
 		os.Exit(1)
+	case <-shutdown.Done():
+		// Stop accepting connections and let in-flight tool calls finish.
+		slog.Info(fmt.Sprintf("Shutting down, waiting up to %s for in-flight requests", httpConfig.ShutdownTimeout))
+		ctx, cancel := context.WithTimeout(context.Background(), httpConfig.ShutdownTimeout)
+		defer cancel()
+		if err := httpServer.Shutdown(ctx); err != nil {
+			slog.Warn(fmt.Sprintf("Closing the HTTP server before all requests finished: %v", err))
+		}
+	}
+}
+
+// statefulSessions reports whether a session lives across requests, so that
+// chip can keep state per session and the tools it lists are the tools it
+// calls.
+func statefulSessions(mcpConfig McpConfig) bool {
+	switch mcpConfig.Mode {
+	case "stdio", "http-sse":
+		return true
+	case "http", "http-streamable":
+		return mcpConfig.Http.Stateful
+	}
+	return false
+}
+
+// serveMetrics runs a dedicated listener for the Prometheus endpoint so
+// metrics can be scraped in stdio mode, where there is no HTTP server.
+func serveMetrics(addr string, handler http.Handler) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", handler)
+	slog.Info(fmt.Sprintf("Serving metrics on %s/metrics", addr))
+	if err := http.ListenAndServe(addr, mux); err != nil {
+		slog.Error(fmt.Sprintf("Failed to start metrics server: %v", err))
+		os.Exit(1)
 	}
 }
 
-func setCollibraHost(collibraHost string) func(ctx context.Context, toolRequest *mcp.CallToolRequest, next chip.CallToolFunc) (*mcp.CallToolResult, error) {
-	return func(ctx context.Context, toolRequest *mcp.CallToolRequest, next chip.CallToolFunc) (*mcp.CallToolResult, error) {
-		ctx = chip.SetCollibraHost(ctx, collibraHost)
-		slog.InfoContext(ctx, fmt.Sprintf("Calling tool: %s", toolRequest.Params.Name), "tool_name", toolRequest.Params.Name)
-		return next(ctx, toolRequest)
+// serverWideCaller names the account chip itself authenticates to a
+// Collibra instance with, which is who acts when a request carries no
+// credentials of its own.
+func serverWideCaller(instance InstanceConfig) string {
+	if instance.OAuth2.Enabled() {
+		return "oauth2:" + instance.OAuth2.ClientID
 	}
+	return instance.Username
 }
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"log/slog"
+	"net/http"
+	"os"
+
+	"github.com/collibra/chip/pkg/clients"
+)
+
+// dropMetamodelCache drops the cached metamodel lookups, so changes made to
+// the metamodel in Collibra show before the cached entries expire.
+func dropMetamodelCache() {
+	clients.InvalidateMetamodelCache()
+	slog.Info("Dropped the cached metamodel lookups")
+}
+
+// metamodelCacheHandler answers DELETE /metamodel-cache by dropping the cached
+// metamodel lookups.
+func metamodelCacheHandler(w http.ResponseWriter, _ *http.Request) {
+	dropMetamodelCache()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// dropMetamodelCacheOn drops the cached metamodel lookups for every signal
+// received on signals, until the channel is closed.
+func dropMetamodelCacheOn(signals <-chan os.Signal) {
+	for range signals {
+		dropMetamodelCache()
+	}
+}
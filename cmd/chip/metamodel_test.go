@@ -0,0 +1,76 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/collibra/chip/pkg/chip"
+	"github.com/collibra/chip/pkg/clients"
+	"github.com/collibra/chip/pkg/tools/testutil"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// listStatusesTwice fills the metamodel cache and returns a function that
+// lists the statuses again and reports how many requests reached Collibra.
+func listStatusesTwice(t *testing.T) func() int32 {
+	t.Helper()
+	var requests atomic.Int32
+	collibra := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(`{"total":1,"results":[{"id":"s1","name":"Candidate"}]}`))
+	}))
+	t.Cleanup(collibra.Close)
+	client := testutil.NewClient(collibra)
+	ctx := chip.SetCollibraHost(chip.SetCallToolRequest(t.Context(), &mcp.CallToolRequest{}), collibra.URL)
+
+	for range 2 {
+		if _, err := clients.ListStatuses(ctx, client); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return func() int32 {
+		if _, err := clients.ListStatuses(ctx, client); err != nil {
+			t.Fatal(err)
+		}
+		return requests.Load()
+	}
+}
+
+func TestMetamodelCacheHandler(t *testing.T) {
+	listAgain := listStatusesTwice(t)
+
+	recorder := httptest.NewRecorder()
+	metamodelCacheHandler(recorder, httptest.NewRequest(http.MethodDelete, "/metamodel-cache", nil))
+	if recorder.Code != http.StatusNoContent {
+		t.Errorf("expected status 204, got %d", recorder.Code)
+	}
+	if n := listAgain(); n != 2 {
+		t.Errorf("expected the statuses to be fetched again after the cache was dropped only, got %d requests", n)
+	}
+}
+
+func TestDropMetamodelCacheOn(t *testing.T) {
+	listAgain := listStatusesTwice(t)
+
+	signals := make(chan os.Signal)
+	done := make(chan struct{})
+	go func() {
+		dropMetamodelCacheOn(signals)
+		close(done)
+	}()
+	signals <- syscall.SIGHUP
+	close(signals)
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected dropMetamodelCacheOn to return once the channel is closed")
+	}
+	if n := listAgain(); n != 2 {
+		t.Errorf("expected the statuses to be fetched again after SIGHUP only, got %d requests", n)
+	}
+}
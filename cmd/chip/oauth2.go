@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// oauth2TokenSource obtains bearer tokens for the Collibra API via the OAuth2
+// client-credentials grant. Tokens are cached until shortly before they
+// expire; invalidate drops the cached token so the next call fetches a fresh
+// one (used when Collibra rejects a token that still looked valid locally,
+// e.g. after a revocation or clock skew).
+type oauth2TokenSource struct {
+	config *clientcredentials.Config
+	// httpClient is used for the token endpoint call so it honors the same
+	// proxy and TLS settings as the Collibra API requests.
+	httpClient *http.Client
+
+	mu      sync.Mutex
+	source  oauth2.TokenSource
+	current *oauth2.Token
+}
+
+func newOAuth2TokenSource(config OAuth2Config, transport http.RoundTripper) *oauth2TokenSource {
+	return &oauth2TokenSource{
+		config: &clientcredentials.Config{
+			ClientID:     config.ClientID,
+			ClientSecret: config.ClientSecret,
+			TokenURL:     config.TokenURL,
+			Scopes:       config.Scopes,
+		},
+		httpClient: &http.Client{Transport: transport},
+	}
+}
+
+func (s *oauth2TokenSource) token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.source == nil {
+		// The context only carries the HTTP client for the token endpoint; it
+		// is retained by the token source for every refresh, so it must not be
+		// a request-scoped context that gets cancelled. The returned source
+		// caches the token until it expires.
+		ctx := context.WithValue(context.Background(), oauth2.HTTPClient, s.httpClient)
+		s.source = s.config.TokenSource(ctx)
+	}
+	tok, err := s.source.Token()
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain OAuth2 token: %w", err)
+	}
+	s.current = tok
+	return tok, nil
+}
+
+// invalidate discards the cached token if it is still the one that was
+// rejected. Concurrent requests that were all refused with the same stale
+// token therefore trigger a single refresh rather than one each.
+func (s *oauth2TokenSource) invalidate(rejected *oauth2.Token) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.current != nil && s.current.AccessToken == rejected.AccessToken {
+		s.source = nil
+		s.current = nil
+	}
+}
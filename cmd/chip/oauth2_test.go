@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func newTokenServer(t *testing.T, issued *atomic.Int32) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("parse token request: %v", err)
+		}
+		if got := r.PostForm.Get("grant_type"); got != "client_credentials" {
+			t.Errorf("grant_type = %q, want client_credentials", got)
+		}
+		n := issued.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":3600}`, n)
+	}))
+}
+
+func TestOAuth2RoundTrip_CachesToken(t *testing.T) {
+	var issued atomic.Int32
+	tokenServer := newTokenServer(t, &issued)
+	defer tokenServer.Close()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer token-1" {
+			t.Errorf("Authorization = %q, want Bearer token-1", got)
+		}
+	}))
+	defer api.Close()
+
+	client := &collibraClient{
+		next:   http.DefaultTransport,
+		oauth2: newOAuth2TokenSource(OAuth2Config{TokenURL: tokenServer.URL, ClientID: "id", ClientSecret: "secret"}, http.DefaultTransport),
+	}
+	for range 3 {
+		req, _ := http.NewRequestWithContext(t.Context(), http.MethodGet, api.URL, nil)
+		resp, err := client.roundTripOAuth2(req)
+		if err != nil {
+			t.Fatalf("round trip: %v", err)
+		}
+		_ = resp.Body.Close()
+	}
+	if n := issued.Load(); n != 1 {
+		t.Fatalf("expected a single token to be issued, got %d", n)
+	}
+}
+
+func TestOAuth2RoundTrip_RetriesOnceWithFreshTokenOn401(t *testing.T) {
+	var issued atomic.Int32
+	tokenServer := newTokenServer(t, &issued)
+	defer tokenServer.Close()
+
+	var bodies []string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if r.Header.Get("Authorization") == "Bearer token-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer api.Close()
+
+	client := &collibraClient{
+		next:   http.DefaultTransport,
+		oauth2: newOAuth2TokenSource(OAuth2Config{TokenURL: tokenServer.URL, ClientID: "id", ClientSecret: "secret"}, http.DefaultTransport),
+	}
+	req, _ := http.NewRequestWithContext(t.Context(), http.MethodPost, api.URL, strings.NewReader(`{"a":1}`))
+	resp, err := client.roundTripOAuth2(req)
+	if err != nil {
+		t.Fatalf("round trip: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected retry to succeed, got status %d", resp.StatusCode)
+	}
+	if n := issued.Load(); n != 2 {
+		t.Fatalf("expected a fresh token after the 401, got %d tokens issued", n)
+	}
+	if len(bodies) != 2 || bodies[0] != `{"a":1}` || bodies[1] != `{"a":1}` {
+		t.Fatalf("expected the body to be replayed on retry, got %q", bodies)
+	}
+}
+
+func TestOAuth2RoundTrip_DoesNotRetryTwice(t *testing.T) {
+	var issued atomic.Int32
+	tokenServer := newTokenServer(t, &issued)
+	defer tokenServer.Close()
+
+	var calls atomic.Int32
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer api.Close()
+
+	client := &collibraClient{
+		next:   http.DefaultTransport,
+		oauth2: newOAuth2TokenSource(OAuth2Config{TokenURL: tokenServer.URL, ClientID: "id", ClientSecret: "secret"}, http.DefaultTransport),
+	}
+	req, _ := http.NewRequestWithContext(t.Context(), http.MethodGet, api.URL, nil)
+	resp, err := client.roundTripOAuth2(req)
+	if err != nil {
+		t.Fatalf("round trip: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected the second 401 to be returned, got %d", resp.StatusCode)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Fatalf("expected exactly one retry, got %d calls", n)
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sync"
+
+	"github.com/collibra/chip/pkg/chip"
+	"github.com/collibra/chip/pkg/skills"
+	"github.com/collibra/chip/pkg/tools"
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+)
+
+// toolConfigReloader applies changes to the tool settings of the config file
+// (enabled-tools, disabled-tools, enable-debug-tools, experimental and
+// skills-dir) to the running server, which notifies connected clients that
+// the tool list changed. Other settings only take effect on restart.
+type toolConfigReloader struct {
+	mu         sync.Mutex
+	server     *chip.Server
+	client     *http.Client
+	toolConfig *chip.ServerToolConfig
+}
+
+func newToolConfig(mcpConfig McpConfig) *chip.ServerToolConfig {
+	return &chip.ServerToolConfig{
+		EnabledTools:     mcpConfig.EnabledTools,
+		DisabledTools:    mcpConfig.DisabledTools,
+		EnableDebugTools: mcpConfig.EnableDebugTools,
+		Experimental:     mcpConfig.Experimental,
+		SkillsDir:        mcpConfig.SkillsDir,
+	}
+}
+
+// current returns the tool settings in effect.
+func (r *toolConfigReloader) current() *chip.ServerToolConfig {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.toolConfig
+}
+
+// watch re-applies the tool settings whenever the config file changes.
+// Flags and environment variables keep their precedence over the file.
+func (r *toolConfigReloader) watch() {
+	viper.OnConfigChange(func(event fsnotify.Event) {
+		var config Config
+		if err := viper.Unmarshal(&config); err != nil {
+			slog.Warn(fmt.Sprintf("Keeping previous tool configuration, unable to decode %s: %v", event.Name, err))
+			return
+		}
+		if err := r.apply(newToolConfig(config.Mcp)); err != nil {
+			slog.Warn(fmt.Sprintf("Keeping previous tool configuration: %v", err))
+		}
+	})
+	viper.WatchConfig()
+	slog.Info(fmt.Sprintf("Watching %s for tool configuration changes", viper.ConfigFileUsed()))
+}
+
+// apply registers the tools enabled by toolConfig and removes the others.
+// It does nothing when the tool settings did not change.
+func (r *toolConfigReloader) apply(toolConfig *chip.ServerToolConfig) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if sameToolConfig(r.toolConfig, toolConfig) {
+		return nil
+	}
+	if len(toolConfig.EnabledTools) > 0 && len(toolConfig.DisabledTools) > 0 {
+		return fmt.Errorf("cannot specify both enabled-tools and disabled-tools, only one can be specified")
+	}
+	validateExperimental(toolConfig.Experimental)
+	if skills.Enabled(toolConfig) {
+		// Load the catalog up front so a broken skills-dir leaves the
+		// registered tools untouched.
+		if _, err := skills.LoadWith(toolConfig.SkillsDir); err != nil {
+			return fmt.Errorf("failed to load skills: %w", err)
+		}
+	}
+	if skills.Enabled(toolConfig) != skills.Enabled(r.toolConfig) {
+		slog.Warn("The server instructions for the skills feature only change on restart")
+	}
+
+	err := r.server.SyncTools(func() error {
+		return tools.RegisterAll(r.server, r.client, toolConfig)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register tools: %w", err)
+	}
+	r.toolConfig = toolConfig
+	slog.Info(fmt.Sprintf("Reloaded tool configuration, %d tools enabled", len(r.server.ToolNames())))
+	return nil
+}
+
+func sameToolConfig(a, b *chip.ServerToolConfig) bool {
+	return slices.Equal(a.EnabledTools, b.EnabledTools) &&
+		slices.Equal(a.DisabledTools, b.DisabledTools) &&
+		a.EnableDebugTools == b.EnableDebugTools &&
+		slices.Equal(a.Experimental, b.Experimental) &&
+		a.SkillsDir == b.SkillsDir
+}
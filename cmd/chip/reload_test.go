@@ -0,0 +1,53 @@
+package main
+
+import (
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/collibra/chip/pkg/chip"
+	"github.com/collibra/chip/pkg/tools"
+)
+
+func newTestReloader(t *testing.T, toolConfig *chip.ServerToolConfig) *toolConfigReloader {
+	t.Helper()
+	server := chip.NewServer()
+	client := &http.Client{}
+	if err := tools.RegisterAll(server, client, toolConfig); err != nil {
+		t.Fatal(err)
+	}
+	return &toolConfigReloader{server: server, client: client, toolConfig: toolConfig}
+}
+
+func TestToolConfigReloader_AppliesToolSettings(t *testing.T) {
+	reloader := newTestReloader(t, &chip.ServerToolConfig{EnabledTools: []string{"search_asset_keyword", "get_asset_details"}})
+
+	err := reloader.apply(&chip.ServerToolConfig{
+		EnabledTools: []string{"search_asset_keyword", "dq_delete_job"},
+		Experimental: []string{tools.DataQualityFeatureName},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := reloader.server.ToolNames(); !slices.Equal(names, []string{"dq_delete_job", "search_asset_keyword"}) {
+		t.Errorf("unexpected tools after reload: %v", names)
+	}
+
+	err = reloader.apply(&chip.ServerToolConfig{EnabledTools: []string{"get_asset_details"}, DisabledTools: []string{"search_asset_keyword"}})
+	if err == nil {
+		t.Error("expected enabled-tools together with disabled-tools to be rejected")
+	}
+	if !slices.Equal(reloader.current().EnabledTools, []string{"search_asset_keyword", "dq_delete_job"}) {
+		t.Errorf("expected a rejected change to keep the previous settings, got %+v", reloader.current())
+	}
+}
+
+func TestSameToolConfig(t *testing.T) {
+	a := &chip.ServerToolConfig{Experimental: []string{"skills"}}
+	if !sameToolConfig(a, &chip.ServerToolConfig{Experimental: []string{"skills"}, EnabledTools: []string{}}) {
+		t.Error("expected an empty list to equal an unset one")
+	}
+	if sameToolConfig(a, &chip.ServerToolConfig{Experimental: []string{"skills"}, SkillsDir: "/skills"}) {
+		t.Error("expected a different skills-dir to be a change")
+	}
+}
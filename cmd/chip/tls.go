@@ -0,0 +1,149 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// tlsReloader serves the HTTP endpoint's certificate, and optionally the
+// client CA pool for mutual TLS, from files on disk. Both are re-read when
+// the files change, so rotated certificates take effect without a restart.
+// A reload that fails (e.g. a half-written key) is logged and the previous
+// material stays in use.
+type tlsReloader struct {
+	config TLSConfig
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+}
+
+func newTLSReloader(config TLSConfig) (*tlsReloader, error) {
+	r := &tlsReloader{config: config}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *tlsReloader) reload() error {
+	cert, err := tls.LoadX509KeyPair(r.config.Cert, r.config.Key)
+	if err != nil {
+		return fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+	var clientCAs *x509.CertPool
+	if r.config.ClientCA != "" {
+		pem, err := os.ReadFile(r.config.ClientCA)
+		if err != nil {
+			return fmt.Errorf("failed to read client CA file: %w", err)
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificates found in client CA file %s", r.config.ClientCA)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert = &cert
+	r.clientCAs = clientCAs
+	return nil
+}
+
+// tlsConfig returns the server TLS configuration. The certificate is
+// resolved per handshake, and so is the client CA pool, on a clone of the one
+// configuration, so reloads apply to new connections while ALPN and session
+// tickets stay those of the server.
+func (r *tlsReloader) tlsConfig() *tls.Config {
+	base := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// ListenAndServeTLS offers h2 on the configuration it is given, but
+		// not on the ones returned by GetConfigForClient.
+		NextProtos:     []string{"h2", "http/1.1"},
+		GetCertificate: r.certificate,
+	}
+	if r.config.ClientCA == "" {
+		return base
+	}
+	base.ClientAuth = tls.RequireAndVerifyClientCert
+	base.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+		config := base.Clone()
+		config.GetConfigForClient = nil
+		config.ClientCAs = r.clientCAs
+		return config, nil
+	}
+	return base
+}
+
+func (r *tlsReloader) certificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+// watch reloads the TLS material whenever one of its files changes. The
+// parent directories are watched rather than the files themselves so that
+// atomic replacements (rename over the old file, or the symlink swap used by
+// Kubernetes secret volumes) are picked up as well.
+func (r *tlsReloader) watch() (*fsnotify.Watcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	files := map[string]bool{}
+	for _, f := range []string{r.config.Cert, r.config.Key, r.config.ClientCA} {
+		if f == "" {
+			continue
+		}
+		abs, err := filepath.Abs(f)
+		if err != nil {
+			_ = watcher.Close()
+			return nil, err
+		}
+		files[abs] = true
+		if err := watcher.Add(filepath.Dir(abs)); err != nil {
+			_ = watcher.Close()
+			return nil, fmt.Errorf("failed to watch %s: %w", filepath.Dir(abs), err)
+		}
+	}
+
+	go func() {
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if !files[event.Name] && filepath.Base(event.Name) != "..data" {
+					continue
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+					continue
+				}
+				if err := r.reload(); err != nil {
+					slog.Warn(fmt.Sprintf("Keeping previous TLS certificate: %v", err))
+					continue
+				}
+				slog.Info("Reloaded TLS certificate")
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				if !errors.Is(err, fsnotify.ErrEventOverflow) {
+					slog.Warn(fmt.Sprintf("TLS certificate watcher error: %v", err))
+				}
+			}
+		}
+	}()
+	return watcher, nil
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeCert writes a self-signed certificate and key for the given common
+// name and returns the parsed certificate.
+func writeCert(t *testing.T, certFile, keyFile, commonName string) *x509.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: commonName},
+		DNSNames:              []string{"localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	return cert
+}
+
+func servedCommonName(t *testing.T, r *tlsReloader) string {
+	t.Helper()
+	cert, err := r.tlsConfig().GetCertificate(&tls.ClientHelloInfo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return leaf.Subject.CommonName
+}
+
+func TestTLSReloader_ReloadsOnFileChange(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	writeCert(t, certFile, keyFile, "first")
+
+	reloader, err := newTLSReloader(TLSConfig{Cert: certFile, Key: keyFile})
+	if err != nil {
+		t.Fatalf("newTLSReloader: %v", err)
+	}
+	watcher, err := reloader.watch()
+	if err != nil {
+		t.Fatalf("watch: %v", err)
+	}
+	defer func() { _ = watcher.Close() }()
+
+	if got := servedCommonName(t, reloader); got != "first" {
+		t.Fatalf("served certificate CN = %q, want first", got)
+	}
+
+	writeCert(t, certFile, keyFile, "second")
+	deadline := time.Now().Add(5 * time.Second)
+	for servedCommonName(t, reloader) != "second" {
+		if time.Now().After(deadline) {
+			t.Fatal("certificate was not reloaded after the files changed")
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestTLSReloader_KeepsPreviousCertificateOnInvalidFiles(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	writeCert(t, certFile, keyFile, "good")
+
+	reloader, err := newTLSReloader(TLSConfig{Cert: certFile, Key: keyFile})
+	if err != nil {
+		t.Fatalf("newTLSReloader: %v", err)
+	}
+	if err := os.WriteFile(keyFile, []byte("garbage"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := reloader.reload(); err == nil {
+		t.Fatal("expected reload to fail with an invalid key")
+	}
+	if got := servedCommonName(t, reloader); got != "good" {
+		t.Fatalf("served certificate CN = %q, want the previous one", got)
+	}
+}
+
+func TestTLSReloader_RequiresClientCertificateWithClientCA(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	serverCert := writeCert(t, certFile, keyFile, "localhost")
+	clientCertFile, clientKeyFile := filepath.Join(dir, "client.crt"), filepath.Join(dir, "client.key")
+	writeCert(t, clientCertFile, clientKeyFile, "client")
+
+	reloader, err := newTLSReloader(TLSConfig{Cert: certFile, Key: keyFile, ClientCA: clientCertFile})
+	if err != nil {
+		t.Fatalf("newTLSReloader: %v", err)
+	}
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.TLS = reloader.tlsConfig()
+	server.StartTLS()
+	defer server.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(serverCert)
+	newClient := func(certs ...tls.Certificate) *http.Client {
+		return &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots, Certificates: certs, ServerName: "localhost"}}}
+	}
+
+	if resp, err := newClient().Get(server.URL); err == nil {
+		_ = resp.Body.Close()
+		t.Fatal("expected the handshake to fail without a client certificate")
+	}
+
+	clientCert, err := tls.LoadX509KeyPair(clientCertFile, clientKeyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := newClient(clientCert).Get(server.URL)
+	if err != nil {
+		t.Fatalf("expected the request with a client certificate to succeed: %v", err)
+	}
+	_ = resp.Body.Close()
+}
+
+func TestTLSReloader_NegotiatesHTTP2AndResumesSessions(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	serverCert := writeCert(t, certFile, keyFile, "localhost")
+	clientCertFile, clientKeyFile := filepath.Join(dir, "client.crt"), filepath.Join(dir, "client.key")
+	writeCert(t, clientCertFile, clientKeyFile, "client")
+	clientCert, err := tls.LoadX509KeyPair(clientCertFile, clientKeyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(serverCert)
+
+	for _, config := range []TLSConfig{
+		{Cert: certFile, Key: keyFile},
+		{Cert: certFile, Key: keyFile, ClientCA: clientCertFile},
+	} {
+		reloader, err := newTLSReloader(config)
+		if err != nil {
+			t.Fatalf("newTLSReloader: %v", err)
+		}
+		// Serve as main does, so the server sets up ALPN itself.
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		server := &http.Server{Handler: http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}), TLSConfig: reloader.tlsConfig()}
+		go func() { _ = server.ServeTLS(listener, "", "") }()
+
+		sessions := tls.NewLRUClientSessionCache(1)
+		for i := range 2 {
+			// A new client per request, so that the second one resumes the
+			// TLS session of the first instead of reusing its connection.
+			client := &http.Client{Transport: &http.Transport{
+				TLSClientConfig:   &tls.Config{RootCAs: roots, Certificates: []tls.Certificate{clientCert}, ServerName: "localhost", ClientSessionCache: sessions},
+				ForceAttemptHTTP2: true,
+			}}
+			resp, err := client.Get("https://" + listener.Addr().String())
+			if err != nil {
+				t.Fatalf("client CA %q: %v", config.ClientCA, err)
+			}
+			_ = resp.Body.Close()
+			client.CloseIdleConnections()
+			if resp.TLS.NegotiatedProtocol != "h2" || resp.ProtoMajor != 2 {
+				t.Errorf("client CA %q: expected h2, got %q (%s)", config.ClientCA, resp.TLS.NegotiatedProtocol, resp.Proto)
+			}
+			if resumed := i == 1; resp.TLS.DidResume != resumed {
+				t.Errorf("client CA %q, request %d: expected resumption %v, got %v", config.ClientCA, i, resumed, resp.TLS.DidResume)
+			}
+		}
+		_ = server.Close()
+	}
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/collibra/chip/pkg/tools"
+)
+
+// formatToolGroupsForHelp renders the TOOL GROUPS block of --help output:
+// one line per group with the tools it holds.
+func formatToolGroupsForHelp() string {
+	groups := make([]string, 0, len(tools.ToolGroups))
+	for group := range tools.ToolGroups {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+	var b strings.Builder
+	for _, group := range groups {
+		fmt.Fprintf(&b, "  %-28s%s\n", group, strings.Join(tools.ToolGroups[group], ", "))
+	}
+	fmt.Fprintf(&b, "  %-28s%s\n", tools.WritesGroup, "Every tool not annotated as read-only.")
+	return b.String()
+}
@@ -0,0 +1,330 @@
+// Package audit keeps a compliance trail of agent-driven changes. A tool
+// middleware writes one JSON line per call to a tool that is not annotated
+// as read-only, recording who called it, with which (redacted) arguments,
+// how it ended and which Collibra resources it touched.
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/collibra/chip/pkg/chip"
+	"github.com/collibra/chip/pkg/dryrun"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// OutputStdout selects standard output as the audit sink. Only use it in
+// HTTP modes: in stdio mode standard output carries the MCP protocol.
+const OutputStdout = "stdout"
+
+const redacted = "[REDACTED]"
+
+// Config selects the audit sink and what is kept out of it.
+type Config struct {
+	// Output is OutputStdout or the path of a JSONL file.
+	Output string
+	// MaxSizeMB rotates the file once it reaches this size. Zero disables
+	// rotation.
+	MaxSizeMB int
+	// MaxBackups is the number of rotated files kept next to the current one
+	// (file.1 being the most recent).
+	MaxBackups int
+	// Redact lists argument field names, matched case-insensitively at any
+	// depth, whose values are replaced by "[REDACTED]".
+	Redact []string
+	// DefaultCaller identifies the caller when the request carries no
+	// credentials of its own, e.g. the server-wide Collibra account.
+	DefaultCaller string
+	// InstanceCallers overrides DefaultCaller for calls routed to the named
+	// Collibra instances, which may each have their own server-wide account.
+	InstanceCallers map[string]string
+}
+
+// Record is one audit log line.
+type Record struct {
+	Time      time.Time `json:"time"`
+	SessionID string    `json:"sessionId,omitempty"`
+	Tool      string    `json:"tool"`
+	Instance  string    `json:"instance,omitempty"`
+	Caller    string    `json:"caller,omitempty"`
+	// CallerVerified is set when Caller was verified by chip's inbound
+	// authentication, or is the server-wide account chip itself calls
+	// Collibra with. Other callers are claimed by the client or are
+	// fingerprints of its credentials.
+	CallerVerified bool           `json:"callerVerified"`
+	Arguments      map[string]any `json:"arguments,omitempty"`
+	Outcome        string         `json:"outcome"`
+	Status         string         `json:"status,omitempty"`
+	Error          string         `json:"error,omitempty"`
+	ErrorCode      string         `json:"errorCode,omitempty"`
+	ResourceIDs    []string       `json:"resourceIds,omitempty"`
+	// DryRun is set when the call ran in dry-run mode, so nothing it reports
+	// was persisted in Collibra.
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+type Logger struct {
+	mu              sync.Mutex
+	out             io.Writer
+	close           func() error
+	redact          map[string]bool
+	defaultCaller   string
+	instanceCallers map[string]string
+	now             func() time.Time
+}
+
+func New(config Config) (*Logger, error) {
+	l := &Logger{
+		redact:          map[string]bool{},
+		defaultCaller:   config.DefaultCaller,
+		instanceCallers: config.InstanceCallers,
+		now:             time.Now,
+	}
+	for _, field := range config.Redact {
+		l.redact[strings.ToLower(field)] = true
+	}
+	if config.Output == OutputStdout {
+		l.out = os.Stdout
+		return l, nil
+	}
+	file, err := openRotatingFile(config.Output, int64(config.MaxSizeMB)<<20, config.MaxBackups)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	l.out, l.close = file, file.Close
+	return l, nil
+}
+
+// Close releases the audit file.
+func (l *Logger) Close() error {
+	if l.close == nil {
+		return nil
+	}
+	return l.close()
+}
+
+// ToolMiddleware records every call to a tool that is not annotated as
+// read-only. A failure to write the record is logged but does not fail the
+// call, which has already taken effect in Collibra.
+func (l *Logger) ToolMiddleware() chip.ToolMiddleware {
+	return chip.ToolMiddlewareFunc(func(ctx context.Context, toolRequest *mcp.CallToolRequest, next chip.CallToolFunc) (*mcp.CallToolResult, error) {
+		metadata, ok := chip.GetToolMetadata(ctx)
+		if ok && metadata.ReadOnly() {
+			return next(ctx, toolRequest)
+		}
+
+		instance, _ := chip.GetCollibraInstance(ctx)
+		caller, verified := l.caller(toolRequest, instance)
+		record := Record{
+			Time:           l.now().UTC(),
+			Tool:           toolRequest.Params.Name,
+			Instance:       instance,
+			Caller:         caller,
+			CallerVerified: verified,
+			Arguments:      l.arguments(toolRequest.Params.Arguments),
+		}
+		if session := toolRequest.Session; session != nil {
+			record.SessionID = session.ID()
+		}
+
+		res, err := next(ctx, toolRequest)
+		var output map[string]any
+		if res != nil {
+			output = asMap(res.StructuredContent)
+		}
+		record.Outcome, record.Status, record.Error = outcome(res, output, err)
+		if err != nil || (res != nil && res.IsError) {
+			record.ErrorCode = string(chip.ResultErrorCode(res, err))
+		}
+		record.ResourceIDs = resourceIDs(output)
+		if res != nil && res.Meta[dryrun.MetaKey] != nil {
+			// The ids of the resources a dry run pretends to create are
+			// placeholders.
+			record.DryRun = true
+			record.ResourceIDs = slices.DeleteFunc(record.ResourceIDs, func(id string) bool { return id == dryrun.PlaceholderID })
+		}
+		if writeErr := l.write(record); writeErr != nil {
+			slog.ErrorContext(ctx, "Failed to write audit record", "tool", record.Tool, "error", writeErr)
+		}
+		return res, err
+	})
+}
+
+func (l *Logger) write(record Record) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, err = l.out.Write(append(line, '\n'))
+	return err
+}
+
+func (l *Logger) arguments(raw json.RawMessage) map[string]any {
+	var arguments map[string]any
+	if len(raw) == 0 || json.Unmarshal(raw, &arguments) != nil {
+		return nil
+	}
+	l.redactValue(arguments)
+	return arguments
+}
+
+func (l *Logger) redactValue(value any) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			if l.redact[strings.ToLower(key)] {
+				v[key] = redacted
+				continue
+			}
+			l.redactValue(child)
+		}
+	case []any:
+		for _, child := range v {
+			l.redactValue(child)
+		}
+	}
+}
+
+// caller derives who made the call, and whether the identity was verified.
+// Only the user of a token verified by the inbound authentication is a
+// verified identity. Otherwise the caller is taken from the credentials the
+// MCP client sent, which chip did not verify: the user name of basic auth, or
+// a fingerprint of a bearer token or API key, which must not be logged as is.
+// Without credentials the server-wide account of the instance the call is
+// routed to acts.
+func (l *Logger) caller(toolRequest *mcp.CallToolRequest, instance string) (string, bool) {
+	extra := toolRequest.GetExtra()
+	if extra != nil && extra.TokenInfo != nil && extra.TokenInfo.UserID != "" {
+		return extra.TokenInfo.UserID, true
+	}
+	var header http.Header
+	if extra != nil {
+		header = extra.Header
+	}
+	if authorization := header.Get("Authorization"); authorization != "" {
+		scheme, credentials, _ := strings.Cut(authorization, " ")
+		switch strings.ToLower(scheme) {
+		case "basic":
+			if decoded, err := base64.StdEncoding.DecodeString(credentials); err == nil {
+				user, _, _ := strings.Cut(string(decoded), ":")
+				return user, false
+			}
+		case "bearer":
+			return "token:" + fingerprint(credentials), false
+		}
+	}
+	if apiKey := header.Get("X-API-Key"); apiKey != "" {
+		return "api-key:" + fingerprint(apiKey), false
+	}
+	if caller, ok := l.instanceCallers[instance]; ok {
+		return caller, true
+	}
+	return l.defaultCaller, l.defaultCaller != ""
+}
+
+func fingerprint(secret string) string {
+	digest := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(digest[:6])
+}
+
+// outcome classifies a call. Many tools report failures in their output
+// rather than as an error, so a "status" string, a "success" flag or an
+// "error" message in the output is taken into account.
+func outcome(res *mcp.CallToolResult, output map[string]any, err error) (outcome, status, message string) {
+	if err != nil {
+		return "error", "", err.Error()
+	}
+	outcome = "success"
+	if res != nil && res.IsError {
+		outcome = "error"
+	}
+	if s, ok := output["status"].(string); ok {
+		status = s
+		if strings.Contains(strings.ToLower(s), "error") {
+			outcome = "error"
+		}
+	}
+	if success, ok := output["success"].(bool); ok && !success {
+		outcome = "error"
+	}
+	if e, ok := output["error"].(string); ok && e != "" {
+		outcome, message = "error", e
+	}
+	return outcome, status, message
+}
+
+// resourceIDs collects the identifiers found in a tool's output: string
+// values of "id" fields and of fields ending in "Id" or "Ids".
+func resourceIDs(output map[string]any) []string {
+	var ids []string
+	seen := map[string]bool{}
+	add := func(id string) {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	var walk func(value any)
+	walk = func(value any) {
+		switch v := value.(type) {
+		case map[string]any:
+			for _, key := range slices.Sorted(maps.Keys(v)) {
+				child := v[key]
+				switch {
+				case key == "id" || strings.HasSuffix(key, "Id"):
+					if id, ok := child.(string); ok {
+						add(id)
+						continue
+					}
+				case strings.HasSuffix(key, "Ids"):
+					if list, ok := child.([]any); ok {
+						for _, item := range list {
+							if id, ok := item.(string); ok {
+								add(id)
+							}
+						}
+						continue
+					}
+				}
+				walk(child)
+			}
+		case []any:
+			for _, child := range v {
+				walk(child)
+			}
+		}
+	}
+	walk(output)
+	return ids
+}
+
+// asMap converts a typed tool output into its generic JSON form.
+func asMap(output any) map[string]any {
+	if output == nil {
+		return nil
+	}
+	raw, err := json.Marshal(output)
+	if err != nil {
+		return nil
+	}
+	var fields map[string]any
+	if json.Unmarshal(raw, &fields) != nil {
+		return nil
+	}
+	return fields
+}
@@ -0,0 +1,183 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/collibra/chip/pkg/chip"
+	"github.com/collibra/chip/pkg/dryrun"
+	"github.com/modelcontextprotocol/go-sdk/auth"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func newBufferLogger(config Config) (*Logger, *bytes.Buffer) {
+	var buf bytes.Buffer
+	l := &Logger{out: &buf, redact: map[string]bool{}, defaultCaller: config.DefaultCaller, instanceCallers: config.InstanceCallers, now: time.Now}
+	for _, field := range config.Redact {
+		l.redact[strings.ToLower(field)] = true
+	}
+	return l, &buf
+}
+
+func callTool(t *testing.T, l *Logger, annotations *mcp.ToolAnnotations, header http.Header, arguments string, result any, err error) {
+	t.Helper()
+	request := &mcp.CallToolRequest{
+		Params: &mcp.CallToolParamsRaw{Name: "edit_asset", Arguments: json.RawMessage(arguments)},
+		Extra:  &mcp.RequestExtra{Header: header},
+	}
+	ctx := chip.SetToolMetadata(context.Background(), &chip.ToolMetadata{Name: "edit_asset", Annotations: annotations})
+	_, _ = l.ToolMiddleware().ToolHandle(ctx, request, func(context.Context, *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if err != nil {
+			return nil, err
+		}
+		return &mcp.CallToolResult{StructuredContent: result}, nil
+	})
+}
+
+func records(t *testing.T, buf *bytes.Buffer) []Record {
+	t.Helper()
+	var out []Record
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var r Record
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			t.Fatalf("invalid audit line %q: %v", line, err)
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+func TestToolMiddleware_RecordsWriteToolsOnly(t *testing.T) {
+	l, buf := newBufferLogger(Config{Redact: []string{"Password"}})
+	header := http.Header{"Authorization": {"Basic YWxpY2U6c2VjcmV0"}} // alice:secret
+
+	output := map[string]any{
+		"status":  "partial_success",
+		"asset":   map[string]any{"id": "asset-1", "name": "Revenue"},
+		"results": []any{map[string]any{"status": "success", "relationId": "rel-1"}},
+	}
+
+	callTool(t, l, &mcp.ToolAnnotations{ReadOnlyHint: true}, header, `{}`, nil, nil)
+	callTool(t, l, &mcp.ToolAnnotations{}, header, `{"assetId":"asset-1","credentials":{"password":"hunter2"}}`, output, nil)
+	callTool(t, l, nil, nil, `{}`, nil, errors.New("boom"))
+
+	got := records(t, buf)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 records (read-only call skipped), got %d", len(got))
+	}
+
+	r := got[0]
+	if r.Tool != "edit_asset" || r.Caller != "alice" || r.Outcome != "success" || r.Status != "partial_success" {
+		t.Errorf("unexpected record %+v", r)
+	}
+	if creds := r.Arguments["credentials"].(map[string]any); creds["password"] != "[REDACTED]" {
+		t.Errorf("expected the password argument to be redacted, got %v", creds["password"])
+	}
+	if strings.Join(r.ResourceIDs, ",") != "asset-1,rel-1" {
+		t.Errorf("unexpected resource ids %v", r.ResourceIDs)
+	}
+
+	if got[1].Outcome != "error" || got[1].Error != "boom" {
+		t.Errorf("expected a failed call to be recorded as an error, got %+v", got[1])
+	}
+}
+
+func TestToolMiddleware_RecordsDryRuns(t *testing.T) {
+	l, buf := newBufferLogger(Config{})
+	request := &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: "create_asset", Arguments: json.RawMessage(`{}`)}}
+	ctx := chip.SetToolMetadata(context.Background(), &chip.ToolMetadata{Name: "create_asset"})
+	_, _ = l.ToolMiddleware().ToolHandle(ctx, request, func(context.Context, *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return &mcp.CallToolResult{
+			Meta:              mcp.Meta{dryrun.MetaKey: map[string]any{"persisted": false}},
+			StructuredContent: map[string]any{"success": true, "assetId": dryrun.PlaceholderID, "domainId": "domain-1"},
+		}, nil
+	})
+
+	r := records(t, buf)[0]
+	if !r.DryRun || strings.Join(r.ResourceIDs, ",") != "domain-1" {
+		t.Errorf("expected a dry run without the placeholder id, got %+v", r)
+	}
+}
+
+func TestCaller(t *testing.T) {
+	l, _ := newBufferLogger(Config{DefaultCaller: "svc-account", InstanceCallers: map[string]string{"prod": "oauth2:prod-client"}})
+	jwt := "eyJhbGciOiJub25lIn0." + "eyJzdWIiOiJib2IifQ" + ".sig" // {"sub":"bob"}, not verified
+
+	tests := []struct {
+		name         string
+		header       http.Header
+		tokenInfo    *auth.TokenInfo
+		instance     string
+		want         string
+		wantVerified bool
+	}{
+		{"verified token", http.Header{"Authorization": {"Bearer " + jwt}}, &auth.TokenInfo{UserID: "carol"}, "", "carol", true},
+		{"basic auth", http.Header{"Authorization": {"Basic YWxpY2U6c2VjcmV0"}}, nil, "prod", "alice", false},
+		{"unverified JWT", http.Header{"Authorization": {"Bearer " + jwt}}, nil, "", "token:" + fingerprint(jwt), false},
+		{"opaque token", http.Header{"Authorization": {"Bearer opaque"}}, nil, "", "token:" + fingerprint("opaque"), false},
+		{"API key", http.Header{"X-Api-Key": {"k1"}}, nil, "", "api-key:" + fingerprint("k1"), false},
+		{"no credentials", nil, nil, "", "svc-account", true},
+		{"no credentials on another instance", nil, nil, "prod", "oauth2:prod-client", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := &mcp.CallToolRequest{Extra: &mcp.RequestExtra{Header: tt.header, TokenInfo: tt.tokenInfo}}
+			if got, verified := l.caller(request, tt.instance); got != tt.want || verified != tt.wantVerified {
+				t.Errorf("caller = %q (verified %v), want %q (verified %v)", got, verified, tt.want, tt.wantVerified)
+			}
+		})
+	}
+}
+
+func TestOutcome_UsesToolReportedFailures(t *testing.T) {
+	tests := []struct {
+		output map[string]any
+		want   string
+	}{
+		{map[string]any{"status": "deleted"}, "success"},
+		{map[string]any{"status": "validation_error"}, "error"},
+		{map[string]any{"success": false}, "error"},
+		{map[string]any{"error": "manifest is required"}, "error"},
+	}
+	for _, tt := range tests {
+		if got, _, _ := outcome(&mcp.CallToolResult{}, tt.output, nil); got != tt.want {
+			t.Errorf("outcome(%v) = %s, want %s", tt.output, got, tt.want)
+		}
+	}
+}
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	file, err := openRotatingFile(path, 10, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = file.Close() }()
+
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		if _, err := file.Write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for name, want := range map[string]string{path: "fourth\n", path + ".1": "third\n", path + ".2": "second\n"} {
+		got, err := os.ReadFile(name)
+		if err != nil || string(got) != want {
+			t.Errorf("%s = %q (%v), want %q", filepath.Base(name), got, err, want)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Error("expected backups beyond max-backups to be dropped")
+	}
+}
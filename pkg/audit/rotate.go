@@ -0,0 +1,85 @@
+package audit
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+)
+
+// rotatingFile appends to path and, once a write would grow it beyond
+// maxSize, renames it to path.1 (shifting older backups up to
+// path.<maxBackups>, dropping the oldest) and starts a new file.
+type rotatingFile struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+func openRotatingFile(path string, maxSize int64, maxBackups int) (*rotatingFile, error) {
+	r := &rotatingFile{path: path, maxSize: maxSize, maxBackups: maxBackups}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *rotatingFile) open() error {
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+	r.file, r.size = file, info.Size()
+	return nil
+}
+
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.maxSize > 0 && r.size > 0 && r.size+int64(len(p)) > r.maxSize {
+		if err := r.rotate(); err != nil {
+			return 0, fmt.Errorf("rotating %s: %w", r.path, err)
+		}
+	}
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+func (r *rotatingFile) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return err
+	}
+	var err error
+	if r.maxBackups > 0 {
+		for i := r.maxBackups - 1; i >= 1; i-- {
+			_ = os.Rename(r.backup(i), r.backup(i+1))
+		}
+		err = os.Rename(r.path, r.backup(1))
+	} else {
+		err = os.Remove(r.path)
+	}
+	if err != nil {
+		// Keep appending to the current file rather than losing records.
+		slog.Warn("Failed to rotate audit log", "path", r.path, "error", err)
+	}
+	return r.open()
+}
+
+func (r *rotatingFile) backup(i int) string {
+	return fmt.Sprintf("%s.%d", r.path, i)
+}
+
+func (r *rotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.file.Close()
+}
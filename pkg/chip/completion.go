@@ -0,0 +1,97 @@
+package chip
+
+import (
+	"cmp"
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// maxCompletionValues is the most values a completion/complete result may
+// carry, as set by the MCP specification.
+const maxCompletionValues = 100
+
+// CompleteFunc returns the candidate values of an argument for the
+// completion request. It may return candidates that do not start with the
+// value typed so far; the server keeps those that do.
+type CompleteFunc func(ctx context.Context, req *mcp.CompleteRequest) ([]string, error)
+
+// completionKey identifies a completed argument: the name of its prompt, or
+// the URI template of its resource template, and its name.
+type completionKey struct {
+	ref      string
+	argument string
+}
+
+// AddCompletion completes the argument of ref, a prompt name or a resource
+// template URI template, with complete, replacing any previous completion of
+// that argument.
+func (s *Server) AddCompletion(ref, argument string, complete CompleteFunc) {
+	s.completionsMu.Lock()
+	defer s.completionsMu.Unlock()
+	s.completions[completionKey{ref: ref, argument: argument}] = complete
+}
+
+// RemoveCompletions stops completing the named arguments of ref.
+func (s *Server) RemoveCompletions(ref string, arguments ...string) {
+	s.completionsMu.Lock()
+	defer s.completionsMu.Unlock()
+	for _, argument := range arguments {
+		delete(s.completions, completionKey{ref: ref, argument: argument})
+	}
+}
+
+// complete handles completion/complete. Arguments without a completion, and
+// completions that fail, complete to nothing: a client asks while the user
+// types, and has no use for an error.
+func (s *Server) complete(ctx context.Context, req *mcp.CompleteRequest) (*mcp.CompleteResult, error) {
+	result := &mcp.CompleteResult{Completion: mcp.CompletionResultDetails{Values: []string{}}}
+	if req.Params.Ref == nil {
+		return result, nil
+	}
+	key := completionKey{ref: req.Params.Ref.Name, argument: req.Params.Argument.Name}
+	if req.Params.Ref.Type == "ref/resource" {
+		key.ref = req.Params.Ref.URI
+	}
+	s.completionsMu.RLock()
+	complete := s.completions[key]
+	s.completionsMu.RUnlock()
+	if complete == nil {
+		return result, nil
+	}
+	candidates, err := complete(ctx, req)
+	if err != nil {
+		slog.WarnContext(ctx, "could not complete argument", "ref", key.ref, "argument", key.argument, "error", err)
+		return result, nil
+	}
+	values := matchPrefix(candidates, req.Params.Argument.Value)
+	result.Completion.Total = len(values)
+	if len(values) > maxCompletionValues {
+		values = values[:maxCompletionValues]
+		result.Completion.HasMore = true
+	}
+	result.Completion.Values = values
+	return result, nil
+}
+
+// matchPrefix returns the distinct candidates starting with prefix, ignoring
+// case and surrounding whitespace, sorted case-insensitively.
+func matchPrefix(candidates []string, prefix string) []string {
+	prefix = strings.ToLower(strings.TrimSpace(prefix))
+	seen := make(map[string]bool, len(candidates))
+	matches := []string{}
+	for _, candidate := range candidates {
+		if candidate == "" || seen[candidate] || !strings.HasPrefix(strings.ToLower(candidate), prefix) {
+			continue
+		}
+		seen[candidate] = true
+		matches = append(matches, candidate)
+	}
+	slices.SortFunc(matches, func(a, b string) int {
+		return cmp.Or(cmp.Compare(strings.ToLower(a), strings.ToLower(b)), cmp.Compare(a, b))
+	})
+	return matches
+}
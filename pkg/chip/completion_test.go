@@ -0,0 +1,72 @@
+package chip
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestServer_CompleteFiltersByPrefix(t *testing.T) {
+	chipServer := NewServer()
+	chipServer.AddCompletion("prompt", "status", func(context.Context, *mcp.CompleteRequest) ([]string, error) {
+		return []string{"accepted", "Candidate", "Accepted", "Obsolete", "Accepted"}, nil
+	})
+	chipServer.AddCompletion("prompt", "many", func(context.Context, *mcp.CompleteRequest) ([]string, error) {
+		var names []string
+		for i := range 150 {
+			names = append(names, fmt.Sprintf("name %03d", i))
+		}
+		return names, nil
+	})
+	chipServer.AddCompletion("prompt", "failing", func(context.Context, *mcp.CompleteRequest) ([]string, error) {
+		return nil, errors.New("boom")
+	})
+	chipServer.AddCompletion("collibra://item/{id}", "id", func(context.Context, *mcp.CompleteRequest) ([]string, error) {
+		return []string{"42"}, nil
+	})
+	chipSession := newChipSession(t.Context(), chipServer)
+	defer closeSilently(chipSession)
+
+	completeRef := func(ref *mcp.CompleteReference, argument, value string) mcp.CompletionResultDetails {
+		t.Helper()
+		res, err := chipSession.Complete(t.Context(), &mcp.CompleteParams{
+			Ref:      ref,
+			Argument: mcp.CompleteParamsArgument{Name: argument, Value: value},
+		})
+		if err != nil {
+			t.Fatalf("%s=%q: %v", argument, value, err)
+		}
+		return res.Completion
+	}
+	complete := func(argument, value string) mcp.CompletionResultDetails {
+		t.Helper()
+		return completeRef(&mcp.CompleteReference{Type: "ref/prompt", Name: "prompt"}, argument, value)
+	}
+
+	if got := complete("status", " AC"); !slices.Equal(got.Values, []string{"Accepted", "accepted"}) {
+		t.Errorf("expected the distinct names starting with ac, got %v", got.Values)
+	}
+	if got := complete("many", ""); len(got.Values) != 100 || !got.HasMore || got.Total != 150 {
+		t.Errorf("expected the first 100 of 150 names, got %d (hasMore=%v, total=%d)", len(got.Values), got.HasMore, got.Total)
+	}
+	for _, argument := range []string{"failing", "unknown"} {
+		if got := complete(argument, ""); got.Values == nil || len(got.Values) != 0 {
+			t.Errorf("%s: expected no values, got %#v", argument, got.Values)
+		}
+	}
+	if got := completeRef(&mcp.CompleteReference{Type: "ref/prompt", Name: "other"}, "status", ""); len(got.Values) != 0 {
+		t.Errorf("expected no values for the argument of another prompt, got %v", got.Values)
+	}
+	if got := completeRef(&mcp.CompleteReference{Type: "ref/resource", URI: "collibra://item/{id}"}, "id", ""); !slices.Equal(got.Values, []string{"42"}) {
+		t.Errorf("expected the resource template argument to complete, got %v", got.Values)
+	}
+
+	chipServer.RemoveCompletions("prompt", "status")
+	if got := complete("status", ""); len(got.Values) != 0 {
+		t.Errorf("expected no values after RemoveCompletions, got %v", got.Values)
+	}
+}
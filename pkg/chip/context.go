@@ -12,7 +12,11 @@ type contextKey int
 const (
 	callToolRequestKey contextKey = iota
 	collibraHostKey
+	collibraInstanceKey
 	initParamsKey
+	serverCredentialsKey
+	sessionKey
+	toolMetadataKey
 )
 
 func SetCallToolRequest(ctx context.Context, toolRequest *mcp.CallToolRequest) context.Context {
@@ -33,6 +37,40 @@ func GetCollibraHost(ctx context.Context) (string, bool) {
 	return collibraHost, ok
 }
 
+// SetCollibraInstance records the name of the Collibra instance profile the
+// tool call is routed to.
+func SetCollibraInstance(ctx context.Context, instance string) context.Context {
+	return context.WithValue(ctx, collibraInstanceKey, instance)
+}
+
+func GetCollibraInstance(ctx context.Context) (string, bool) {
+	instance, ok := ctx.Value(collibraInstanceKey).(string)
+	return instance, ok
+}
+
+// SetServerCredentials records whether chip authenticates to the Collibra
+// instance of ctx with credentials of its own, rather than forwarding the
+// caller's Authorization header.
+func SetServerCredentials(ctx context.Context, serverCredentials bool) context.Context {
+	return context.WithValue(ctx, serverCredentialsKey, serverCredentials)
+}
+
+func HasServerCredentials(ctx context.Context) bool {
+	serverCredentials, _ := ctx.Value(serverCredentialsKey).(bool)
+	return serverCredentials
+}
+
+// SetToolMetadata records the metadata of the tool being called, so tool
+// middlewares can act on its annotations and permissions.
+func SetToolMetadata(ctx context.Context, metadata *ToolMetadata) context.Context {
+	return context.WithValue(ctx, toolMetadataKey, metadata)
+}
+
+func GetToolMetadata(ctx context.Context) (*ToolMetadata, bool) {
+	metadata, ok := ctx.Value(toolMetadataKey).(*ToolMetadata)
+	return metadata, ok
+}
+
 func SetInitParams(ctx context.Context, params *mcp.InitializeParams) context.Context {
 	return context.WithValue(ctx, initParamsKey, params)
 }
@@ -43,6 +81,9 @@ func GetInitParams(ctx context.Context) (*mcp.InitializeParams, bool) {
 }
 
 func GetSessionId(ctx context.Context) string {
+	if session, ok := GetSession(ctx); ok {
+		return session.ID
+	}
 	toolRequest, ok := GetCallToolRequest(ctx)
 	if ok {
 		return toolRequest.GetSession().ID()
@@ -0,0 +1,166 @@
+package chip
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ErrorCode is the stable, machine-readable category of a failed tool call.
+// It is reported in the _meta of isError results under ErrorMetaKey and at
+// the start of their text, so agents and dashboards can react by category.
+type ErrorCode string
+
+const (
+	ErrorNotFound         ErrorCode = "not_found"
+	ErrorPermissionDenied ErrorCode = "permission_denied"
+	ErrorConflict         ErrorCode = "conflict"
+	ErrorRateLimited      ErrorCode = "rate_limited"
+	ErrorValidation       ErrorCode = "validation"
+	ErrorUpstream         ErrorCode = "upstream"
+	ErrorInternal         ErrorCode = "internal"
+)
+
+// ErrorMetaKey is the _meta key of the error details of isError results.
+const ErrorMetaKey = "collibra.com/error"
+
+// CodedError is implemented by errors that belong to a category of the
+// taxonomy. Errors that do not are reported as ErrorInternal.
+type CodedError interface {
+	error
+	ErrorCode() ErrorCode
+}
+
+// ErrorDetailer is implemented by errors that add details to the _meta of the
+// result, e.g. the invalid fields of a validation error.
+type ErrorDetailer interface {
+	ErrorDetails() map[string]any
+}
+
+// ErrorCodeOf returns the category of err.
+func ErrorCodeOf(err error) ErrorCode {
+	var coded CodedError
+	if errors.As(err, &coded) {
+		return coded.ErrorCode()
+	}
+	return ErrorInternal
+}
+
+// ResultErrorCode returns the category of a failed tool call: that of err, or
+// the code in the _meta of res when a middleware returned an isError result
+// instead of an error.
+func ResultErrorCode(res *mcp.CallToolResult, err error) ErrorCode {
+	if err == nil && res != nil {
+		details, _ := res.Meta[ErrorMetaKey].(map[string]any)
+		switch code := details["code"].(type) {
+		case ErrorCode:
+			return code
+		case string:
+			return ErrorCode(code)
+		}
+	}
+	return ErrorCodeOf(err)
+}
+
+// Errorf formats an error of the category code, as fmt.Errorf does, for
+// tools that reject a call themselves, e.g. on an invalid argument.
+func Errorf(code ErrorCode, format string, args ...any) error {
+	return &codedError{err: fmt.Errorf(format, args...), code: code}
+}
+
+type codedError struct {
+	err  error
+	code ErrorCode
+}
+
+func (e *codedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *codedError) Unwrap() error {
+	return e.err
+}
+
+func (e *codedError) ErrorCode() ErrorCode {
+	return e.code
+}
+
+// toolError is the error of a tool call, categorized by RegisterTool.
+type toolError struct {
+	err         error
+	code        ErrorCode
+	permissions []string
+}
+
+// newToolError categorizes err. When Collibra denies a call without naming
+// the missing permission, the permissions the tool needs are reported.
+func newToolError(err error, metadata *ToolMetadata) *toolError {
+	te := &toolError{err: err, code: ErrorCodeOf(err)}
+	if te.code == ErrorPermissionDenied {
+		te.permissions = metadata.Permissions
+	}
+	return te
+}
+
+func (e *toolError) Error() string {
+	return e.err.Error()
+}
+
+func (e *toolError) Unwrap() error {
+	return e.err
+}
+
+func (e *toolError) ErrorCode() ErrorCode {
+	return e.code
+}
+
+func (e *toolError) ErrorDetails() map[string]any {
+	details := map[string]any{}
+	var detailer ErrorDetailer
+	if errors.As(e.err, &detailer) {
+		for key, value := range detailer.ErrorDetails() {
+			details[key] = value
+		}
+	}
+	if _, ok := details["permissions"]; !ok && len(e.permissions) > 0 {
+		details["permissions"] = e.permissions
+	}
+	return details
+}
+
+// toolErrors completes the isError results of tool calls: the error code and
+// details go to _meta and the code prefixes the text. Calls rejected by the
+// SDK before reaching the tool, such as invalid arguments, are validation
+// errors.
+func toolErrors(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		result, err := next(ctx, method, req)
+		res, ok := result.(*mcp.CallToolResult)
+		if method != "tools/call" || err != nil || !ok || res == nil || !res.IsError {
+			return result, err
+		}
+		details := map[string]any{}
+		var te *toolError
+		if errors.As(res.GetError(), &te) {
+			details = te.ErrorDetails()
+			details["code"] = te.code
+		} else if res.GetError() != nil {
+			details["code"] = ErrorValidation
+		} else {
+			// An isError result built by a middleware.
+			return result, err
+		}
+		if res.Meta == nil {
+			res.Meta = mcp.Meta{}
+		}
+		res.Meta[ErrorMetaKey] = details
+		if len(res.Content) > 0 {
+			if text, ok := res.Content[0].(*mcp.TextContent); ok {
+				text.Text = fmt.Sprintf("[%s] %s", details["code"], text.Text)
+			}
+		}
+		return result, err
+	}
+}
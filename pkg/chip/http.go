@@ -2,6 +2,7 @@ package chip
 
 import (
 	"net/http"
+	"strings"
 )
 
 type CollibraClient struct {
@@ -23,3 +24,16 @@ func (c *CollibraClient) RoundTrip(request *http.Request) (*http.Response, error
 	reqClone.Header.Set("User-Agent", "Collibra MCP/"+Version)
 	return c.next.RoundTrip(reqClone)
 }
+
+// APIFamily groups an upstream Collibra request path by its first two
+// segments (e.g. "/rest/2.0", "/rest/dq", "/graphql/knowledgeGraph",
+// "/rest/aiCopilot"). The set of families is bounded by the paths the
+// clients call, which makes it safe to use as a metric label or a key for
+// per-API settings.
+func APIFamily(requestPath string) string {
+	segments := strings.SplitN(strings.TrimPrefix(requestPath, "/"), "/", 3)
+	if len(segments) < 2 || segments[0] == "" || segments[1] == "" {
+		return "other"
+	}
+	return "/" + segments[0] + "/" + segments[1]
+}
@@ -0,0 +1,21 @@
+package chip
+
+import "testing"
+
+func TestAPIFamily(t *testing.T) {
+	cases := map[string]string{
+		"/rest/2.0/assets/123":               "/rest/2.0",
+		"/rest/dq/1.0/jobs":                  "/rest/dq",
+		"/graphql/knowledgeGraph/v1":         "/graphql/knowledgeGraph",
+		"/rest/aiCopilot/v1/tools/assetChat": "/rest/aiCopilot",
+		"rest/2.0/search":                    "/rest/2.0",
+		"/rest":                              "other",
+		"/":                                  "other",
+		"":                                   "other",
+	}
+	for path, want := range cases {
+		if got := APIFamily(path); got != want {
+			t.Errorf("APIFamily(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
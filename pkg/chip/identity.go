@@ -0,0 +1,28 @@
+package chip
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// CallerIdentity identifies the credentials chip sends to the Collibra
+// instance of ctx on behalf of the caller, as a hash of the Authorization
+// header the MCP client forwards so that it is not kept in memory as is. It
+// is empty when chip authenticates with its own credentials, which every
+// caller then shares, whatever header authenticated them to chip, and when
+// the request carries no credentials. Caches of what Collibra returns are
+// scoped to it, since that depends on the caller's permissions.
+func CallerIdentity(ctx context.Context, extra *mcp.RequestExtra) string {
+	if HasServerCredentials(ctx) || extra == nil || extra.Header == nil {
+		return ""
+	}
+	authorization := extra.Header.Get("Authorization")
+	if authorization == "" {
+		return ""
+	}
+	digest := sha256.Sum256([]byte(authorization))
+	return hex.EncodeToString(digest[:])
+}
@@ -0,0 +1,33 @@
+package chip
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// NotifyProgress reports that a tool call has done done of total steps, with
+// a short message, to the client that called it. The notification is only
+// sent when the client asked for progress by passing a progress token with
+// the call; otherwise NotifyProgress does nothing, so tools can report
+// progress unconditionally.
+func NotifyProgress(ctx context.Context, done, total int, message string) {
+	toolRequest, ok := GetCallToolRequest(ctx)
+	if !ok || toolRequest.Session == nil || toolRequest.Params == nil {
+		return
+	}
+	token := toolRequest.Params.GetProgressToken()
+	if token == nil {
+		return
+	}
+	err := toolRequest.Session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
+		ProgressToken: token,
+		Progress:      float64(done),
+		Total:         float64(total),
+		Message:       message,
+	})
+	if err != nil {
+		slog.DebugContext(ctx, "could not send progress notification", "error", err)
+	}
+}
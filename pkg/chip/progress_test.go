@@ -0,0 +1,59 @@
+package chip
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestNotifyProgress_SendsToTheProgressTokenOfTheCall(t *testing.T) {
+	chipServer := NewServer()
+	RegisterTool(chipServer, &Tool[toolInput, toolOutput]{
+		Name:        "fan_out",
+		Description: "Reports progress for testing.",
+		Handler: func(ctx context.Context, _ toolInput) (toolOutput, error) {
+			NotifyProgress(ctx, 1, 2, "first")
+			NotifyProgress(ctx, 2, 2, "second")
+			return toolOutput{}, nil
+		},
+	})
+
+	progress := make(chan *mcp.ProgressNotificationParams, 2)
+	t1, t2 := mcp.NewInMemoryTransports()
+	if _, err := chipServer.Connect(t.Context(), t1, nil); err != nil {
+		t.Fatal(err)
+	}
+	chipClient := mcp.NewClient(&mcp.Implementation{Name: "client", Version: "v0.0.1"}, &mcp.ClientOptions{
+		ProgressNotificationHandler: func(_ context.Context, req *mcp.ProgressNotificationClientRequest) {
+			progress <- req.Params
+		},
+	})
+	chipSession, err := chipClient.Connect(t.Context(), t2, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closeSilently(chipSession)
+
+	// Without a progress token nothing is sent.
+	if _, err := chipSession.CallTool(t.Context(), &mcp.CallToolParams{Name: "fan_out", Arguments: map[string]any{"input": "x"}}); err != nil {
+		t.Fatal(err)
+	}
+	params := &mcp.CallToolParams{Name: "fan_out", Arguments: map[string]any{"input": "x"}}
+	params.SetProgressToken("call-1")
+	if _, err := chipSession.CallTool(t.Context(), params); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{"first", "second"} {
+		select {
+		case p := <-progress:
+			if p.ProgressToken != "call-1" || p.Message != want || p.Total != 2 {
+				t.Errorf("expected %q for call-1, got %+v", want, p)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("expected the %q progress notification", want)
+		}
+	}
+}
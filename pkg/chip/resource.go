@@ -0,0 +1,77 @@
+package chip
+
+import (
+	"context"
+	"slices"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// recentResourcesKey is the session value holding the resources the session
+// touched, most recent first.
+const recentResourcesKey = "chip.recentResources"
+
+// maxRecentResources bounds the resources kept per session.
+const maxRecentResources = 50
+
+// TouchResource records that the session of ctx read or worked on resource.
+// resources/list returns the resources a session touched, most recent first,
+// on top of the resources registered on the server. Without session state,
+// as in stateless HTTP mode, nothing is recorded.
+func TouchResource(ctx context.Context, resource *mcp.Resource) {
+	session, ok := GetSession(ctx)
+	if !ok {
+		return
+	}
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	recent, _ := session.values[recentResourcesKey].([]*mcp.Resource)
+	if i := slices.IndexFunc(recent, func(r *mcp.Resource) bool { return r.URI == resource.URI }); i >= 0 {
+		if resource.Title == "" {
+			// Keep the title a previous read found.
+			touched := *resource
+			touched.Title = recent[i].Title
+			resource = &touched
+		}
+		recent = slices.Delete(slices.Clone(recent), i, i+1)
+	}
+	recent = append([]*mcp.Resource{resource}, recent...)
+	if len(recent) > maxRecentResources {
+		recent = recent[:maxRecentResources]
+	}
+	session.values[recentResourcesKey] = recent
+}
+
+// RecentResources returns the resources touched by the session of ctx, most
+// recent first.
+func RecentResources(ctx context.Context) []*mcp.Resource {
+	session, ok := GetSession(ctx)
+	if !ok {
+		return nil
+	}
+	recent, _ := session.Value(recentResourcesKey)
+	resources, _ := recent.([]*mcp.Resource)
+	return resources
+}
+
+// listRecentResources prepends the resources the session touched to the
+// first page of resources/list.
+func listRecentResources(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		res, err := next(ctx, method, req)
+		listRequest, ok := req.(*mcp.ListResourcesRequest)
+		if err != nil || !ok || (listRequest.Params != nil && listRequest.Params.Cursor != "") {
+			return res, err
+		}
+		listResult, ok := res.(*mcp.ListResourcesResult)
+		if !ok {
+			return res, err
+		}
+		recent := RecentResources(ctx)
+		if len(recent) == 0 {
+			return res, err
+		}
+		listResult.Resources = append(slices.Clone(recent), listResult.Resources...)
+		return listResult, nil
+	}
+}
@@ -6,12 +6,15 @@ import (
 	"fmt"
 	"log"
 	"log/slog"
+	"maps"
+	"path"
 	"reflect"
 	"slices"
 	"strings"
 	"sync"
 
 	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/jsonrpc"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -35,7 +38,8 @@ func (f ToolMiddlewareFunc) ToolHandle(ctx context.Context, toolRequest *mcp.Cal
 // initParamsStore holds the last InitializeParams received from a client.
 // In stateless HTTP mode each call gets a fresh session, so the params from
 // the initial handshake are captured here and re-injected into the per-request
-// context by the receiving middleware.
+// context by the receiving middleware. Servers with a session store keep the
+// params per session instead.
 type initParamsStore struct {
 	mu     sync.RWMutex
 	params *mcp.InitializeParams
@@ -54,9 +58,20 @@ func (s *initParamsStore) get() *mcp.InitializeParams {
 }
 
 type Server struct {
-	toolMiddlewares  []ToolMiddleware
+	toolMiddlewares []ToolMiddleware
+	// syncMu serializes SyncTools; toolsMu guards toolMetadata and syncedTools.
+	syncMu           sync.Mutex
+	toolsMu          sync.RWMutex
 	toolMetadata     map[string]*ToolMetadata
+	syncedTools      map[string]bool
+	toolArguments    map[string]*jsonschema.Schema
+	readOnly         bool
+	sessions         *sessionTracker
 	instructionParts []string
+	// completionsMu guards completions, the completion of each prompt and
+	// resource template argument.
+	completionsMu sync.RWMutex
+	completions   map[completionKey]CompleteFunc
 	mcp.Server
 }
 
@@ -64,7 +79,9 @@ func NewServer(opts ...ServerOption) *Server {
 	s := &Server{
 		toolMiddlewares:  []ToolMiddleware{},
 		toolMetadata:     make(map[string]*ToolMetadata),
+		toolArguments:    make(map[string]*jsonschema.Schema),
 		instructionParts: []string{instructions},
+		completions:      make(map[completionKey]CompleteFunc),
 	}
 
 	for _, opt := range opts {
@@ -76,19 +93,35 @@ func NewServer(opts ...ServerOption) *Server {
 		Title:   "Collibra Data Intelligence Platform MCP Server",
 		Version: Version,
 	}, &mcp.ServerOptions{
-		Instructions: joinInstructions(s.instructionParts),
+		Instructions:      joinInstructions(s.instructionParts),
+		CompletionHandler: s.complete,
 	})
 
+	// Added first so that it runs inside the middleware that sets the session.
+	s.AddReceivingMiddleware(listRecentResources)
 	store := &initParamsStore{}
 	s.AddReceivingMiddleware(func(next mcp.MethodHandler) mcp.MethodHandler {
 		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			var initParams *mcp.InitializeParams
 			if method == "initialize" {
-				if params, ok := req.GetParams().(*mcp.InitializeParams); ok {
-					store.set(params)
+				initParams, _ = req.GetParams().(*mcp.InitializeParams)
+			}
+			ss, _ := req.GetSession().(*mcp.ServerSession)
+			if s.sessions != nil && ss != nil {
+				if session := s.sessions.session(ss, initParams); session != nil {
+					ctx = SetSession(ctx, session)
+					if params := session.InitializeParams(); params != nil {
+						ctx = SetInitParams(ctx, params)
+					}
 				}
+				return next(ctx, method, req)
+			}
+
+			if initParams != nil {
+				store.set(initParams)
 			}
 			p := store.get()
-			if ss, ok := req.GetSession().(*mcp.ServerSession); ok {
+			if ss != nil {
 				if sp := ss.InitializeParams(); sp != nil && sp.ClientInfo != nil {
 					p = sp
 				}
@@ -99,6 +132,7 @@ func NewServer(opts ...ServerOption) *Server {
 			return next(ctx, method, req)
 		}
 	})
+	s.AddReceivingMiddleware(toolErrors)
 
 	return s
 }
@@ -109,17 +143,111 @@ func joinInstructions(parts []string) string {
 
 // GetToolMetadata returns the metadata for a given tool
 func (s *Server) GetToolMetadata(toolName string) *ToolMetadata {
+	s.toolsMu.RLock()
+	defer s.toolsMu.RUnlock()
 	return s.toolMetadata[toolName]
 }
 
+// RunAsTool runs run through the tool middlewares as a call of the tool named
+// toolName, so that requests serving the data of a tool, such as resource
+// reads, are routed to an instance, checked for permissions and observed like
+// its calls. toolRequest stands for the request, e.g. with its name, _meta and
+// credentials.
+func (s *Server) RunAsTool(ctx context.Context, toolName string, toolRequest *mcp.CallToolRequest, run CallToolFunc) (*mcp.CallToolResult, error) {
+	metadata := s.GetToolMetadata(toolName)
+	if metadata == nil {
+		return nil, fmt.Errorf("unknown tool %q", toolName)
+	}
+	ctx = SetCallToolRequest(ctx, toolRequest)
+	ctx = SetToolMetadata(ctx, metadata)
+	return s.chainToolMiddlewares(run)(ctx, toolRequest)
+}
+
+// chainToolMiddlewares wraps next in the tool middlewares, the first added
+// outermost.
+func (s *Server) chainToolMiddlewares(next CallToolFunc) CallToolFunc {
+	for i := len(s.toolMiddlewares) - 1; i >= 0; i-- {
+		mw := s.toolMiddlewares[i]
+		inner := next
+		next = func(ctx context.Context, r *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mw.ToolHandle(ctx, r, inner)
+		}
+	}
+	return next
+}
+
+// ToolNames returns the names of the registered tools, sorted.
+func (s *Server) ToolNames() []string {
+	s.toolsMu.RLock()
+	defer s.toolsMu.RUnlock()
+	return slices.Sorted(maps.Keys(s.toolMetadata))
+}
+
+// HasTool reports whether the tool is registered. During SyncTools, it
+// reports whether register registered the tool so far.
+func (s *Server) HasTool(name string) bool {
+	s.toolsMu.RLock()
+	defer s.toolsMu.RUnlock()
+	if s.syncedTools != nil {
+		return s.syncedTools[name]
+	}
+	_, ok := s.toolMetadata[name]
+	return ok
+}
+
+// SyncTools replaces the registered tools with those registered by register,
+// and removes the tools it no longer registers. The SDK notifies connected
+// clients with notifications/tools/list_changed. When register fails, the
+// tools it did register are kept and nothing is removed.
+func (s *Server) SyncTools(register func() error) error {
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+
+	s.toolsMu.Lock()
+	s.syncedTools = make(map[string]bool)
+	s.toolsMu.Unlock()
+
+	err := register()
+
+	s.toolsMu.Lock()
+	var removed []string
+	if err == nil {
+		for name := range s.toolMetadata {
+			if !s.syncedTools[name] {
+				removed = append(removed, name)
+				delete(s.toolMetadata, name)
+			}
+		}
+	}
+	s.syncedTools = nil
+	s.toolsMu.Unlock()
+
+	if len(removed) > 0 {
+		slog.Info(fmt.Sprintf("Removing tools: %s", strings.Join(removed, ", ")))
+		s.RemoveTools(removed...)
+	}
+	return err
+}
+
 // ToolMetadata stores metadata about a registered tool
 type ToolMetadata struct {
 	Name        string
 	Permissions []string
+	Annotations *mcp.ToolAnnotations
+}
+
+// ReadOnly reports whether the tool is annotated as not modifying its
+// environment. Tools without annotations are assumed to write, matching the
+// MCP default for readOnlyHint.
+func (m *ToolMetadata) ReadOnly() bool {
+	return m.Annotations != nil && m.Annotations.ReadOnlyHint
 }
 
 // ServerToolConfig is used to configure which tools are enabled/disabled at the server level
 type ServerToolConfig struct {
+	// EnabledTools and DisabledTools hold tool names, names of the groups a
+	// tool belongs to, or glob patterns as accepted by path.Match (e.g.
+	// "get_lineage_*").
 	EnabledTools  []string
 	DisabledTools []string
 	// EnableDebugTools, when true, registers debug tools that are otherwise hidden.
@@ -132,16 +260,30 @@ type ServerToolConfig struct {
 	SkillsDir string
 }
 
-func (tc *ServerToolConfig) IsToolEnabled(toolName string) bool {
-	if slices.Contains(tc.DisabledTools, toolName) {
+// IsToolEnabled reports whether the tool, belonging to the given groups, is
+// selected by the enabled and disabled tools.
+func (tc *ServerToolConfig) IsToolEnabled(toolName string, groups ...string) bool {
+	if matchesTool(tc.DisabledTools, toolName, groups) {
 		return false
 	}
 	if len(tc.EnabledTools) > 0 {
-		return slices.Contains(tc.EnabledTools, toolName)
+		return matchesTool(tc.EnabledTools, toolName, groups)
 	}
 	return true
 }
 
+func matchesTool(patterns []string, toolName string, groups []string) bool {
+	for _, pattern := range patterns {
+		if pattern == toolName || slices.Contains(groups, pattern) {
+			return true
+		}
+		if matched, _ := path.Match(pattern, toolName); matched {
+			return true
+		}
+	}
+	return false
+}
+
 // IsExperimentalEnabled reports whether the given experimental feature
 // name was opted into via --experimental, COLLIBRA_MCP_EXPERIMENTAL, or
 // mcp.experimental in the YAML config.
@@ -157,6 +299,30 @@ func WithToolMiddleware(middleware ToolMiddleware) ServerOption {
 	}
 }
 
+// WithToolArgument adds an optional argument to the input schema of every
+// tool. Tool handlers never see it: it is meant for tool middlewares, which
+// read it from the raw arguments of the request (e.g. to select the Collibra
+// instance a call is routed to).
+func WithToolArgument(name string, schema *jsonschema.Schema) ServerOption {
+	return func(s *Server) {
+		s.toolArguments[name] = schema
+	}
+}
+
+// WithReadOnly makes RegisterTool skip every tool that is not annotated as
+// read-only, so the server cannot expose a tool that changes the catalog,
+// whatever the enabled and disabled tools are.
+func WithReadOnly() ServerOption {
+	return func(s *Server) {
+		s.readOnly = true
+	}
+}
+
+// ReadOnly reports whether the server only registers read-only tools.
+func (s *Server) ReadOnly() bool {
+	return s.readOnly
+}
+
 // WithInstructions appends a snippet to the server's initialize instructions.
 // Use this so optional features (e.g. experimental skills) can contribute
 // their own bootstrap text only when enabled.
@@ -193,13 +359,24 @@ type Tool[In, Out any] struct {
 }
 
 func RegisterTool[In, Out any](s *Server, tool *Tool[In, Out]) {
+	metadata := &ToolMetadata{
+		Name:        tool.Name,
+		Permissions: tool.Permissions,
+		Annotations: tool.Annotations,
+	}
+	if s.readOnly && !metadata.ReadOnly() {
+		slog.Info(fmt.Sprintf("Skipping tool in read-only mode: %s", tool.Name))
+		return
+	}
 	slog.Info(fmt.Sprintf("Registering tool: %s", tool.Name))
 
 	// Store tool metadata
-	s.toolMetadata[tool.Name] = &ToolMetadata{
-		Name:        tool.Name,
-		Permissions: tool.Permissions,
+	s.toolsMu.Lock()
+	s.toolMetadata[tool.Name] = metadata
+	if s.syncedTools != nil {
+		s.syncedTools[tool.Name] = true
 	}
+	s.toolsMu.Unlock()
 
 	handler := func(ctx context.Context, toolRequest *mcp.CallToolRequest, input In) (*mcp.CallToolResult, Out, error) {
 		var capturedOutput Out
@@ -214,28 +391,40 @@ func RegisterTool[In, Out any](s *Server, tool *Tool[In, Out]) {
 			// concrete-typed schema stripNullableTypes produces (see
 			// normalizeNilCollections).
 			normalizeNilCollections(reflect.ValueOf(&capturedOutput).Elem())
-			return nil, err
-		}
-
-		for i := len(s.toolMiddlewares) - 1; i >= 0; i-- {
-			mw := s.toolMiddlewares[i]
-			next := middlewareChain
-			middlewareChain = func(ctx context.Context, r *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-				return mw.ToolHandle(ctx, r, next)
+			if err != nil {
+				return nil, err
 			}
+			// Expose the typed output to the middlewares; the SDK replaces
+			// StructuredContent with its validated JSON encoding afterwards.
+			return &mcp.CallToolResult{StructuredContent: capturedOutput}, nil
 		}
 
 		ctx = SetCallToolRequest(ctx, toolRequest)
-		res, err := middlewareChain(ctx, toolRequest)
+		ctx = SetToolMetadata(ctx, metadata)
+		res, err := s.chainToolMiddlewares(middlewareChain)(ctx, toolRequest)
+		if _, protocolError := err.(*jsonrpc.Error); err != nil && !protocolError {
+			err = newToolError(err, metadata)
+		}
 
 		return res, capturedOutput, err
 	}
 
+	inputSchema := buildSchema[In]()
+	for name, schema := range s.toolArguments {
+		if _, exists := inputSchema.Properties[name]; exists {
+			log.Fatalf("tool %s already has an argument named %s", tool.Name, name)
+		}
+		if inputSchema.Properties == nil {
+			inputSchema.Properties = make(map[string]*jsonschema.Schema)
+		}
+		inputSchema.Properties[name] = schema
+	}
+
 	mcp.AddTool(&s.Server, &mcp.Tool{
 		Name:         tool.Name,
 		Title:        tool.Title,
 		Description:  tool.Description,
-		InputSchema:  buildSchema[In](),
+		InputSchema:  inputSchema,
 		OutputSchema: buildSchema[Out](),
 		Annotations:  tool.Annotations,
 	}, handler)
@@ -2,10 +2,16 @@ package chip
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -156,17 +162,24 @@ func TestServerToolConfig_IsToolEnabled(t *testing.T) {
 		name     string
 		cfg      ServerToolConfig
 		tool     string
+		groups   []string
 		expected bool
 	}{
-		{"empty config enables everything", ServerToolConfig{}, "foo", true},
-		{"explicitly disabled", ServerToolConfig{DisabledTools: []string{"foo"}}, "foo", false},
-		{"allow-list excludes others", ServerToolConfig{EnabledTools: []string{"bar"}}, "foo", false},
-		{"allow-list includes self", ServerToolConfig{EnabledTools: []string{"foo"}}, "foo", true},
-		{"disabled wins over enabled", ServerToolConfig{EnabledTools: []string{"foo"}, DisabledTools: []string{"foo"}}, "foo", false},
+		{"empty config enables everything", ServerToolConfig{}, "foo", nil, true},
+		{"explicitly disabled", ServerToolConfig{DisabledTools: []string{"foo"}}, "foo", nil, false},
+		{"allow-list excludes others", ServerToolConfig{EnabledTools: []string{"bar"}}, "foo", nil, false},
+		{"allow-list includes self", ServerToolConfig{EnabledTools: []string{"foo"}}, "foo", nil, true},
+		{"disabled wins over enabled", ServerToolConfig{EnabledTools: []string{"foo"}, DisabledTools: []string{"foo"}}, "foo", nil, false},
+		{"allow-list includes group", ServerToolConfig{EnabledTools: []string{"lineage"}}, "get_lineage_entity", []string{"lineage"}, true},
+		{"group disabled", ServerToolConfig{DisabledTools: []string{"writes"}}, "create_asset", []string{"writes"}, false},
+		{"allow-list glob", ServerToolConfig{EnabledTools: []string{"get_lineage_*"}}, "get_lineage_entity", nil, true},
+		{"allow-list glob excludes others", ServerToolConfig{EnabledTools: []string{"get_lineage_*"}}, "search_lineage_entities", nil, false},
+		{"disabled glob", ServerToolConfig{DisabledTools: []string{"dq_*"}}, "dq_delete_job", nil, false},
+		{"malformed glob matches nothing", ServerToolConfig{DisabledTools: []string{"get_["}}, "get_", nil, true},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			if got := tc.cfg.IsToolEnabled(tc.tool); got != tc.expected {
+			if got := tc.cfg.IsToolEnabled(tc.tool, tc.groups...); got != tc.expected {
 				t.Fatalf("IsToolEnabled(%q) = %v, want %v", tc.tool, got, tc.expected)
 			}
 		})
@@ -245,3 +258,207 @@ func TestServerToolConfig_IsExperimentalEnabled(t *testing.T) {
 		})
 	}
 }
+
+func TestTool_MiddlewareSeesMetadataAndOutput(t *testing.T) {
+	var metadata *ToolMetadata
+	var output any
+	chipServer := NewServer(WithToolMiddleware(ToolMiddlewareFunc(func(ctx context.Context, r *mcp.CallToolRequest, next CallToolFunc) (*mcp.CallToolResult, error) {
+		metadata, _ = GetToolMetadata(ctx)
+		res, err := next(ctx, r)
+		if res != nil {
+			output = res.StructuredContent
+		}
+		return res, err
+	})))
+	tool := newTool()
+	tool.Annotations = &mcp.ToolAnnotations{ReadOnlyHint: true}
+	RegisterTool[toolInput, toolOutput](chipServer, tool)
+	chipSession := newChipSession(t.Context(), chipServer)
+	defer closeSilently(chipSession)
+
+	res, err := chipSession.CallTool(t.Context(), &mcp.CallToolParams{
+		Name:      "the_tool",
+		Arguments: map[string]any{"input": "hello"},
+	})
+	if err != nil || res.IsError {
+		t.Fatalf("unexpected failure: %v %+v", err, res)
+	}
+	if metadata == nil || metadata.Name != "the_tool" || !metadata.ReadOnly() {
+		t.Errorf("expected the tool metadata in the middleware context, got %+v", metadata)
+	}
+	if got, ok := output.(toolOutput); !ok || got.Output != "hello" {
+		t.Errorf("expected the typed output in the middleware result, got %#v", output)
+	}
+	if text := res.Content[0].(*mcp.TextContent).Text; text != `{"output":"hello"}` {
+		t.Errorf("unexpected content sent to the client: %s", text)
+	}
+}
+
+func TestTool_WithToolArgumentExtendsEveryInputSchema(t *testing.T) {
+	var instance string
+	chipServer := NewServer(
+		WithToolArgument("instance", &jsonschema.Schema{Type: "string", Enum: []any{"dev", "prod"}}),
+		WithToolMiddleware(ToolMiddlewareFunc(func(ctx context.Context, r *mcp.CallToolRequest, next CallToolFunc) (*mcp.CallToolResult, error) {
+			var arguments struct {
+				Instance string `json:"instance"`
+			}
+			_ = json.Unmarshal(r.Params.Arguments, &arguments)
+			instance = arguments.Instance
+			return next(ctx, r)
+		})),
+	)
+	RegisterTool[toolInput, toolOutput](chipServer, newTool())
+	chipSession := newChipSession(t.Context(), chipServer)
+	defer closeSilently(chipSession)
+
+	tools, err := chipSession.ListTools(t.Context(), &mcp.ListToolsParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := json.Marshal(tools.Tools[0].InputSchema)
+	if !strings.Contains(string(raw), `"instance":{"enum":["dev","prod"],"type":"string"}`) {
+		t.Errorf("expected the instance argument in the input schema, got %s", raw)
+	}
+
+	res, err := chipSession.CallTool(t.Context(), &mcp.CallToolParams{
+		Name:      "the_tool",
+		Arguments: map[string]any{"input": "hello", "instance": "prod"},
+	})
+	if err != nil || res.IsError {
+		t.Fatalf("unexpected failure: %v %+v", err, res)
+	}
+	if instance != "prod" {
+		t.Errorf("expected the middleware to read the instance argument, got %q", instance)
+	}
+
+	res, _ = chipSession.CallTool(t.Context(), &mcp.CallToolParams{
+		Name:      "the_tool",
+		Arguments: map[string]any{"input": "hello", "instance": "qa"},
+	})
+	if res == nil || !res.IsError {
+		t.Error("expected an instance outside the enum to be rejected")
+	}
+}
+
+func TestServer_SyncToolsRemovesUnregisteredToolsAndNotifies(t *testing.T) {
+	chipServer := NewServer()
+	other := newTool()
+	other.Name = "other_tool"
+	RegisterTool[toolInput, toolOutput](chipServer, newTool())
+	RegisterTool[toolInput, toolOutput](chipServer, other)
+
+	changed := make(chan struct{}, 1)
+	t1, t2 := mcp.NewInMemoryTransports()
+	if _, err := chipServer.Connect(t.Context(), t1, nil); err != nil {
+		t.Fatal(err)
+	}
+	chipClient := mcp.NewClient(&mcp.Implementation{Name: "client", Version: "v0.0.1"}, &mcp.ClientOptions{
+		ToolListChangedHandler: func(context.Context, *mcp.ToolListChangedRequest) {
+			select {
+			case changed <- struct{}{}:
+			default:
+			}
+		},
+	})
+	chipSession, err := chipClient.Connect(t.Context(), t2, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closeSilently(chipSession)
+
+	err = chipServer.SyncTools(func() error {
+		RegisterTool[toolInput, toolOutput](chipServer, newTool())
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := chipServer.ToolNames(); !slices.Equal(names, []string{"the_tool"}) {
+		t.Errorf("expected only the re-registered tool to remain, got %v", names)
+	}
+	select {
+	case <-changed:
+	case <-time.After(time.Second):
+		t.Fatal("expected a tools/list_changed notification")
+	}
+	tools, err := chipSession.ListTools(t.Context(), &mcp.ListToolsParams{})
+	if err != nil || len(tools.Tools) != 1 || tools.Tools[0].Name != "the_tool" {
+		t.Errorf("expected the client to list only the_tool, got %+v (%v)", tools, err)
+	}
+
+	err = chipServer.SyncTools(func() error { return errors.New("boom") })
+	if err == nil || len(chipServer.ToolNames()) != 1 {
+		t.Errorf("expected a failed sync to keep the tools, got %v (%v)", chipServer.ToolNames(), err)
+	}
+}
+
+func TestServer_WithReadOnlySkipsWriteTools(t *testing.T) {
+	chipServer := NewServer(WithReadOnly())
+	reader := newTool()
+	reader.Name = "reader"
+	reader.Annotations = &mcp.ToolAnnotations{ReadOnlyHint: true}
+	writer := newTool()
+	writer.Name = "writer"
+	writer.Annotations = &mcp.ToolAnnotations{ReadOnlyHint: false}
+	RegisterTool[toolInput, toolOutput](chipServer, reader)
+	RegisterTool[toolInput, toolOutput](chipServer, writer)
+	RegisterTool[toolInput, toolOutput](chipServer, newTool())
+
+	if names := chipServer.ToolNames(); !slices.Equal(names, []string{"reader"}) {
+		t.Errorf("expected only the read-only tool to be registered, got %v", names)
+	}
+	chipSession := newChipSession(t.Context(), chipServer)
+	defer closeSilently(chipSession)
+	tools, err := chipSession.ListTools(t.Context(), &mcp.ListToolsParams{})
+	if err != nil || len(tools.Tools) != 1 {
+		t.Errorf("expected the client to list only the read-only tool, got %+v (%v)", tools, err)
+	}
+}
+
+type deniedError struct{}
+
+func (deniedError) Error() string { return "HTTP 403: forbidden" }
+
+func (deniedError) ErrorCode() ErrorCode { return ErrorPermissionDenied }
+
+func TestRegisterTool_MapsErrorsToCodedResults(t *testing.T) {
+	chipServer := NewServer()
+	RegisterTool(chipServer, &Tool[toolInput, toolOutput]{
+		Name:        "the_tool",
+		Permissions: []string{"dgc.classify"},
+		Handler: func(_ context.Context, input toolInput) (toolOutput, error) {
+			if input.Input == "denied" {
+				return toolOutput{}, fmt.Errorf("classifying: %w", deniedError{})
+			}
+			return toolOutput{}, errors.New("boom")
+		},
+	})
+	chipSession := newChipSession(t.Context(), chipServer)
+	defer closeSilently(chipSession)
+
+	tests := []struct {
+		arguments map[string]any
+		code      ErrorCode
+		text      string
+	}{
+		{map[string]any{"input": "denied"}, ErrorPermissionDenied, "[permission_denied] classifying: HTTP 403: forbidden"},
+		{map[string]any{"input": "other"}, ErrorInternal, "[internal] boom"},
+		{map[string]any{}, ErrorValidation, "[validation] "},
+	}
+	for _, tt := range tests {
+		res, err := chipSession.CallTool(t.Context(), &mcp.CallToolParams{Name: "the_tool", Arguments: tt.arguments})
+		if err != nil || !res.IsError {
+			t.Fatalf("expected a tool error, got %v %+v", err, res)
+		}
+		details, _ := res.Meta[ErrorMetaKey].(map[string]any)
+		if details["code"] != string(tt.code) {
+			t.Errorf("expected code %s, got %v", tt.code, res.Meta)
+		}
+		if text := res.Content[0].(*mcp.TextContent).Text; !strings.HasPrefix(text, tt.text) {
+			t.Errorf("expected %q, got %q", tt.text, text)
+		}
+		if tt.code == ErrorPermissionDenied && fmt.Sprint(details["permissions"]) != "[dgc.classify]" {
+			t.Errorf("expected the tool's permissions, got %v", details["permissions"])
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package chip
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Session holds what chip keeps about one MCP session between requests: the
+// client's initialize parameters and values set by tools and middlewares,
+// such as the caller's resolved identity, caches or pagination cursors.
+type Session struct {
+	ID string
+
+	mu         sync.Mutex
+	initParams *mcp.InitializeParams
+	values     map[string]any
+	lastUsed   time.Time
+}
+
+func newSession(id string, params *mcp.InitializeParams) *Session {
+	return &Session{ID: id, initParams: params, values: make(map[string]any), lastUsed: time.Now()}
+}
+
+// InitializeParams returns the parameters the client initialized the session
+// with.
+func (s *Session) InitializeParams() *mcp.InitializeParams {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.initParams
+}
+
+func (s *Session) setInitializeParams(params *mcp.InitializeParams) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.initParams = params
+}
+
+// Value returns the value stored under key in the session.
+func (s *Session) Value(key string) (any, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	value, ok := s.values[key]
+	return value, ok
+}
+
+// SetValue stores value under key for the rest of the session.
+func (s *Session) SetValue(key string, value any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[key] = value
+}
+
+// LastUsed returns when the session last received a request.
+func (s *Session) LastUsed() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastUsed
+}
+
+func (s *Session) touch() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastUsed = time.Now()
+}
+
+// SessionStore keeps the state of the open sessions. Sessions are deleted
+// when they close; a store may also drop sessions that stayed idle, which
+// start over with a fresh state on their next request.
+type SessionStore interface {
+	Load(id string) (*Session, bool)
+	Store(session *Session)
+	Delete(id string)
+}
+
+// MemorySessionStore keeps sessions in memory and drops those idle for
+// longer than its idle timeout.
+type MemorySessionStore struct {
+	idleTimeout time.Duration
+	now         func() time.Time
+
+	mu       sync.Mutex
+	sessions map[string]*Session
+}
+
+// NewMemorySessionStore returns an in-memory store. A zero idleTimeout keeps
+// sessions until they close.
+func NewMemorySessionStore(idleTimeout time.Duration) *MemorySessionStore {
+	return &MemorySessionStore{idleTimeout: idleTimeout, now: time.Now, sessions: make(map[string]*Session)}
+}
+
+func (m *MemorySessionStore) Load(id string) (*Session, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	session, ok := m.sessions[id]
+	if ok && m.idle(session) {
+		delete(m.sessions, id)
+		return nil, false
+	}
+	return session, ok
+}
+
+func (m *MemorySessionStore) Store(session *Session) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id, stored := range m.sessions {
+		if m.idle(stored) {
+			delete(m.sessions, id)
+		}
+	}
+	m.sessions[session.ID] = session
+}
+
+func (m *MemorySessionStore) Delete(id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.sessions, id)
+}
+
+// Len returns the number of sessions kept.
+func (m *MemorySessionStore) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.sessions)
+}
+
+func (m *MemorySessionStore) idle(session *Session) bool {
+	return m.idleTimeout > 0 && m.now().Sub(session.LastUsed()) > m.idleTimeout
+}
+
+// sessionTracker maps the SDK's sessions to their chip state. Streamable
+// HTTP sessions keep their Mcp-Session-Id; stdio and SSE sessions, which
+// have none, get a generated one.
+type sessionTracker struct {
+	store SessionStore
+	ids   sync.Map // *mcp.ServerSession -> string
+}
+
+// session returns the state of ss, creating it on the first request of the
+// session or after the store dropped it. Requests made before initialize,
+// such as server/discover, get no state.
+func (t *sessionTracker) session(ss *mcp.ServerSession, params *mcp.InitializeParams) *Session {
+	if params == nil && ss.InitializeParams() == nil {
+		return nil
+	}
+	id, known := t.ids.Load(ss)
+	if !known {
+		newID := ss.ID()
+		if newID == "" {
+			newID = uuid.New().String()
+		}
+		id, known = t.ids.LoadOrStore(ss, newID)
+		if !known {
+			go func() {
+				_ = ss.Wait()
+				t.ids.Delete(ss)
+				t.store.Delete(id.(string))
+			}()
+		}
+	}
+	session, ok := t.store.Load(id.(string))
+	if !ok {
+		if params == nil {
+			params = ss.InitializeParams()
+		}
+		session = newSession(id.(string), params)
+		t.store.Store(session)
+	} else if params != nil {
+		session.setInitializeParams(params)
+	}
+	session.touch()
+	return session
+}
+
+// WithSessionStore keeps per-session state in store, available to tools and
+// middlewares through GetSession. Use it when sessions live across requests
+// (stdio, SSE and stateful streamable HTTP); the initialize parameters are
+// then taken from each session instead of the last client that initialized.
+func WithSessionStore(store SessionStore) ServerOption {
+	return func(s *Server) {
+		s.sessions = &sessionTracker{store: store}
+	}
+}
+
+// SetSession records the state of the session the request belongs to.
+func SetSession(ctx context.Context, session *Session) context.Context {
+	return context.WithValue(ctx, sessionKey, session)
+}
+
+func GetSession(ctx context.Context) (*Session, bool) {
+	session, ok := ctx.Value(sessionKey).(*Session)
+	return session, ok
+}
@@ -0,0 +1,121 @@
+package chip
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// countingTool counts its calls in the session state and reports the client
+// that initialized the session.
+func countingTool() *Tool[toolInput, toolOutput] {
+	return &Tool[toolInput, toolOutput]{
+		Name: "count",
+		Handler: func(ctx context.Context, _ toolInput) (toolOutput, error) {
+			session, ok := GetSession(ctx)
+			if !ok {
+				return toolOutput{Output: "no session"}, nil
+			}
+			calls, _ := session.Value("calls")
+			count, _ := calls.(int)
+			session.SetValue("calls", count+1)
+			params, _ := GetInitParams(ctx)
+			return toolOutput{Output: params.ClientInfo.Name + string(rune('0'+count+1))}, nil
+		},
+	}
+}
+
+func connectStreamable(t *testing.T, url, clientName string) *mcp.ClientSession {
+	t.Helper()
+	client := mcp.NewClient(&mcp.Implementation{Name: clientName, Version: "v0.0.1"}, nil)
+	session, err := client.Connect(t.Context(), &mcp.StreamableClientTransport{Endpoint: url}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return session
+}
+
+func callCount(t *testing.T, session *mcp.ClientSession) string {
+	t.Helper()
+	res, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: "count", Arguments: map[string]any{"input": ""}})
+	if err != nil || res.IsError {
+		t.Fatalf("unexpected failure: %v %+v", err, res)
+	}
+	return res.StructuredContent.(map[string]any)["output"].(string)
+}
+
+func TestServer_WithSessionStoreKeepsStatePerSession(t *testing.T) {
+	store := NewMemorySessionStore(0)
+	chipServer := NewServer(WithSessionStore(store))
+	RegisterTool(chipServer, countingTool())
+	httpServer := httptest.NewServer(mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server {
+		return &chipServer.Server
+	}, nil))
+	defer httpServer.Close()
+
+	alice := connectStreamable(t, httpServer.URL, "alice")
+	bob := connectStreamable(t, httpServer.URL, "bob")
+	defer closeSilently(bob)
+
+	for _, want := range []struct {
+		session *mcp.ClientSession
+		output  string
+	}{{alice, "alice1"}, {bob, "bob1"}, {alice, "alice2"}} {
+		if got := callCount(t, want.session); got != want.output {
+			t.Errorf("expected %s, got %s", want.output, got)
+		}
+	}
+	if _, ok := store.Load(alice.ID()); !ok {
+		t.Errorf("expected the session to be stored under its Mcp-Session-Id %s", alice.ID())
+	}
+
+	closeSilently(alice)
+	deadline := time.Now().Add(time.Second)
+	for store.Len() != 1 {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the closed session to be deleted, %d sessions kept", store.Len())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestSession_InitializeParamsAreSafeForConcurrentUse(t *testing.T) {
+	session := newSession("id", nil)
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			session.setInitializeParams(&mcp.InitializeParams{ProtocolVersion: string(rune('0' + i))})
+		}()
+		go func() {
+			defer wg.Done()
+			_ = session.InitializeParams()
+		}()
+	}
+	wg.Wait()
+	if session.InitializeParams() == nil {
+		t.Error("expected the initialize parameters to be set")
+	}
+}
+
+func TestMemorySessionStore_DropsIdleSessions(t *testing.T) {
+	store := NewMemorySessionStore(time.Minute)
+	now := time.Now()
+	store.now = func() time.Time { return now }
+	session := newSession("s1", nil)
+	store.Store(session)
+
+	if _, ok := store.Load("s1"); !ok {
+		t.Fatal("expected a fresh session to be kept")
+	}
+	now = now.Add(2 * time.Minute)
+	if _, ok := store.Load("s1"); ok {
+		t.Error("expected an idle session to be dropped")
+	}
+}
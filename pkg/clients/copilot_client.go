@@ -46,8 +46,8 @@ func callTool(ctx context.Context, collibraHttpClient *http.Client, endpoint str
 		return "", fmt.Errorf("failed to read response: %w", err)
 	}
 
-	if response.StatusCode < 200 || response.StatusCode >= 300 { // TODO: ToolHandle 400 and 500 errors.
-		return "", fmt.Errorf("HTTP %d: %s", response.StatusCode, string(body))
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return "", httpError(response, body)
 	}
 
 	toolResponse, err := unmarshalToolResponse(body)
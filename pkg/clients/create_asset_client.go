@@ -133,13 +133,13 @@ func CreateAsset(ctx context.Context, client *http.Client, request CreateAssetRe
 	if resp.StatusCode != http.StatusCreated {
 		switch resp.StatusCode {
 		case http.StatusBadRequest:
-			return nil, fmt.Errorf("creating asset: bad request (invalid parameters or duplicate name): %s", string(respBody))
+			return nil, newAPIError(resp, respBody, fmt.Sprintf("creating asset: bad request (invalid parameters or duplicate name): %s", string(respBody)))
 		case http.StatusForbidden:
-			return nil, fmt.Errorf("creating asset: asset type not allowed in domain: %s", string(respBody))
+			return nil, newAPIError(resp, respBody, fmt.Sprintf("creating asset: asset type not allowed in domain: %s", string(respBody)))
 		case http.StatusNotFound:
-			return nil, fmt.Errorf("creating asset: invalid assetTypeId or domainId: %s", string(respBody))
+			return nil, newAPIError(resp, respBody, fmt.Sprintf("creating asset: invalid assetTypeId or domainId: %s", string(respBody)))
 		default:
-			return nil, fmt.Errorf("creating asset: unexpected status %d: %s", resp.StatusCode, string(respBody))
+			return nil, newAPIError(resp, respBody, fmt.Sprintf("creating asset: unexpected status %d: %s", resp.StatusCode, string(respBody)))
 		}
 	}
 
@@ -179,11 +179,11 @@ func CreateAttribute(ctx context.Context, client *http.Client, request CreateAtt
 	if resp.StatusCode != http.StatusCreated {
 		switch resp.StatusCode {
 		case http.StatusBadRequest:
-			return nil, fmt.Errorf("creating attribute: bad request (invalid parameters): %s", string(respBody))
+			return nil, newAPIError(resp, respBody, fmt.Sprintf("creating attribute: bad request (invalid parameters): %s", string(respBody)))
 		case http.StatusNotFound:
-			return nil, fmt.Errorf("creating attribute: asset or attribute type not found: %s", string(respBody))
+			return nil, newAPIError(resp, respBody, fmt.Sprintf("creating attribute: asset or attribute type not found: %s", string(respBody)))
 		default:
-			return nil, fmt.Errorf("creating attribute: unexpected status %d: %s", resp.StatusCode, string(respBody))
+			return nil, newAPIError(resp, respBody, fmt.Sprintf("creating attribute: unexpected status %d: %s", resp.StatusCode, string(respBody)))
 		}
 	}
 
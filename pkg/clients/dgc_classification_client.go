@@ -89,7 +89,7 @@ func fetchJSON(method string, endpoint string, ctx context.Context, httpClient *
 		return nil, resp.StatusCode, fmt.Errorf("failed to read response body: %w", err)
 	}
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, resp.StatusCode, fmt.Errorf("http %d: %s", resp.StatusCode, string(body))
+		return nil, resp.StatusCode, httpError(resp, body)
 	}
 	return body, resp.StatusCode, nil
 }
@@ -84,15 +84,15 @@ func AddDataClassificationMatch(ctx context.Context, httpClient *http.Client, re
 	}
 
 	if resp.StatusCode == 404 {
-		return nil, fmt.Errorf("classification or asset not found (HTTP 404): %s", string(body))
+		return nil, newAPIError(resp, body, fmt.Sprintf("classification or asset not found (HTTP 404): %s", string(body)))
 	}
 
 	if resp.StatusCode == 422 {
-		return nil, fmt.Errorf("classification match already exists between this asset and classification (HTTP 422): %s", string(body))
+		return nil, newAPIError(resp, body, fmt.Sprintf("classification match already exists between this asset and classification (HTTP 422): %s", string(body)))
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("http %d: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp, body, fmt.Sprintf("http %d: %s", resp.StatusCode, string(body)))
 	}
 
 	var match DataClassificationMatch
@@ -135,7 +135,7 @@ func SearchDataClassificationMatches(ctx context.Context, httpClient *http.Clien
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, 0, fmt.Errorf("http %d: %s", resp.StatusCode, string(body))
+		return nil, 0, newAPIError(resp, body, fmt.Sprintf("http %d: %s", resp.StatusCode, string(body)))
 	}
 	var pagedResponse PagedResponseDataClassificationMatch
 
@@ -165,15 +165,15 @@ func RemoveDataClassificationMatch(ctx context.Context, httpClient *http.Client,
 	}(resp.Body)
 
 	if resp.StatusCode == 404 {
-		return fmt.Errorf("classification match not found")
+		return newAPIError(resp, nil, "classification match not found")
 	}
 
 	if resp.StatusCode != 204 {
 		body, _ := io.ReadAll(resp.Body)
 		if len(body) > 0 {
-			return fmt.Errorf("%s (HTTP %d)", string(body), resp.StatusCode)
+			return newAPIError(resp, body, fmt.Sprintf("%s (HTTP %d)", string(body), resp.StatusCode))
 		}
-		return fmt.Errorf("unexpected response (HTTP %d)", resp.StatusCode)
+		return newAPIError(resp, body, fmt.Sprintf("unexpected response (HTTP %d)", resp.StatusCode))
 	}
 
 	return nil
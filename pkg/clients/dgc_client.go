@@ -25,7 +25,7 @@ func SearchKeyword(ctx context.Context, collibraHttpClient *http.Client, questio
 		return nil, fmt.Errorf("failed to marshal search request: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", searchUrl, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(withIdempotent(ctx), "POST", searchUrl, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -57,7 +57,7 @@ func GetAssetSummary(
 		return nil, fmt.Errorf("failed to marshal GraphQL request: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", gqlUrl, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(withIdempotent(ctx), "POST", gqlUrl, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -82,12 +82,18 @@ func GetAssetWithRelations(ctx context.Context, collibraHttpClient *http.Client,
 		return nil, err
 	}
 	if len(assets) == 0 {
-		return nil, fmt.Errorf("asset %s not found", assetID)
+		return nil, statusError(http.StatusNotFound, nil, fmt.Sprintf("asset %s not found", assetID))
 	}
 	return &assets[0], nil
 }
 
 func ListAssetTypes(ctx context.Context, collibraHttpClient *http.Client, limit int, offset int) (*AssetTypePagedResponse, error) {
+	return cachedLookup(ctx, fmt.Sprintf("assetTypes?limit=%d&offset=%d", limit, offset), func() (*AssetTypePagedResponse, error) {
+		return fetchAssetTypes(ctx, collibraHttpClient, limit, offset)
+	})
+}
+
+func fetchAssetTypes(ctx context.Context, collibraHttpClient *http.Client, limit int, offset int) (*AssetTypePagedResponse, error) {
 	slog.InfoContext(ctx, fmt.Sprintf("Listing asset types with limit: %d, offset: %d", limit, offset))
 
 	params := AssetTypesQueryParams{
@@ -227,9 +233,9 @@ func executeRequest(client *http.Client, req *http.Request) ([]byte, error) {
 }
 
 func executeRequestWithStatus(client *http.Client, req *http.Request) ([]byte, int, error) {
-	response, err := client.Do(req)
+	response, err := doWithRetry(client, req)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to make request: %w", err)
+		return nil, 0, requestError(err)
 	}
 	defer func(Body io.ReadCloser) {
 		_ = Body.Close()
@@ -241,7 +247,7 @@ func executeRequestWithStatus(client *http.Client, req *http.Request) ([]byte, i
 	}
 
 	if response.StatusCode < 200 || response.StatusCode >= 300 {
-		return responseBody, response.StatusCode, fmt.Errorf("HTTP %d: %s", response.StatusCode, string(responseBody))
+		return responseBody, response.StatusCode, httpError(response, responseBody)
 	}
 
 	return responseBody, response.StatusCode, nil
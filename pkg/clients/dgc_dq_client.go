@@ -1251,7 +1251,7 @@ func GetDqConnectionPermissions(ctx context.Context, collibraHttpClient *http.Cl
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to marshal permissions query: %w", err)
 	}
-	req, err := http.NewRequestWithContext(ctx, "POST", "/graphql", bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(withIdempotent(ctx), "POST", "/graphql", bytes.NewBuffer(jsonData))
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -190,15 +190,15 @@ func SearchCatalogColumns(ctx context.Context, client *http.Client, params Catal
 			"offset": params.Offset,
 		},
 	}
-	respBody, status, err := dqDo(ctx, client, http.MethodPost, kgEndpoint, body)
+	respBody, status, err := dqDo(withIdempotent(ctx), client, http.MethodPost, kgEndpoint, body)
 	if err != nil {
 		return nil, fmt.Errorf("searching catalog columns: %w", err)
 	}
 	if status != http.StatusOK {
 		if status == http.StatusNotFound {
-			return nil, fmt.Errorf("searching catalog columns: knowledge graph endpoint not available on this instance (HTTP 404): %s", string(respBody))
+			return nil, statusError(status, respBody, fmt.Sprintf("searching catalog columns: knowledge graph endpoint not available on this instance (HTTP 404): %s", string(respBody)))
 		}
-		return nil, fmt.Errorf("searching catalog columns: unexpected status %d: %s", status, string(respBody))
+		return nil, statusError(status, respBody, fmt.Sprintf("searching catalog columns: unexpected status %d: %s", status, string(respBody)))
 	}
 	var resp kgSearchResponse
 	if err := json.Unmarshal(respBody, &resp); err != nil {
@@ -35,11 +35,11 @@ func GenerateDQRuleSQL(ctx context.Context, client *http.Client, request Text2SQ
 	if status != http.StatusOK {
 		switch status {
 		case http.StatusBadRequest:
-			return nil, fmt.Errorf("generating dq rule sql: bad request (the description could not be turned into valid SQL): %s", string(respBody))
+			return nil, statusError(status, respBody, fmt.Sprintf("generating dq rule sql: bad request (the description could not be turned into valid SQL): %s", string(respBody)))
 		case http.StatusForbidden:
-			return nil, fmt.Errorf("generating dq rule sql: missing permission to use DQ AI: %s", string(respBody))
+			return nil, statusError(status, respBody, fmt.Sprintf("generating dq rule sql: missing permission to use DQ AI: %s", string(respBody)))
 		default:
-			return nil, fmt.Errorf("generating dq rule sql: unexpected status %d: %s", status, string(respBody))
+			return nil, statusError(status, respBody, fmt.Sprintf("generating dq rule sql: unexpected status %d: %s", status, string(respBody)))
 		}
 	}
 	var result Text2SQLResponse
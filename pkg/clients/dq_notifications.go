@@ -189,7 +189,7 @@ func GetCurrentUser(ctx context.Context, client *http.Client) (*EditAssetUser, e
 		return nil, fmt.Errorf("get current user: reading response: %w", err)
 	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("get current user: status %d: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp, body, fmt.Sprintf("get current user: status %d: %s", resp.StatusCode, string(body)))
 	}
 	var user EditAssetUser
 	if err := json.Unmarshal(body, &user); err != nil {
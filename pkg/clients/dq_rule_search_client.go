@@ -64,15 +64,15 @@ type DQMonitorSearchResult struct {
 // column, for duplicate detection. Filters are ANDed together.
 func FindDQRules(ctx context.Context, client *http.Client, filters []DQMonitorFilter, offset, limit int) (*DQMonitorSearchResult, error) {
 	req := dqDashboardRequest{Filters: filters, Offset: offset, Limit: limit}
-	respBody, status, err := dqDo(ctx, client, http.MethodPost, "/rest/dq/internal/v1/monitoring/monitors/dashboard", req)
+	respBody, status, err := dqDo(withIdempotent(ctx), client, http.MethodPost, "/rest/dq/internal/v1/monitoring/monitors/dashboard", req)
 	if err != nil {
 		return nil, fmt.Errorf("finding dq rules: %w", err)
 	}
 	if status != http.StatusOK {
 		if status == http.StatusBadRequest {
-			return nil, fmt.Errorf("finding dq rules: bad request (invalid filter): %s", string(respBody))
+			return nil, statusError(status, respBody, fmt.Sprintf("finding dq rules: bad request (invalid filter): %s", string(respBody)))
 		}
-		return nil, fmt.Errorf("finding dq rules: unexpected status %d: %s", status, string(respBody))
+		return nil, statusError(status, respBody, fmt.Sprintf("finding dq rules: unexpected status %d: %s", status, string(respBody)))
 	}
 	var resp dqDashboardResponse
 	if err := json.Unmarshal(respBody, &resp); err != nil {
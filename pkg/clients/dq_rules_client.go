@@ -44,15 +44,15 @@ func CreateDQRule(ctx context.Context, client *http.Client, request CreateDQRule
 	if status != http.StatusOK {
 		switch status {
 		case http.StatusBadRequest:
-			return nil, fmt.Errorf("creating dq rule: bad request (invalid rule definition): %s", string(respBody))
+			return nil, statusError(status, respBody, fmt.Sprintf("creating dq rule: bad request (invalid rule definition): %s", string(respBody)))
 		case http.StatusForbidden:
-			return nil, fmt.Errorf("creating dq rule: missing permission to create rules on this job: %s", string(respBody))
+			return nil, statusError(status, respBody, fmt.Sprintf("creating dq rule: missing permission to create rules on this job: %s", string(respBody)))
 		case http.StatusNotFound:
-			return nil, fmt.Errorf("creating dq rule: job or template not found: %s", string(respBody))
+			return nil, statusError(status, respBody, fmt.Sprintf("creating dq rule: job or template not found: %s", string(respBody)))
 		case http.StatusUnprocessableEntity:
-			return nil, fmt.Errorf("creating dq rule: rule creation not allowed for this job (e.g. dataset is not of type PUSHDOWN): %s", string(respBody))
+			return nil, statusError(status, respBody, fmt.Sprintf("creating dq rule: rule creation not allowed for this job (e.g. dataset is not of type PUSHDOWN): %s", string(respBody)))
 		default:
-			return nil, fmt.Errorf("creating dq rule: unexpected status %d: %s", status, string(respBody))
+			return nil, statusError(status, respBody, fmt.Sprintf("creating dq rule: unexpected status %d: %s", status, string(respBody)))
 		}
 	}
 
@@ -86,9 +86,9 @@ func dqDo(ctx context.Context, client *http.Client, method, path string, body an
 	}
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := client.Do(req)
+	resp, err := doWithRetry(client, req)
 	if err != nil {
-		return nil, 0, fmt.Errorf("sending request: %w", err)
+		return nil, 0, requestError(err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
@@ -126,9 +126,9 @@ func GetDQRule(ctx context.Context, client *http.Client, jobName, monitorName st
 	}
 	if status != http.StatusOK {
 		if status == http.StatusNotFound {
-			return nil, fmt.Errorf("getting dq rule: rule %q not found on job %q: %s", monitorName, jobName, string(respBody))
+			return nil, statusError(status, respBody, fmt.Sprintf("getting dq rule: rule %q not found on job %q: %s", monitorName, jobName, string(respBody)))
 		}
-		return nil, fmt.Errorf("getting dq rule: unexpected status %d: %s", status, string(respBody))
+		return nil, statusError(status, respBody, fmt.Sprintf("getting dq rule: unexpected status %d: %s", status, string(respBody)))
 	}
 	var result DQRule
 	if err := json.Unmarshal(respBody, &result); err != nil {
@@ -189,9 +189,9 @@ func GetDQRuleResults(ctx context.Context, client *http.Client, jobName, ruleNam
 	}
 	if status != http.StatusOK {
 		if status == http.StatusNotFound {
-			return nil, fmt.Errorf("getting dq rule results: rule %q not found on job %q: %s", ruleName, jobName, string(respBody))
+			return nil, statusError(status, respBody, fmt.Sprintf("getting dq rule results: rule %q not found on job %q: %s", ruleName, jobName, string(respBody)))
 		}
-		return nil, fmt.Errorf("getting dq rule results: unexpected status %d: %s", status, string(respBody))
+		return nil, statusError(status, respBody, fmt.Sprintf("getting dq rule results: unexpected status %d: %s", status, string(respBody)))
 	}
 	var result DQRuleResults
 	if err := json.Unmarshal(respBody, &result); err != nil {
@@ -224,18 +224,18 @@ type ValidateDQRuleResponse struct {
 // ValidateDQRule checks that a rule's SQL/definition is valid before it is saved
 // or run — POST /rest/dq/internal/v1/rules/validate.
 func ValidateDQRule(ctx context.Context, client *http.Client, request PreviewRuleRequest) (*ValidateDQRuleResponse, error) {
-	respBody, status, err := dqDo(ctx, client, http.MethodPost, "/rest/dq/internal/v1/rules/validate", request)
+	respBody, status, err := dqDo(withIdempotent(ctx), client, http.MethodPost, "/rest/dq/internal/v1/rules/validate", request)
 	if err != nil {
 		return nil, fmt.Errorf("validating dq rule: %w", err)
 	}
 	if status != http.StatusOK {
 		switch status {
 		case http.StatusForbidden:
-			return nil, fmt.Errorf("validating dq rule: missing permission: %s", string(respBody))
+			return nil, statusError(status, respBody, fmt.Sprintf("validating dq rule: missing permission: %s", string(respBody)))
 		case http.StatusNotFound:
-			return nil, fmt.Errorf("validating dq rule: connection or job not found: %s", string(respBody))
+			return nil, statusError(status, respBody, fmt.Sprintf("validating dq rule: connection or job not found: %s", string(respBody)))
 		default:
-			return nil, fmt.Errorf("validating dq rule: unexpected status %d: %s", status, string(respBody))
+			return nil, statusError(status, respBody, fmt.Sprintf("validating dq rule: unexpected status %d: %s", status, string(respBody)))
 		}
 	}
 	var result ValidateDQRuleResponse
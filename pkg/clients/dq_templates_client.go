@@ -94,9 +94,9 @@ func ListDQRuleTemplates(ctx context.Context, client *http.Client, params ListDQ
 	}
 	if status != http.StatusOK {
 		if status == http.StatusForbidden {
-			return nil, fmt.Errorf("listing dq rule templates: missing permission to view templates: %s", string(respBody))
+			return nil, statusError(status, respBody, fmt.Sprintf("listing dq rule templates: missing permission to view templates: %s", string(respBody)))
 		}
-		return nil, fmt.Errorf("listing dq rule templates: unexpected status %d: %s", status, string(respBody))
+		return nil, statusError(status, respBody, fmt.Sprintf("listing dq rule templates: unexpected status %d: %s", status, string(respBody)))
 	}
 	var resp dqRuleTemplateListResponse
 	if err := json.Unmarshal(respBody, &resp); err != nil {
@@ -116,11 +116,11 @@ func GetDQRuleTemplate(ctx context.Context, client *http.Client, ruleTemplateNam
 	if status != http.StatusOK {
 		switch status {
 		case http.StatusForbidden:
-			return nil, fmt.Errorf("getting dq rule template: missing permission to view templates: %s", string(respBody))
+			return nil, statusError(status, respBody, fmt.Sprintf("getting dq rule template: missing permission to view templates: %s", string(respBody)))
 		case http.StatusNotFound:
-			return nil, fmt.Errorf("getting dq rule template: template %q not found: %s", ruleTemplateName, string(respBody))
+			return nil, statusError(status, respBody, fmt.Sprintf("getting dq rule template: template %q not found: %s", ruleTemplateName, string(respBody)))
 		default:
-			return nil, fmt.Errorf("getting dq rule template: unexpected status %d: %s", status, string(respBody))
+			return nil, statusError(status, respBody, fmt.Sprintf("getting dq rule template: unexpected status %d: %s", status, string(respBody)))
 		}
 	}
 	var tmpl DQRuleTemplate
@@ -175,13 +175,13 @@ func DeployDQRuleTemplate(ctx context.Context, client *http.Client, ruleTemplate
 	if status != http.StatusOK {
 		switch status {
 		case http.StatusBadRequest:
-			return nil, fmt.Errorf("deploying dq rule template: bad request (e.g. invalid targets or incompatible template): %s", string(respBody))
+			return nil, statusError(status, respBody, fmt.Sprintf("deploying dq rule template: bad request (e.g. invalid targets or incompatible template): %s", string(respBody)))
 		case http.StatusForbidden:
-			return nil, fmt.Errorf("deploying dq rule template: missing permission to deploy templates: %s", string(respBody))
+			return nil, statusError(status, respBody, fmt.Sprintf("deploying dq rule template: missing permission to deploy templates: %s", string(respBody)))
 		case http.StatusNotFound:
-			return nil, fmt.Errorf("deploying dq rule template: template %q not found: %s", ruleTemplateName, string(respBody))
+			return nil, statusError(status, respBody, fmt.Sprintf("deploying dq rule template: template %q not found: %s", ruleTemplateName, string(respBody)))
 		default:
-			return nil, fmt.Errorf("deploying dq rule template: unexpected status %d: %s", status, string(respBody))
+			return nil, statusError(status, respBody, fmt.Sprintf("deploying dq rule template: unexpected status %d: %s", status, string(respBody)))
 		}
 	}
 	var result DQTemplateDeployResult
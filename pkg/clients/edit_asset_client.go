@@ -188,11 +188,11 @@ func GetAssetCore(ctx context.Context, client *http.Client, assetID string) (*Ed
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("asset %q not found", assetID)
+		return nil, newAPIError(resp, nil, fmt.Sprintf("asset %q not found", assetID))
 	}
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("get asset: status %d: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp, body, fmt.Sprintf("get asset: status %d: %s", resp.StatusCode, string(body)))
 	}
 
 	var result EditAssetCore
@@ -231,7 +231,7 @@ func ListAttributesForAsset(ctx context.Context, client *http.Client, assetID st
 			return nil, fmt.Errorf("list attributes: reading response: %w", readErr)
 		}
 		if resp.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("list attributes: status %d: %s", resp.StatusCode, string(body))
+			return nil, newAPIError(resp, body, fmt.Sprintf("list attributes: status %d: %s", resp.StatusCode, string(body)))
 		}
 		var page editAssetAttributesList
 		if err := json.Unmarshal(body, &page); err != nil {
@@ -268,7 +268,7 @@ func GetEffectiveAssignmentForAsset(ctx context.Context, client *http.Client, as
 	}
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("get effective assignment: status %d: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp, body, fmt.Sprintf("get effective assignment: status %d: %s", resp.StatusCode, string(body)))
 	}
 
 	var raw rawAssignmentResponse
@@ -402,7 +402,7 @@ func PatchAsset(ctx context.Context, client *http.Client, assetID string, payloa
 		return nil, fmt.Errorf("patch asset: reading response: %w", err)
 	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("patch asset: status %d: %s", resp.StatusCode, string(respBody))
+		return nil, newAPIError(resp, respBody, fmt.Sprintf("patch asset: status %d: %s", resp.StatusCode, string(respBody)))
 	}
 
 	var result EditAssetCore
@@ -438,7 +438,7 @@ func PatchAttributeValue(ctx context.Context, client *http.Client, attributeID,
 		return nil, fmt.Errorf("patch attribute: reading response: %w", err)
 	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("patch attribute: status %d: %s", resp.StatusCode, string(respBody))
+		return nil, newAPIError(resp, respBody, fmt.Sprintf("patch attribute: status %d: %s", resp.StatusCode, string(respBody)))
 	}
 
 	var result EditAssetAttributeInstance
@@ -478,7 +478,7 @@ func CreateAttributeOnAsset(ctx context.Context, client *http.Client, assetID, a
 		return nil, fmt.Errorf("create attribute: reading response: %w", err)
 	}
 	if resp.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("create attribute: status %d: %s", resp.StatusCode, string(respBody))
+		return nil, newAPIError(resp, respBody, fmt.Sprintf("create attribute: status %d: %s", resp.StatusCode, string(respBody)))
 	}
 
 	var result EditAssetAttributeInstance
@@ -505,7 +505,7 @@ func DeleteAttribute(ctx context.Context, client *http.Client, attributeID strin
 
 	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("delete attribute: status %d: %s", resp.StatusCode, string(body))
+		return newAPIError(resp, body, fmt.Sprintf("delete attribute: status %d: %s", resp.StatusCode, string(body)))
 	}
 	return nil
 }
@@ -550,7 +550,7 @@ func CreateRelation(ctx context.Context, client *http.Client, payload EditAssetC
 		return nil, fmt.Errorf("create relation: reading response: %w", err)
 	}
 	if resp.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("create relation: status %d: %s", resp.StatusCode, string(respBody))
+		return nil, newAPIError(resp, respBody, fmt.Sprintf("create relation: status %d: %s", resp.StatusCode, string(respBody)))
 	}
 
 	var result EditAssetRelation
@@ -590,7 +590,7 @@ func AddTagsToAsset(ctx context.Context, client *http.Client, assetID string, ta
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent {
 		respBody, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("add tags: status %d: %s", resp.StatusCode, string(respBody))
+		return newAPIError(resp, respBody, fmt.Sprintf("add tags: status %d: %s", resp.StatusCode, string(respBody)))
 	}
 	return nil
 }
@@ -620,6 +620,12 @@ type editAssetStatusesList struct {
 // ListStatuses returns all asset statuses defined in Collibra. Used to resolve
 // a status name (e.g. "Candidate") to its UUID before patching an asset.
 func ListStatuses(ctx context.Context, client *http.Client) ([]EditAssetStatus, error) {
+	return cachedLookup(ctx, "statuses", func() ([]EditAssetStatus, error) {
+		return fetchStatuses(ctx, client)
+	})
+}
+
+func fetchStatuses(ctx context.Context, client *http.Client) ([]EditAssetStatus, error) {
 	const pageSize = 1000
 	var all []EditAssetStatus
 	offset := 0
@@ -645,7 +651,7 @@ func ListStatuses(ctx context.Context, client *http.Client) ([]EditAssetStatus,
 			return nil, fmt.Errorf("list statuses: reading response: %w", readErr)
 		}
 		if resp.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("list statuses: status %d: %s", resp.StatusCode, string(body))
+			return nil, newAPIError(resp, body, fmt.Sprintf("list statuses: status %d: %s", resp.StatusCode, string(body)))
 		}
 		var page editAssetStatusesList
 		if err := json.Unmarshal(body, &page); err != nil {
@@ -672,6 +678,12 @@ type editAssetRolesList struct {
 // to resolve a role name (e.g. "Steward") to its UUID before creating a
 // responsibility. The full list is typically small and fits in a single page.
 func ListRoles(ctx context.Context, client *http.Client) ([]EditAssetRole, error) {
+	return cachedLookup(ctx, "roles", func() ([]EditAssetRole, error) {
+		return fetchRoles(ctx, client)
+	})
+}
+
+func fetchRoles(ctx context.Context, client *http.Client) ([]EditAssetRole, error) {
 	const pageSize = 1000
 	var all []EditAssetRole
 	offset := 0
@@ -697,7 +709,7 @@ func ListRoles(ctx context.Context, client *http.Client) ([]EditAssetRole, error
 			return nil, fmt.Errorf("list roles: reading response: %w", readErr)
 		}
 		if resp.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("list roles: status %d: %s", resp.StatusCode, string(body))
+			return nil, newAPIError(resp, body, fmt.Sprintf("list roles: status %d: %s", resp.StatusCode, string(body)))
 		}
 		var page editAssetRolesList
 		if err := json.Unmarshal(body, &page); err != nil {
@@ -777,7 +789,7 @@ func FindUserByEmail(ctx context.Context, client *http.Client, email string) (*E
 		return nil, nil
 	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("find user by email: status %d: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp, body, fmt.Sprintf("find user by email: status %d: %s", resp.StatusCode, string(body)))
 	}
 	var user EditAssetUser
 	if err := json.Unmarshal(body, &user); err != nil {
@@ -810,7 +822,7 @@ func listUsers(ctx context.Context, client *http.Client, params url.Values) ([]E
 		return nil, fmt.Errorf("find user: reading response: %w", err)
 	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("find user: status %d: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp, body, fmt.Sprintf("find user: status %d: %s", resp.StatusCode, string(body)))
 	}
 	var page editAssetUsersList
 	if err := json.Unmarshal(body, &page); err != nil {
@@ -864,7 +876,7 @@ func CreateResponsibility(ctx context.Context, client *http.Client, payload Edit
 		return nil, fmt.Errorf("create responsibility: reading response: %w", err)
 	}
 	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("create responsibility: status %d: %s", resp.StatusCode, string(respBody))
+		return nil, newAPIError(resp, respBody, fmt.Sprintf("create responsibility: status %d: %s", resp.StatusCode, string(respBody)))
 	}
 
 	var result EditAssetResponsibility
@@ -895,7 +907,7 @@ func DeleteResponsibility(ctx context.Context, client *http.Client, responsibili
 		return fmt.Errorf("delete responsibility: reading response: %w", err)
 	}
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("delete responsibility: status %d: %s", resp.StatusCode, string(body))
+		return newAPIError(resp, body, fmt.Sprintf("delete responsibility: status %d: %s", resp.StatusCode, string(body)))
 	}
 	return nil
 }
@@ -933,7 +945,7 @@ func BulkCreateAttributes(ctx context.Context, client *http.Client, items []Crea
 		return nil, fmt.Errorf("bulk create attributes: reading response: %w", err)
 	}
 	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("bulk create attributes: status %d: %s", resp.StatusCode, string(respBody))
+		return nil, newAPIError(resp, respBody, fmt.Sprintf("bulk create attributes: status %d: %s", resp.StatusCode, string(respBody)))
 	}
 	var result []EditAssetAttributeInstance
 	if err := json.Unmarshal(respBody, &result); err != nil {
@@ -968,7 +980,7 @@ func BulkPatchAttributes(ctx context.Context, client *http.Client, items []EditA
 		return nil, fmt.Errorf("bulk patch attributes: reading response: %w", err)
 	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("bulk patch attributes: status %d: %s", resp.StatusCode, string(respBody))
+		return nil, newAPIError(resp, respBody, fmt.Sprintf("bulk patch attributes: status %d: %s", resp.StatusCode, string(respBody)))
 	}
 	var result []EditAssetAttributeInstance
 	if err := json.Unmarshal(respBody, &result); err != nil {
@@ -1002,7 +1014,7 @@ func BulkCreateRelations(ctx context.Context, client *http.Client, items []EditA
 		return nil, fmt.Errorf("bulk create relations: reading response: %w", err)
 	}
 	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("bulk create relations: status %d: %s", resp.StatusCode, string(respBody))
+		return nil, newAPIError(resp, respBody, fmt.Sprintf("bulk create relations: status %d: %s", resp.StatusCode, string(respBody)))
 	}
 	var result []EditAssetRelation
 	if err := json.Unmarshal(respBody, &result); err != nil {
@@ -1031,7 +1043,7 @@ func DeleteRelation(ctx context.Context, client *http.Client, relationID string)
 	}
 	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("delete relation: status %d: %s", resp.StatusCode, string(body))
+		return newAPIError(resp, body, fmt.Sprintf("delete relation: status %d: %s", resp.StatusCode, string(body)))
 	}
 	return nil
 }
@@ -6,8 +6,188 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/collibra/chip/pkg/chip"
+)
+
+// APIError is a failed request to Collibra. Its Code puts it in a category of
+// chip's error taxonomy; match a category with errors.Is and ErrNotFound,
+// ErrPermissionDenied, ErrConflict, ErrRateLimited, ErrValidation or
+// ErrUpstream.
+type APIError struct {
+	Code       chip.ErrorCode
+	StatusCode int
+	// CollibraErrorCode is Collibra's own errorCode, when the response has one.
+	CollibraErrorCode string
+	// Permission is the missing permission, when Collibra names it.
+	Permission string
+	// Fields are the invalid fields of a validation error.
+	Fields []FieldError
+	// RetryAfter is how long Collibra asked to wait before trying again.
+	RetryAfter time.Duration
+
+	message string
+	err     error
+}
+
+// FieldError is an invalid field reported by Collibra.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+var (
+	ErrNotFound         = &APIError{Code: chip.ErrorNotFound}
+	ErrPermissionDenied = &APIError{Code: chip.ErrorPermissionDenied}
+	ErrConflict         = &APIError{Code: chip.ErrorConflict}
+	ErrRateLimited      = &APIError{Code: chip.ErrorRateLimited}
+	ErrValidation       = &APIError{Code: chip.ErrorValidation}
+	ErrUpstream         = &APIError{Code: chip.ErrorUpstream}
 )
 
+func (e *APIError) Error() string {
+	if e.message == "" && e.err == nil {
+		return string(e.Code)
+	}
+	if e.err != nil {
+		return e.message + ": " + e.err.Error()
+	}
+	return e.message
+}
+
+func (e *APIError) Unwrap() error {
+	return e.err
+}
+
+// Is matches the category sentinels, so errors.Is(err, ErrNotFound) holds for
+// every not found error.
+func (e *APIError) Is(target error) bool {
+	category, ok := target.(*APIError)
+	return ok && category.StatusCode == 0 && category.message == "" && category.Code == e.Code
+}
+
+func (e *APIError) ErrorCode() chip.ErrorCode {
+	return e.Code
+}
+
+func (e *APIError) ErrorDetails() map[string]any {
+	details := map[string]any{}
+	if e.StatusCode != 0 {
+		details["status"] = e.StatusCode
+	}
+	if e.CollibraErrorCode != "" {
+		details["collibraErrorCode"] = e.CollibraErrorCode
+	}
+	if e.Permission != "" {
+		details["permissions"] = []string{e.Permission}
+	}
+	if len(e.Fields) > 0 {
+		details["fields"] = e.Fields
+	}
+	if e.RetryAfter > 0 {
+		details["retryAfterSeconds"] = e.RetryAfter.Seconds()
+	}
+	return details
+}
+
+// errorCodeForStatus maps an HTTP status to its category.
+func errorCodeForStatus(status int) chip.ErrorCode {
+	switch status {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return chip.ErrorValidation
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return chip.ErrorPermissionDenied
+	case http.StatusNotFound, http.StatusGone:
+		return chip.ErrorNotFound
+	case http.StatusConflict, http.StatusPreconditionFailed:
+		return chip.ErrorConflict
+	case http.StatusTooManyRequests:
+		return chip.ErrorRateLimited
+	default:
+		return chip.ErrorUpstream
+	}
+}
+
+// permissionPattern finds the permission named in a Collibra error message,
+// e.g. "You do not have the permission dgc.classify".
+var permissionPattern = regexp.MustCompile(`\bdgc\.[a-z][a-z-]*[a-z]\b`)
+
+// newAPIError categorizes a non-2xx response. message is the text of the
+// error; the body is read for Collibra's error details.
+func newAPIError(response *http.Response, body []byte, message string) *APIError {
+	apiErr := &APIError{
+		Code:       errorCodeForStatus(response.StatusCode),
+		StatusCode: response.StatusCode,
+		message:    message,
+	}
+	var details struct {
+		collibraStandardError
+		Errors      []fieldError `json:"errors"`
+		FieldErrors []fieldError `json:"fieldErrors"`
+	}
+	if json.Unmarshal(body, &details) == nil {
+		apiErr.CollibraErrorCode = details.ErrorCode
+		for _, field := range append(details.FieldErrors, details.Errors...) {
+			if field.field() != "" {
+				apiErr.Fields = append(apiErr.Fields, FieldError{Field: field.field(), Message: field.message()})
+			}
+		}
+	}
+	if apiErr.Code == chip.ErrorPermissionDenied {
+		apiErr.Permission = permissionPattern.FindString(string(body))
+	}
+	if after, ok := retryAfter(response.Header.Get("Retry-After")); ok && apiErr.Code == chip.ErrorRateLimited {
+		apiErr.RetryAfter = after
+	}
+	return apiErr
+}
+
+// httpError categorizes a non-2xx response as "HTTP <status>: <body>".
+func httpError(response *http.Response, body []byte) *APIError {
+	return newAPIError(response, body, fmt.Sprintf("HTTP %d: %s", response.StatusCode, string(body)))
+}
+
+// statusError categorizes a non-2xx response of which only the status and
+// body were kept, as returned by dqDo.
+func statusError(status int, body []byte, message string) *APIError {
+	return newAPIError(&http.Response{StatusCode: status}, body, message)
+}
+
+// requestError categorizes a request that got no response. Errors that are
+// already categorized, such as a refusal in read-only mode, keep their
+// category.
+func requestError(err error) error {
+	var coded chip.CodedError
+	if errors.As(err, &coded) {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	return &APIError{Code: chip.ErrorUpstream, message: "failed to make request", err: err}
+}
+
+// fieldError is a field error in the shapes used by Collibra APIs.
+type fieldError struct {
+	Field          string `json:"field"`
+	Property       string `json:"property"`
+	Message        string `json:"message"`
+	DefaultMessage string `json:"defaultMessage"`
+}
+
+func (f fieldError) field() string {
+	if f.Field != "" {
+		return f.Field
+	}
+	return f.Property
+}
+
+func (f fieldError) message() string {
+	if f.Message != "" {
+		return f.Message
+	}
+	return f.DefaultMessage
+}
+
 // collibraStandardError is the error envelope returned by Collibra APIs on
 // non-2xx responses. Both the Semantic Blueprint and Context Engine APIs use
 // this shape.
@@ -24,9 +204,9 @@ type collibraStandardError struct {
 // machine-readable errorCode and user-facing userMessage so the calling model
 // can understand why the call failed.
 func executeCollibraRequest(client *http.Client, req *http.Request) ([]byte, error) {
-	response, err := client.Do(req)
+	response, err := doWithRetry(client, req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
+		return nil, requestError(err)
 	}
 	defer func(body io.ReadCloser) {
 		_ = body.Close()
@@ -47,9 +227,9 @@ func executeCollibraRequest(client *http.Client, req *http.Request) ([]byte, err
 			if errResp.HelpMessage != "" {
 				msg += ". Hint: " + errResp.HelpMessage
 			}
-			return nil, errors.New(msg)
+			return nil, newAPIError(response, responseBody, msg)
 		}
-		return nil, fmt.Errorf("HTTP %d: %s", response.StatusCode, string(responseBody))
+		return nil, httpError(response, responseBody)
 	}
 
 	return responseBody, nil
@@ -0,0 +1,106 @@
+package clients
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/collibra/chip/pkg/chip"
+)
+
+func TestExecuteRequest_CategorizesFailures(t *testing.T) {
+	useRetryPolicy(t, RetryPolicy{MaxAttempts: 1})
+	tests := []struct {
+		status   int
+		header   http.Header
+		body     string
+		category error
+		check    func(t *testing.T, apiErr *APIError)
+	}{
+		{status: http.StatusNotFound, body: `{"errorCode":"entityNotFound"}`, category: ErrNotFound, check: func(t *testing.T, apiErr *APIError) {
+			if apiErr.CollibraErrorCode != "entityNotFound" {
+				t.Errorf("expected Collibra's errorCode, got %q", apiErr.CollibraErrorCode)
+			}
+		}},
+		{status: http.StatusForbidden, body: `{"userMessage":"You do not have the permission dgc.classify."}`, category: ErrPermissionDenied, check: func(t *testing.T, apiErr *APIError) {
+			if apiErr.Permission != "dgc.classify" {
+				t.Errorf("expected the missing permission, got %q", apiErr.Permission)
+			}
+		}},
+		{status: http.StatusConflict, category: ErrConflict},
+		{status: http.StatusTooManyRequests, header: http.Header{"Retry-After": {"7"}}, category: ErrRateLimited, check: func(t *testing.T, apiErr *APIError) {
+			if apiErr.RetryAfter != 7*time.Second {
+				t.Errorf("expected Retry-After, got %s", apiErr.RetryAfter)
+			}
+		}},
+		{status: http.StatusBadRequest, body: `{"errors":[{"field":"name","defaultMessage":"must not be blank"}]}`, category: ErrValidation, check: func(t *testing.T, apiErr *APIError) {
+			if len(apiErr.Fields) != 1 || apiErr.Fields[0] != (FieldError{Field: "name", Message: "must not be blank"}) {
+				t.Errorf("expected the invalid field, got %+v", apiErr.Fields)
+			}
+		}},
+		{status: http.StatusInternalServerError, body: "boom", category: ErrUpstream},
+	}
+	for _, tt := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for k, v := range tt.header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(tt.status)
+			_, _ = w.Write([]byte(tt.body))
+		}))
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		_, err := executeRequest(server.Client(), req)
+		server.Close()
+
+		var apiErr *APIError
+		if !errors.Is(err, tt.category) || !errors.As(err, &apiErr) {
+			t.Errorf("HTTP %d: expected %v, got %v", tt.status, tt.category, err)
+			continue
+		}
+		if apiErr.StatusCode != tt.status || chip.ErrorCodeOf(err) != apiErr.Code {
+			t.Errorf("HTTP %d: unexpected error %+v", tt.status, apiErr)
+		}
+		if tt.check != nil {
+			tt.check(t, apiErr)
+		}
+	}
+}
+
+func TestExecuteRequest_KeepsTheErrorMessage(t *testing.T) {
+	useRetryPolicy(t, RetryPolicy{MaxAttempts: 1})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte("no such asset"))
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	if _, err := executeRequest(server.Client(), req); err == nil || err.Error() != "HTTP 404: no such asset" {
+		t.Errorf("unexpected error %v", err)
+	}
+	req, _ = http.NewRequest(http.MethodGet, "http://127.0.0.1:1", nil)
+	if _, err := executeRequest(http.DefaultClient, req); !errors.Is(err, ErrUpstream) {
+		t.Errorf("expected an unreachable Collibra to be an upstream error, got %v", err)
+	}
+}
+
+func TestClients_CategorizeUnexpectedStatuses(t *testing.T) {
+	useRetryPolicy(t, RetryPolicy{MaxAttempts: 1})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"userMessage":"You do not have the permission dgc.view-asset."}`))
+	}))
+	defer server.Close()
+
+	_, err := GetAssetCore(t.Context(), newTestClient(server), "asset-1")
+	if !errors.Is(err, ErrPermissionDenied) || err.Error() != `get asset: status 403: {"userMessage":"You do not have the permission dgc.view-asset."}` {
+		t.Errorf("expected a permission denied error keeping its message, got %v", err)
+	}
+	_, err = GetDQRule(t.Context(), newTestClient(server), "job", "rule")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != chip.ErrorPermissionDenied || apiErr.Permission != "dgc.view-asset" {
+		t.Errorf("expected a permission denied error from the DQ API, got %v", err)
+	}
+}
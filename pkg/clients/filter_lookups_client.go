@@ -46,7 +46,7 @@ func SearchCommunitiesByName(ctx context.Context, client *http.Client, name stri
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("searching communities by name: status %d: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp, body, fmt.Sprintf("searching communities by name: status %d: %s", resp.StatusCode, string(body)))
 	}
 
 	var result communityListResponse
@@ -72,6 +72,12 @@ type domainTypeListResponse struct {
 // ListDomainTypes fetches every domain type defined in the instance. The set is
 // small (tens of entries in OOTB Collibra), so a single large page suffices.
 func ListDomainTypes(ctx context.Context, client *http.Client) ([]DomainType, error) {
+	return cachedLookup(ctx, "domainTypes", func() ([]DomainType, error) {
+		return fetchDomainTypes(ctx, client)
+	})
+}
+
+func fetchDomainTypes(ctx context.Context, client *http.Client) ([]DomainType, error) {
 	reqURL := "/rest/2.0/domainTypes?limit=1000&offset=0"
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
 	if err != nil {
@@ -87,7 +93,7 @@ func ListDomainTypes(ctx context.Context, client *http.Client) ([]DomainType, er
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("listing domain types: status %d: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp, body, fmt.Sprintf("listing domain types: status %d: %s", resp.StatusCode, string(body)))
 	}
 
 	var result domainTypeListResponse
@@ -102,7 +102,6 @@ type lineageTransformationsResponse struct {
 
 type GetLineageEntityOutput struct {
 	Entity *LineageEntity `json:"entity,omitempty"`
-	Error  string         `json:"error,omitempty"`
 	Found  bool           `json:"found"`
 }
 
@@ -112,7 +111,6 @@ type GetLineageDirectionalOutput struct {
 	Relations  []LineageRelation        `json:"relations"`
 	Pagination *LineagePagination       `json:"pagination,omitempty"`
 	Warnings   []LineageResponseWarning `json:"warnings,omitempty"`
-	Error      string                   `json:"error,omitempty"`
 }
 
 type SearchLineageEntitiesOutput struct {
@@ -123,7 +121,6 @@ type SearchLineageEntitiesOutput struct {
 
 type GetLineageTransformationOutput struct {
 	Transformation *LineageTransformation `json:"transformation,omitempty"`
-	Error          string                 `json:"error,omitempty"`
 	Found          bool                   `json:"found"`
 }
 
@@ -182,7 +179,7 @@ func GetLineageEntity(ctx context.Context, collibraHttpClient *http.Client, enti
 
 	body, err := executeRequest(collibraHttpClient, req)
 	if err != nil {
-		return &GetLineageEntityOutput{Found: false, Error: err.Error()}, nil
+		return nil, err
 	}
 
 	var entity LineageEntity
@@ -222,7 +219,7 @@ func getLineageDirectional(ctx context.Context, collibraHttpClient *http.Client,
 
 	body, err := executeRequest(collibraHttpClient, req)
 	if err != nil {
-		return &GetLineageDirectionalOutput{EntityId: entityId, Direction: direction, Relations: []LineageRelation{}, Error: err.Error()}, nil
+		return nil, err
 	}
 
 	var resp lineageUpstreamDownstreamResponse
@@ -291,7 +288,7 @@ func GetLineageTransformation(ctx context.Context, collibraHttpClient *http.Clie
 
 	body, err := executeRequest(collibraHttpClient, req)
 	if err != nil {
-		return &GetLineageTransformationOutput{Found: false, Error: err.Error()}, nil
+		return nil, err
 	}
 
 	var t LineageTransformation
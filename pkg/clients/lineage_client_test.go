@@ -3,6 +3,7 @@ package clients
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -180,39 +181,27 @@ func TestGetLineageDownstream_RoutesCorrectly(t *testing.T) {
 	}
 }
 
-func TestGetLineageDownstream_ErrorReturnsEmptyRelations(t *testing.T) {
+func TestGetLineageDownstream_ReturnsTheError(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "not found", http.StatusNotFound)
 	}))
 	defer server.Close()
 
 	out, err := GetLineageDownstream(context.Background(), newTestClient(server), "entity-x", "", 0, "")
-	if err != nil {
-		t.Fatalf("unexpected hard error: %v", err)
-	}
-	if out.Error == "" {
-		t.Errorf("expected error message in output")
-	}
-	if out.Relations == nil {
-		t.Errorf("expected non-nil Relations slice on error, got nil")
+	if !errors.Is(err, ErrNotFound) || out != nil {
+		t.Errorf("expected a not found error, got %+v, %v", out, err)
 	}
 }
 
-func TestGetLineageUpstream_ErrorReturnsEmptyRelations(t *testing.T) {
+func TestGetLineageUpstream_ReturnsTheError(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "not found", http.StatusNotFound)
 	}))
 	defer server.Close()
 
 	out, err := GetLineageUpstream(context.Background(), newTestClient(server), "entity-x", "", 0, "")
-	if err != nil {
-		t.Fatalf("unexpected hard error: %v", err)
-	}
-	if out.Error == "" {
-		t.Errorf("expected error message in output")
-	}
-	if out.Relations == nil {
-		t.Errorf("expected non-nil Relations slice on error, got nil")
+	if !errors.Is(err, ErrNotFound) || out != nil {
+		t.Errorf("expected a not found error, got %+v, %v", out, err)
 	}
 }
 
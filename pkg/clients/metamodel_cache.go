@@ -0,0 +1,170 @@
+package clients
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/collibra/chip/pkg/chip"
+)
+
+// MetamodelCacheConfig controls the read-through cache in front of the
+// metamodel lookups (statuses, roles, asset and domain types, attribute and
+// relation type details) that write workflows repeat on every call.
+type MetamodelCacheConfig struct {
+	// TTL is how long a lookup result is served from the cache. Zero
+	// disables the cache.
+	TTL time.Duration
+	// MaxEntries bounds the number of cached results; the least recently
+	// used one is evicted first. Zero means unbounded.
+	MaxEntries int
+}
+
+// DefaultMetamodelCacheConfig is used until ConfigureMetamodelCache is called.
+var DefaultMetamodelCacheConfig = MetamodelCacheConfig{
+	TTL:        5 * time.Minute,
+	MaxEntries: 1000,
+}
+
+var metamodel = newMetamodelCache(DefaultMetamodelCacheConfig)
+
+// ConfigureMetamodelCache replaces the cache configuration and drops every
+// cached result.
+func ConfigureMetamodelCache(config MetamodelCacheConfig) {
+	metamodel.configure(config)
+}
+
+// InvalidateMetamodelCache drops every cached metamodel lookup, e.g. after
+// the metamodel was changed in Collibra.
+func InvalidateMetamodelCache() {
+	metamodel.clear()
+}
+
+type metamodelCacheKey struct {
+	host     string
+	identity string
+	lookup   string
+}
+
+type metamodelCacheEntry struct {
+	key     metamodelCacheKey
+	value   []byte
+	expires time.Time
+}
+
+type metamodelCache struct {
+	mu      sync.Mutex
+	config  MetamodelCacheConfig
+	entries map[metamodelCacheKey]*list.Element
+	lru     *list.List // most recently used first
+	now     func() time.Time
+}
+
+func newMetamodelCache(config MetamodelCacheConfig) *metamodelCache {
+	return &metamodelCache{
+		config:  config,
+		entries: map[metamodelCacheKey]*list.Element{},
+		lru:     list.New(),
+		now:     time.Now,
+	}
+}
+
+func (c *metamodelCache) configure(config MetamodelCacheConfig) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.config = config
+	c.clearLocked()
+}
+
+func (c *metamodelCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.clearLocked()
+}
+
+func (c *metamodelCache) clearLocked() {
+	clear(c.entries)
+	c.lru.Init()
+}
+
+func (c *metamodelCache) get(key metamodelCacheKey) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*metamodelCacheEntry)
+	if !c.now().Before(entry.expires) {
+		c.lru.Remove(element)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.lru.MoveToFront(element)
+	return entry.value, true
+}
+
+func (c *metamodelCache) put(key metamodelCacheKey, value []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.config.TTL <= 0 {
+		return
+	}
+	entry := &metamodelCacheEntry{key: key, value: value, expires: c.now().Add(c.config.TTL)}
+	if element, ok := c.entries[key]; ok {
+		element.Value = entry
+		c.lru.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(entry)
+	for c.config.MaxEntries > 0 && c.lru.Len() > c.config.MaxEntries {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*metamodelCacheEntry).key)
+	}
+}
+
+func (c *metamodelCache) enabled() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.config.TTL > 0
+}
+
+// cachedLookup serves lookup from the metamodel cache, calling fetch on a
+// miss. Results are scoped to the Collibra host and the caller's credentials,
+// since what the metamodel endpoints return depends on the caller's
+// permissions. Without a Collibra host in ctx (i.e. outside a tool call) the
+// cache is bypassed. Errors are never cached. Results are kept as JSON and
+// every hit decodes a copy, so callers may modify what they get.
+func cachedLookup[T any](ctx context.Context, lookup string, fetch func() (T, error)) (T, error) {
+	host, ok := chip.GetCollibraHost(ctx)
+	if !ok || !metamodel.enabled() {
+		return fetch()
+	}
+	key := metamodelCacheKey{host: host, identity: callerIdentity(ctx), lookup: lookup}
+	if data, ok := metamodel.get(key); ok {
+		var value T
+		if json.Unmarshal(data, &value) == nil {
+			return value, nil
+		}
+	}
+	value, err := fetch()
+	if err != nil {
+		return value, err
+	}
+	if data, err := json.Marshal(value); err == nil {
+		metamodel.put(key, data)
+	}
+	return value, nil
+}
+
+// callerIdentity identifies the credentials of the tool call of ctx.
+func callerIdentity(ctx context.Context) string {
+	toolRequest, ok := chip.GetCallToolRequest(ctx)
+	if !ok {
+		return ""
+	}
+	return chip.CallerIdentity(ctx, toolRequest.GetExtra())
+}
@@ -0,0 +1,149 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/collibra/chip/pkg/chip"
+	"github.com/collibra/chip/pkg/tools/testutil"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func useMetamodelCache(t *testing.T, config MetamodelCacheConfig) {
+	t.Helper()
+	ConfigureMetamodelCache(config)
+	t.Cleanup(func() { ConfigureMetamodelCache(DefaultMetamodelCacheConfig) })
+}
+
+// toolContext mimics the context of a tool call against host, made with the
+// given Authorization header.
+func toolContext(host, authorization string) context.Context {
+	header := http.Header{}
+	if authorization != "" {
+		header.Set("Authorization", authorization)
+	}
+	request := &mcp.CallToolRequest{Extra: &mcp.RequestExtra{Header: header}}
+	ctx := chip.SetCallToolRequest(context.Background(), request)
+	return chip.SetCollibraHost(ctx, host)
+}
+
+func countingStatusServer(t *testing.T) (*http.Client, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(`{"total":1,"results":[{"id":"s1","name":"Candidate"}]}`))
+	}))
+	t.Cleanup(server.Close)
+	return testutil.NewClient(server), &calls
+}
+
+func TestMetamodelCache_ServesRepeatedLookupsPerHostAndCaller(t *testing.T) {
+	useMetamodelCache(t, MetamodelCacheConfig{TTL: time.Minute, MaxEntries: 10})
+	client, calls := countingStatusServer(t)
+
+	alice := toolContext("https://dev.collibra.com", "Basic YWxpY2U6")
+	for range 3 {
+		statuses, err := ListStatuses(alice, client)
+		if err != nil || len(statuses) != 1 || statuses[0].Name != "Candidate" {
+			t.Fatalf("unexpected result %+v, %v", statuses, err)
+		}
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("expected repeated lookups to be cached, got %d requests", calls.Load())
+	}
+
+	_, _ = ListStatuses(toolContext("https://dev.collibra.com", "Basic Ym9iOg=="), client)
+	_, _ = ListStatuses(toolContext("https://prod.collibra.com", "Basic YWxpY2U6"), client)
+	if calls.Load() != 3 {
+		t.Errorf("expected other callers and hosts to miss the cache, got %d requests", calls.Load())
+	}
+
+	InvalidateMetamodelCache()
+	_, _ = ListStatuses(alice, client)
+	if calls.Load() != 4 {
+		t.Errorf("expected a lookup after invalidation to hit Collibra, got %d requests", calls.Load())
+	}
+}
+
+func TestMetamodelCache_SharedByCallersOfServerCredentials(t *testing.T) {
+	useMetamodelCache(t, MetamodelCacheConfig{TTL: time.Minute, MaxEntries: 10})
+	client, calls := countingStatusServer(t)
+
+	// The callers authenticate to chip with their own API keys, which the
+	// Collibra client does not forward when chip has credentials of its own.
+	for _, authorization := range []string{"Bearer alice-key", "Bearer bob-key"} {
+		ctx := chip.SetServerCredentials(toolContext("https://dev.collibra.com", authorization), true)
+		if _, err := ListStatuses(ctx, client); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected callers of the server-wide credentials to share the cache, got %d requests", calls.Load())
+	}
+}
+
+func TestMetamodelCache_BypassedOutsideToolCallsOrWhenDisabled(t *testing.T) {
+	useMetamodelCache(t, MetamodelCacheConfig{TTL: time.Minute})
+	client, calls := countingStatusServer(t)
+
+	_, _ = ListStatuses(context.Background(), client)
+	_, _ = ListStatuses(context.Background(), client)
+	if calls.Load() != 2 {
+		t.Errorf("expected no caching without a Collibra host, got %d requests", calls.Load())
+	}
+
+	ConfigureMetamodelCache(MetamodelCacheConfig{})
+	ctx := toolContext("https://dev.collibra.com", "")
+	_, _ = ListStatuses(ctx, client)
+	_, _ = ListStatuses(ctx, client)
+	if calls.Load() != 4 {
+		t.Errorf("expected no caching with a zero TTL, got %d requests", calls.Load())
+	}
+}
+
+func TestMetamodelCache_ServesCopies(t *testing.T) {
+	useMetamodelCache(t, MetamodelCacheConfig{TTL: time.Minute})
+	client, calls := countingStatusServer(t)
+	ctx := toolContext("https://dev.collibra.com", "")
+
+	statuses, err := ListStatuses(ctx, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	statuses[0].Name = "Changed"
+	statuses, err = ListStatuses(ctx, client)
+	if err != nil || calls.Load() != 1 {
+		t.Fatalf("expected a cached lookup, got %d requests, %v", calls.Load(), err)
+	}
+	if statuses[0].Name != "Candidate" {
+		t.Errorf("expected a change to a result to leave the cache intact, got %q", statuses[0].Name)
+	}
+}
+
+func TestMetamodelCache_ExpiresAndEvicts(t *testing.T) {
+	cache := newMetamodelCache(MetamodelCacheConfig{TTL: time.Minute, MaxEntries: 2})
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	key := func(lookup string) metamodelCacheKey { return metamodelCacheKey{host: "h", lookup: lookup} }
+
+	cache.put(key("a"), []byte("1"))
+	cache.put(key("b"), []byte("2"))
+	_, _ = cache.get(key("a")) // a is now more recently used than b
+	cache.put(key("c"), []byte("3"))
+	if _, ok := cache.get(key("b")); ok {
+		t.Error("expected the least recently used entry to be evicted")
+	}
+	if _, ok := cache.get(key("a")); !ok {
+		t.Error("expected a recently used entry to survive eviction")
+	}
+
+	now = now.Add(time.Minute)
+	if _, ok := cache.get(key("c")); ok {
+		t.Error("expected the entry to expire after the TTL")
+	}
+}
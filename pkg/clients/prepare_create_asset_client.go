@@ -108,7 +108,7 @@ func ListAssetTypesForPrepare(ctx context.Context, client *http.Client, limit in
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, 0, fmt.Errorf("listing asset types: status %d: %s", resp.StatusCode, string(body))
+		return nil, 0, newAPIError(resp, body, fmt.Sprintf("listing asset types: status %d: %s", resp.StatusCode, string(body)))
 	}
 
 	var result PrepareCreateAssetTypeListResponse
@@ -138,7 +138,7 @@ func GetAssetTypeByPublicID(ctx context.Context, client *http.Client, publicID s
 	}
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("getting asset type: status %d: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp, body, fmt.Sprintf("getting asset type: status %d: %s", resp.StatusCode, string(body)))
 	}
 
 	var result PrepareCreateAssetType
@@ -165,7 +165,7 @@ func ListDomainsForPrepare(ctx context.Context, client *http.Client, limit int)
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, 0, fmt.Errorf("listing domains: status %d: %s", resp.StatusCode, string(body))
+		return nil, 0, newAPIError(resp, body, fmt.Sprintf("listing domains: status %d: %s", resp.StatusCode, string(body)))
 	}
 
 	var result PrepareCreateDomainListResponse
@@ -195,7 +195,7 @@ func GetDomainByID(ctx context.Context, client *http.Client, domainID string) (*
 	}
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("getting domain: status %d: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp, body, fmt.Sprintf("getting domain: status %d: %s", resp.StatusCode, string(body)))
 	}
 
 	var result PrepareCreateDomain
@@ -222,7 +222,7 @@ func GetAvailableAssetTypesForDomain(ctx context.Context, client *http.Client, d
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("getting available asset types for domain: status %d: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp, body, fmt.Sprintf("getting available asset types for domain: status %d: %s", resp.StatusCode, string(body)))
 	}
 
 	var result []PrepareCreateAssetType
@@ -249,7 +249,7 @@ func GetAttributeTypeByID(ctx context.Context, client *http.Client, attrTypeID s
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("getting attribute type: status %d: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp, body, fmt.Sprintf("getting attribute type: status %d: %s", resp.StatusCode, string(body)))
 	}
 
 	var result PrepareCreateAttributeType
@@ -282,7 +282,7 @@ func SearchAssetsForDuplicate(ctx context.Context, client *http.Client, name str
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("searching assets: status %d: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp, body, fmt.Sprintf("searching assets: status %d: %s", resp.StatusCode, string(body)))
 	}
 
 	var result PrepareCreateAssetSearchResponse
@@ -292,6 +292,44 @@ func SearchAssetsForDuplicate(ctx context.Context, client *http.Client, name str
 	return result.Results, nil
 }
 
+// SearchAssetsByName queries /assets for the assets whose name starts with
+// name, optionally of the asset types with the given public IDs, and returns
+// them up to the given limit along with the total number of matches.
+func SearchAssetsByName(ctx context.Context, client *http.Client, name string, typePublicIDs []string, limit int) ([]PrepareCreateAssetResult, int, error) {
+	params := url.Values{}
+	params.Set("name", name)
+	params.Set("nameMatchMode", "START")
+	for _, publicID := range typePublicIDs {
+		params.Add("typePublicIds", publicID)
+	}
+	params.Set("limit", fmt.Sprintf("%d", limit))
+	params.Set("offset", "0")
+
+	reqURL := "/rest/2.0/assets?" + params.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("building search assets by name request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("searching assets by name: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, 0, newAPIError(resp, body, fmt.Sprintf("searching assets by name: status %d: %s", resp.StatusCode, string(body)))
+	}
+
+	var result PrepareCreateAssetSearchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, 0, fmt.Errorf("decoding assets search response: %w", err)
+	}
+	return result.Results, result.Total, nil
+}
+
 // --- Consolidated lookups (used by both prepare_create_asset and create_asset) ---
 
 // PrepareCreateStatus is one Collibra status value (e.g. "Candidate").
@@ -418,7 +456,7 @@ func GetAssetTypeByID(ctx context.Context, client *http.Client, id string) (*Pre
 	}
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("getting asset type by id: status %d: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp, body, fmt.Sprintf("getting asset type by id: status %d: %s", resp.StatusCode, string(body)))
 	}
 
 	var result PrepareCreateAssetType
@@ -453,7 +491,7 @@ func SearchAssetTypesByName(ctx context.Context, client *http.Client, name strin
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, 0, fmt.Errorf("searching asset types by name: status %d: %s", resp.StatusCode, string(body))
+		return nil, 0, newAPIError(resp, body, fmt.Sprintf("searching asset types by name: status %d: %s", resp.StatusCode, string(body)))
 	}
 
 	var result PrepareCreateAssetTypeListResponse
@@ -488,7 +526,7 @@ func SearchDomainsByName(ctx context.Context, client *http.Client, name string,
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, 0, fmt.Errorf("searching domains by name: status %d: %s", resp.StatusCode, string(body))
+		return nil, 0, newAPIError(resp, body, fmt.Sprintf("searching domains by name: status %d: %s", resp.StatusCode, string(body)))
 	}
 
 	var result PrepareCreateDomainListResponse
@@ -502,6 +540,12 @@ func SearchDomainsByName(ctx context.Context, client *http.Client, name string,
 // Status counts are small (~30) and fit comfortably in a single page;
 // the limit guard is just defensive.
 func ListStatusesAll(ctx context.Context, client *http.Client) ([]PrepareCreateStatus, error) {
+	return cachedLookup(ctx, "statusesAll", func() ([]PrepareCreateStatus, error) {
+		return fetchStatusesAll(ctx, client)
+	})
+}
+
+func fetchStatusesAll(ctx context.Context, client *http.Client) ([]PrepareCreateStatus, error) {
 	reqURL := "/rest/2.0/statuses?limit=500&offset=0"
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
 	if err != nil {
@@ -517,7 +561,7 @@ func ListStatusesAll(ctx context.Context, client *http.Client) ([]PrepareCreateS
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("listing statuses: status %d: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp, body, fmt.Sprintf("listing statuses: status %d: %s", resp.StatusCode, string(body)))
 	}
 
 	var result PrepareCreateStatusListResponse
@@ -602,7 +646,7 @@ func fetchRawAssignments(ctx context.Context, client *http.Client, assetTypeID s
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("getting assignments: status %d: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp, body, fmt.Sprintf("getting assignments: status %d: %s", resp.StatusCode, string(body)))
 	}
 
 	var raws []rawScopedAssignment
@@ -700,7 +744,7 @@ func getJSON(ctx context.Context, client *http.Client, reqURL string, out any) e
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("getting %s: status %d: %s", reqURL, resp.StatusCode, string(body))
+		return newAPIError(resp, body, fmt.Sprintf("getting %s: status %d: %s", reqURL, resp.StatusCode, string(body)))
 	}
 	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
 		return fmt.Errorf("decoding %s response: %w", reqURL, err)
@@ -937,6 +981,12 @@ func NotAllowedMessage(ctx context.Context, client *http.Client, assetTypeID, as
 // shape including stringType — needed for create_asset / edit_asset to
 // gate Markdown→HTML conversion on RICH_TEXT attributes.
 func GetAttributeTypeFull(ctx context.Context, client *http.Client, id string) (*PrepareCreateAttributeTypeFull, error) {
+	return cachedLookup(ctx, "attributeType/"+id, func() (*PrepareCreateAttributeTypeFull, error) {
+		return fetchAttributeTypeFull(ctx, client, id)
+	})
+}
+
+func fetchAttributeTypeFull(ctx context.Context, client *http.Client, id string) (*PrepareCreateAttributeTypeFull, error) {
 	reqURL := fmt.Sprintf("/rest/2.0/attributeTypes/%s", url.PathEscape(id))
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
 	if err != nil {
@@ -952,7 +1002,7 @@ func GetAttributeTypeFull(ctx context.Context, client *http.Client, id string) (
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("getting attribute type details: status %d: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp, body, fmt.Sprintf("getting attribute type details: status %d: %s", resp.StatusCode, string(body)))
 	}
 
 	var result PrepareCreateAttributeTypeFull
@@ -979,6 +1029,12 @@ type PrepareCreateRelationTypeFull struct {
 // from /rest/2.0/relationTypes/{id}. These are not part of the assignment
 // payload, so relation slots are hydrated with them per id.
 func GetRelationTypeFull(ctx context.Context, client *http.Client, id string) (*PrepareCreateRelationTypeFull, error) {
+	return cachedLookup(ctx, "relationType/"+id, func() (*PrepareCreateRelationTypeFull, error) {
+		return fetchRelationTypeFull(ctx, client, id)
+	})
+}
+
+func fetchRelationTypeFull(ctx context.Context, client *http.Client, id string) (*PrepareCreateRelationTypeFull, error) {
 	reqURL := fmt.Sprintf("/rest/2.0/relationTypes/%s", url.PathEscape(id))
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
 	if err != nil {
@@ -994,7 +1050,7 @@ func GetRelationTypeFull(ctx context.Context, client *http.Client, id string) (*
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("getting relation type details: status %d: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp, body, fmt.Sprintf("getting relation type details: status %d: %s", resp.StatusCode, string(body)))
 	}
 
 	var result PrepareCreateRelationTypeFull
@@ -1045,7 +1101,7 @@ func GetComplexRelationTypeFull(ctx context.Context, client *http.Client, id str
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("getting complex relation type details: status %d: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp, body, fmt.Sprintf("getting complex relation type details: status %d: %s", resp.StatusCode, string(body)))
 	}
 
 	var raw struct {
@@ -0,0 +1,180 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// RetryPolicy controls how the shared request executors retry transient
+// failures: transport errors, 429 Too Many Requests and 502/503/504 gateway
+// errors (plus 500, which Collibra returns for transient backend hiccups).
+// Only idempotent requests are retried — GET, HEAD, OPTIONS, PUT and DELETE,
+// or requests whose context was marked with withIdempotent.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	// Values below 2 disable retries.
+	MaxAttempts int
+	// InitialBackoff is the upper bound of the first jittered delay; it
+	// doubles on every further attempt.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between two attempts. A Retry-After asking
+	// for longer than this is not honored: the response is returned as is.
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy is used until SetRetryPolicy is called.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     10 * time.Second,
+}
+
+var retryPolicy atomic.Pointer[RetryPolicy]
+
+func init() {
+	SetRetryPolicy(DefaultRetryPolicy)
+}
+
+// SetRetryPolicy replaces the retry policy used by all clients.
+func SetRetryPolicy(policy RetryPolicy) {
+	retryPolicy.Store(&policy)
+}
+
+type idempotentKey struct{}
+
+// withIdempotent marks requests built from ctx as safe to retry even though
+// their method is not idempotent, e.g. read-only POST searches and GraphQL
+// queries.
+func withIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentKey{}, true)
+}
+
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	marked, _ := req.Context().Value(idempotentKey{}).(bool)
+	return marked
+}
+
+func isRetryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// doWithRetry sends req with client.Do, retrying transient failures of
+// idempotent requests according to the current RetryPolicy. The body of a
+// retried request is replayed through req.GetBody, which http.NewRequest sets
+// for the in-memory readers used throughout this package.
+func doWithRetry(client *http.Client, req *http.Request) (*http.Response, error) {
+	policy := *retryPolicy.Load()
+	if !isIdempotent(req) || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+		policy.MaxAttempts = 1
+	}
+
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("failed to rewind request body: %w", err)
+			}
+			req.Body = body
+		}
+
+		response, err := client.Do(req)
+		if attempt >= policy.MaxAttempts || !shouldRetry(ctx, response, err) {
+			return response, err
+		}
+
+		delay := policy.backoff(attempt)
+		if response != nil {
+			if after, ok := retryAfter(response.Header.Get("Retry-After")); ok {
+				if after > policy.MaxBackoff {
+					return response, nil
+				}
+				delay = after
+			}
+		}
+
+		attrs := []any{
+			slog.Int("attempt", attempt),
+			slog.Int("max_attempts", policy.MaxAttempts),
+			slog.String("method", req.Method),
+			slog.String("path", req.URL.Path),
+			slog.Duration("delay", delay),
+		}
+		if err != nil {
+			attrs = append(attrs, slog.String("error", err.Error()))
+		} else {
+			attrs = append(attrs, slog.Int("status", response.StatusCode))
+			_, _ = io.Copy(io.Discard, response.Body)
+			_ = response.Body.Close()
+		}
+		slog.WarnContext(ctx, "Retrying Collibra request", attrs...)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			if err == nil {
+				err = ctx.Err()
+			}
+			return nil, err
+		case <-timer.C:
+		}
+	}
+}
+
+func shouldRetry(ctx context.Context, response *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	return isRetryableStatus(response.StatusCode)
+}
+
+// backoff returns a delay drawn uniformly from [0, InitialBackoff*2^(attempt-1)],
+// capped at MaxBackoff ("full jitter"), so concurrent tool calls hitting the
+// same outage do not retry in lockstep.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	ceiling := p.InitialBackoff
+	for i := 1; i < attempt && ceiling < p.MaxBackoff; i++ {
+		ceiling *= 2
+	}
+	ceiling = min(ceiling, p.MaxBackoff)
+	if ceiling <= 0 {
+		return 0
+	}
+	return rand.N(ceiling + 1)
+}
+
+// retryAfter parses a Retry-After header given either in seconds or as an
+// HTTP date.
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
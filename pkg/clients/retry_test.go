@@ -0,0 +1,145 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func useRetryPolicy(t *testing.T, policy RetryPolicy) {
+	t.Helper()
+	SetRetryPolicy(policy)
+	t.Cleanup(func() { SetRetryPolicy(DefaultRetryPolicy) })
+}
+
+// flakyServer fails the first `failures` requests with status and then
+// echoes the request body back.
+func flakyServer(t *testing.T, failures int32, status int, header http.Header) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failures {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(status)
+			return
+		}
+		_, _ = io.Copy(w, r.Body)
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestExecuteRequest_RetriesTransientFailuresOfIdempotentRequests(t *testing.T) {
+	useRetryPolicy(t, RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond})
+	server, calls := flakyServer(t, 2, http.StatusBadGateway, nil)
+
+	req, _ := http.NewRequest(http.MethodPut, server.URL, bytes.NewBufferString("payload"))
+	body, err := executeRequest(server.Client(), req)
+	if err != nil {
+		t.Fatalf("expected the third attempt to succeed: %v", err)
+	}
+	if string(body) != "payload" {
+		t.Errorf("expected the body to be replayed on retry, got %q", body)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls.Load())
+	}
+}
+
+func TestExecuteRequest_GivesUpAfterMaxAttempts(t *testing.T) {
+	useRetryPolicy(t, RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond})
+	server, calls := flakyServer(t, 5, http.StatusServiceUnavailable, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	_, status, err := executeRequestWithStatus(server.Client(), req)
+	if err == nil || status != http.StatusServiceUnavailable {
+		t.Fatalf("expected the last 503 to surface, got status %d, err %v", status, err)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("expected 2 attempts, got %d", calls.Load())
+	}
+}
+
+func TestExecuteRequest_DoesNotRetryNonIdempotentOrPermanentFailures(t *testing.T) {
+	useRetryPolicy(t, RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond})
+
+	tests := []struct {
+		name   string
+		method string
+		ctx    context.Context
+		status int
+		want   int32
+	}{
+		{"plain POST", http.MethodPost, context.Background(), http.StatusBadGateway, 1},
+		{"POST marked idempotent", http.MethodPost, withIdempotent(context.Background()), http.StatusBadGateway, 3},
+		{"client error", http.MethodGet, context.Background(), http.StatusNotFound, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, calls := flakyServer(t, 5, tt.status, nil)
+			req, _ := http.NewRequestWithContext(tt.ctx, tt.method, server.URL, strings.NewReader("{}"))
+			_, _ = executeCollibraRequest(server.Client(), req)
+			if calls.Load() != tt.want {
+				t.Errorf("expected %d attempts, got %d", tt.want, calls.Load())
+			}
+		})
+	}
+}
+
+func TestExecuteRequest_HonorsRetryAfter(t *testing.T) {
+	useRetryPolicy(t, RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Second})
+	server, calls := flakyServer(t, 1, http.StatusTooManyRequests, http.Header{"Retry-After": {"1"}})
+
+	start := time.Now()
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	if _, err := executeRequest(server.Client(), req); err != nil {
+		t.Fatalf("expected the retry to succeed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("expected the retry to wait for Retry-After, only waited %s", elapsed)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("expected 2 attempts, got %d", calls.Load())
+	}
+}
+
+func TestExecuteRequest_DoesNotWaitForRetryAfterBeyondMaxBackoff(t *testing.T) {
+	useRetryPolicy(t, RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond})
+	server, calls := flakyServer(t, 5, http.StatusTooManyRequests, http.Header{"Retry-After": {"120"}})
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	_, status, _ := executeRequestWithStatus(server.Client(), req)
+	if status != http.StatusTooManyRequests || calls.Load() != 1 {
+		t.Errorf("expected an immediate 429, got status %d after %d attempts", status, calls.Load())
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	if d, ok := retryAfter("3"); !ok || d != 3*time.Second {
+		t.Errorf("seconds: got %s, %v", d, ok)
+	}
+	date := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	if d, ok := retryAfter(date); !ok || d <= 58*time.Second || d > time.Minute {
+		t.Errorf("HTTP date: got %s, %v", d, ok)
+	}
+	if _, ok := retryAfter("soon"); ok {
+		t.Error("expected an invalid value to be ignored")
+	}
+}
+
+func TestRetryPolicyBackoffIsCapped(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 10, InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	for attempt := 1; attempt <= 10; attempt++ {
+		if d := policy.backoff(attempt); d < 0 || d > time.Second {
+			t.Errorf("attempt %d: backoff %s outside [0, 1s]", attempt, d)
+		}
+	}
+}
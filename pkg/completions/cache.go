@@ -0,0 +1,99 @@
+package completions
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/collibra/chip/pkg/chip"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// cacheTTL is how long looked-up names are reused. Clients ask for
+	// completions on every keystroke, so even a short TTL saves most lookups.
+	cacheTTL = time.Minute
+	// maxCacheEntries bounds the cache; it is emptied when full.
+	maxCacheEntries = 1000
+)
+
+type cacheKey struct {
+	instance string
+	identity string
+	lookup   string
+	query    string
+}
+
+type cacheEntry struct {
+	names    []string
+	complete bool
+	expires  time.Time
+}
+
+// cache keeps the names found by a lookup for a query. Names are
+// scoped to the Collibra instance and the caller's credentials, since what
+// Collibra returns depends on both.
+type cache struct {
+	mu      sync.Mutex
+	entries map[cacheKey]cacheEntry
+	now     func() time.Time
+}
+
+var lookups = newCache()
+
+func newCache() *cache {
+	return &cache{entries: make(map[cacheKey]cacheEntry), now: time.Now}
+}
+
+// get returns the names cached for query, or for a shorter query whose
+// cached names are complete, since those include every name matching query.
+// On a miss it calls fetch. Errors are never cached.
+func (c *cache) get(ctx context.Context, extra *mcp.RequestExtra, name, query string, fetch func(query string) ([]string, bool, error)) ([]string, error) {
+	instance, _ := chip.GetCollibraInstance(ctx)
+	key := cacheKey{instance: instance, identity: chip.CallerIdentity(ctx, extra), lookup: name, query: strings.ToLower(strings.TrimSpace(query))}
+	if names, ok := c.lookup(key); ok {
+		return names, nil
+	}
+	names, complete, err := fetch(key.query)
+	if err != nil {
+		return nil, err
+	}
+	c.put(key, cacheEntry{names: names, complete: complete, expires: c.now().Add(cacheTTL)})
+	return names, nil
+}
+
+func (c *cache) lookup(key cacheKey) ([]string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	for prefix := key; ; {
+		entry, ok := c.entries[prefix]
+		if ok && now.Before(entry.expires) && (prefix.query == key.query || entry.complete) {
+			return entry.names, true
+		}
+		if prefix.query == "" {
+			return nil, false
+		}
+		_, size := utf8.DecodeLastRuneInString(prefix.query)
+		prefix.query = prefix.query[:len(prefix.query)-size]
+	}
+}
+
+func (c *cache) put(key cacheKey, entry cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= maxCacheEntries {
+		now := c.now()
+		for k, e := range c.entries {
+			if !now.Before(e.expires) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= maxCacheEntries {
+			clear(c.entries)
+		}
+	}
+	c.entries[key] = entry
+}
@@ -0,0 +1,198 @@
+// Package completions completes the arguments of the prompts that name
+// catalog items, such as the asset to trace lineage for or the table to
+// package as a data product, so that users of clients supporting
+// completion/complete pick existing names instead of guessing them. The
+// candidates come from the Collibra lookup endpoints of the selected instance
+// and are cached briefly, per instance and caller credentials.
+package completions
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/collibra/chip/pkg/chip"
+	"github.com/collibra/chip/pkg/clients"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// searchLimit bounds the names a search lookup fetches for a value.
+const searchLimit = 100
+
+// completion looks up the names an argument of a prompt is completed with,
+// and the tool whose data it exposes. The argument is only completed while
+// that tool is registered, so completions never reach data the configured
+// tools do not.
+type completion struct {
+	prompt   string
+	argument string
+	tool     string
+	// lookup names the lookup in the cache; arguments completed with the
+	// same names share it.
+	lookup string
+	// search is set when find filters by the value typed so far. Otherwise
+	// find lists every name, whatever the value.
+	search bool
+	// find returns the names matching value, and whether they are all the
+	// names matching it, so that the cached names can complete longer values.
+	find func(ctx context.Context, client *http.Client, value string) (names []string, complete bool, err error)
+}
+
+// completions lists the prompt arguments chip completes.
+var completions = []*completion{
+	{prompt: "collibra/lineage", argument: "asset", tool: "search_asset_keyword", lookup: "asset", search: true, find: assetNames()},
+	{prompt: "collibra/lineage", argument: "direction", tool: "get_lineage_upstream", lookup: "direction", find: lineageDirections},
+	{prompt: "collibra/data-product-create", argument: "table", tool: "search_asset_keyword", lookup: "table", search: true, find: assetNames("Table")},
+	{prompt: "collibra/asset-create", argument: "assetType", tool: "list_asset_types", lookup: "assetType", search: true, find: assetTypes},
+	{prompt: "collibra/asset-create", argument: "domain", tool: "search_asset_keyword", lookup: "domain", search: true, find: domains},
+	{prompt: "collibra/asset-create", argument: "status", tool: "prepare_create_asset", lookup: "status", find: statuses},
+	{prompt: "collibra/asset-edit", argument: "asset", tool: "search_asset_keyword", lookup: "asset", search: true, find: assetNames()},
+	{prompt: "collibra/asset-edit", argument: "role", tool: "edit_asset", lookup: "role", find: roles},
+	{prompt: "collibra/discovery", argument: "community", tool: "search_asset_keyword", lookup: "community", search: true, find: communities},
+	{prompt: "collibra/dq-rules", argument: "jobName", tool: "find_data_quality_rules", lookup: "jobName", search: true, find: dqJobNames},
+	{prompt: "collibra/dq-rule-workbench", argument: "jobName", tool: "find_data_quality_rules", lookup: "jobName", search: true, find: dqJobNames},
+	{prompt: "collibra/dq-rule-workbench", argument: "ruleTemplateName", tool: "list_data_quality_rule_templates", lookup: "ruleTemplateName", find: dqRuleTemplates},
+}
+
+// RegisterAll completes the arguments whose tool is registered on server and
+// stops completing the others, so it can be called again when the registered
+// tools change.
+func RegisterAll(server *chip.Server, client *http.Client) {
+	for _, c := range completions {
+		if !server.HasTool(c.tool) {
+			server.RemoveCompletions(c.prompt, c.argument)
+			continue
+		}
+		slog.Info(fmt.Sprintf("Registering completion: %s %s", c.prompt, c.argument))
+		server.AddCompletion(c.prompt, c.argument, c.complete(server, client))
+	}
+}
+
+// complete looks the names up as a call of the tool of the completion, so
+// that the lookup goes to the Collibra instance selected by the _meta of the
+// request or its header, and requires the permissions of the tool.
+func (c *completion) complete(server *chip.Server, client *http.Client) chip.CompleteFunc {
+	return func(ctx context.Context, req *mcp.CompleteRequest) ([]string, error) {
+		query := ""
+		if c.search {
+			query = req.Params.Argument.Value
+		}
+		// The Collibra client expects a tool call in the context; it forwards
+		// the credentials of the completion request.
+		toolRequest := &mcp.CallToolRequest{
+			Session: req.Session,
+			Params:  &mcp.CallToolParamsRaw{Meta: req.Params.Meta, Name: "completion/complete"},
+			Extra:   req.Extra,
+		}
+		var names []string
+		_, err := server.RunAsTool(ctx, c.tool, toolRequest, func(ctx context.Context, _ *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			var err error
+			names, err = lookups.get(ctx, req.Extra, c.lookup, query, func(value string) ([]string, bool, error) {
+				return c.find(ctx, client, value)
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &mcp.CallToolResult{}, nil
+		})
+		return names, err
+	}
+}
+
+// assetNames returns a lookup of the names of the assets of the types with
+// the given public IDs, or of any type.
+func assetNames(typePublicIDs ...string) func(context.Context, *http.Client, string) ([]string, bool, error) {
+	return func(ctx context.Context, client *http.Client, value string) ([]string, bool, error) {
+		found, total, err := clients.SearchAssetsByName(ctx, client, value, typePublicIDs, searchLimit)
+		if err != nil {
+			return nil, false, err
+		}
+		names := make([]string, len(found))
+		for i, a := range found {
+			names[i] = a.Name
+		}
+		return names, total <= len(found), nil
+	}
+}
+
+func lineageDirections(context.Context, *http.Client, string) ([]string, bool, error) {
+	return []string{"upstream", "downstream", "both"}, true, nil
+}
+
+func assetTypes(ctx context.Context, client *http.Client, value string) ([]string, bool, error) {
+	types, total, err := clients.SearchAssetTypesByName(ctx, client, value, searchLimit)
+	if err != nil {
+		return nil, false, err
+	}
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = t.Name
+	}
+	return names, total <= len(types), nil
+}
+
+func domains(ctx context.Context, client *http.Client, value string) ([]string, bool, error) {
+	found, total, err := clients.SearchDomainsByName(ctx, client, value, searchLimit)
+	if err != nil {
+		return nil, false, err
+	}
+	names := make([]string, len(found))
+	for i, d := range found {
+		names[i] = d.Name
+	}
+	return names, total <= len(found), nil
+}
+
+func statuses(ctx context.Context, client *http.Client, _ string) ([]string, bool, error) {
+	found, err := clients.ListStatusesAll(ctx, client)
+	if err != nil {
+		return nil, false, err
+	}
+	names := make([]string, len(found))
+	for i, s := range found {
+		names[i] = s.Name
+	}
+	return names, true, nil
+}
+
+func communities(ctx context.Context, client *http.Client, value string) ([]string, bool, error) {
+	found, err := clients.SearchCommunitiesByName(ctx, client, value, searchLimit)
+	if err != nil {
+		return nil, false, err
+	}
+	names := make([]string, len(found))
+	for i, c := range found {
+		names[i] = c.Name
+	}
+	return names, len(found) < searchLimit, nil
+}
+
+func roles(ctx context.Context, client *http.Client, _ string) ([]string, bool, error) {
+	found, err := clients.ListRoles(ctx, client)
+	if err != nil {
+		return nil, false, err
+	}
+	names := make([]string, len(found))
+	for i, r := range found {
+		names[i] = r.Name
+	}
+	return names, true, nil
+}
+
+func dqJobNames(ctx context.Context, client *http.Client, value string) ([]string, bool, error) {
+	names, err := clients.SearchDqJobNames(ctx, client, value)
+	return names, err == nil, err
+}
+
+func dqRuleTemplates(ctx context.Context, client *http.Client, _ string) ([]string, bool, error) {
+	list, err := clients.ListDQRuleTemplates(ctx, client, clients.ListDQRuleTemplatesParams{Limit: 1000})
+	if err != nil {
+		return nil, false, err
+	}
+	names := make([]string, len(list.Results))
+	for i, t := range list.Results {
+		names[i] = t.Name
+	}
+	return names, list.Total <= int64(len(list.Results)), nil
+}
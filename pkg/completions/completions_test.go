@@ -0,0 +1,199 @@
+package completions_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	"github.com/collibra/chip/pkg/chip"
+	"github.com/collibra/chip/pkg/fakecollibra"
+	"github.com/collibra/chip/pkg/tools"
+	"github.com/collibra/chip/pkg/tools/testutil"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// connect serves chip against a fake Collibra seeded from the default
+// fixture, with the given tool middlewares, and returns a client session and
+// the number of requests the fake received.
+func connect(t *testing.T, toolConfig *chip.ServerToolConfig, middlewares ...chip.ToolMiddleware) (*mcp.ClientSession, *atomic.Int32) {
+	t.Helper()
+	fixture, err := fakecollibra.ParseFixture(fakecollibra.DefaultFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake, err := fakecollibra.New(fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	requests := &atomic.Int32{}
+	collibra := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		fake.ServeHTTP(w, r)
+	}))
+	t.Cleanup(collibra.Close)
+
+	var opts []chip.ServerOption
+	for _, middleware := range middlewares {
+		opts = append(opts, chip.WithToolMiddleware(middleware))
+	}
+	server := chip.NewServer(opts...)
+	if err := tools.RegisterAll(server, testutil.NewClient(collibra), toolConfig); err != nil {
+		t.Fatal(err)
+	}
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	if _, err := server.Connect(t.Context(), serverTransport, nil); err != nil {
+		t.Fatal(err)
+	}
+	session, err := mcp.NewClient(&mcp.Implementation{Name: "test"}, nil).Connect(t.Context(), clientTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = session.Close() })
+	return session, requests
+}
+
+func complete(t *testing.T, session *mcp.ClientSession, prompt, argument, value string) []string {
+	t.Helper()
+	res, err := session.Complete(t.Context(), &mcp.CompleteParams{
+		Ref:      &mcp.CompleteReference{Type: "ref/prompt", Name: prompt},
+		Argument: mcp.CompleteParamsArgument{Name: argument, Value: value},
+	})
+	if err != nil {
+		t.Fatalf("%s %s=%q: %v", prompt, argument, value, err)
+	}
+	return res.Completion.Values
+}
+
+func TestComplete(t *testing.T) {
+	session, _ := connect(t, &chip.ServerToolConfig{Experimental: []string{"data-quality", "skills"}})
+
+	// The completed arguments are those of the shipped prompts.
+	arguments := map[string][]string{}
+	for prompt, err := range session.Prompts(t.Context(), nil) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, argument := range prompt.Arguments {
+			arguments[prompt.Name] = append(arguments[prompt.Name], argument.Name)
+		}
+	}
+
+	tests := []struct {
+		prompt   string
+		argument string
+		value    string
+		want     []string
+	}{
+		{"collibra/lineage", "asset", "or", []string{"orders", "orders.amount", "orders.customer_id"}},
+		{"collibra/lineage", "asset", "REV", []string{"Revenue"}},
+		{"collibra/lineage", "direction", "up", []string{"upstream"}},
+		{"collibra/data-product-create", "table", "", []string{"customers", "orders"}},
+		{"collibra/data-product-create", "table", "ord", []string{"orders"}},
+		{"collibra/asset-create", "assetType", "", []string{"Business Term", "Column", "Table"}},
+		{"collibra/asset-create", "assetType", "t", []string{"Table"}},
+		{"collibra/asset-create", "domain", "sales", []string{"Sales Warehouse"}},
+		{"collibra/asset-create", "status", "", []string{"Accepted", "Candidate", "Obsolete"}},
+		{"collibra/asset-create", "status", "ac", []string{"Accepted"}},
+		{"collibra/asset-edit", "asset", "cust", []string{"Customer", "customers"}},
+		{"collibra/asset-edit", "role", "st", []string{"Steward"}},
+		{"collibra/discovery", "community", "f", []string{"Finance"}},
+		{"collibra/dq-rules", "jobName", "SALES", []string{"SALES_ORDERS"}},
+		{"collibra/dq-rule-workbench", "jobName", "sal", []string{"SALES_ORDERS"}},
+		{"collibra/dq-rule-workbench", "ruleTemplateName", "", []string{"non_negative", "not_null"}},
+		{"collibra/dq-rule-workbench", "ruleTemplateName", "not", []string{"not_null"}},
+	}
+	for _, tt := range tests {
+		if !slices.Contains(arguments[tt.prompt], tt.argument) {
+			t.Errorf("prompt %s has no argument %s", tt.prompt, tt.argument)
+		}
+		if got := complete(t, session, tt.prompt, tt.argument, tt.value); !slices.Equal(got, tt.want) {
+			t.Errorf("%s %s=%q: expected %v, got %v", tt.prompt, tt.argument, tt.value, tt.want, got)
+		}
+	}
+	for _, prompt := range []string{"collibra/lineage", "collibra/discovery"} {
+		if got := complete(t, session, prompt, "assetType", ""); len(got) != 0 {
+			t.Errorf("%s: expected no completion of an argument it does not have, got %v", prompt, got)
+		}
+	}
+}
+
+func TestComplete_ReusesTheNamesOfAShorterValue(t *testing.T) {
+	session, requests := connect(t, &chip.ServerToolConfig{})
+
+	if got := complete(t, session, "collibra/asset-create", "domain", "f"); !slices.Equal(got, []string{"Finance Glossary"}) {
+		t.Fatalf("expected Finance Glossary, got %v", got)
+	}
+	before := requests.Load()
+	if got := complete(t, session, "collibra/asset-create", "domain", "Fin"); !slices.Equal(got, []string{"Finance Glossary"}) {
+		t.Fatalf("expected Finance Glossary, got %v", got)
+	}
+	if got := complete(t, session, "collibra/asset-create", "domain", "fx"); len(got) != 0 {
+		t.Fatalf("expected no domain, got %v", got)
+	}
+	if after := requests.Load(); after != before {
+		t.Errorf("expected the names looked up for f to be reused, got %d more requests", after-before)
+	}
+}
+
+func TestComplete_GoesThroughTheToolMiddlewares(t *testing.T) {
+	var tools []string
+	session, requests := connect(t, &chip.ServerToolConfig{}, chip.ToolMiddlewareFunc(func(ctx context.Context, toolRequest *mcp.CallToolRequest, next chip.CallToolFunc) (*mcp.CallToolResult, error) {
+		if toolRequest.Params.Name != "completion/complete" {
+			return next(ctx, toolRequest)
+		}
+		metadata, _ := chip.GetToolMetadata(ctx)
+		tools = append(tools, metadata.Name)
+		instance, _ := toolRequest.Params.GetMeta()["collibra.com/instance"].(string)
+		if instance == "unknown" {
+			return nil, errors.New("unknown Collibra instance")
+		}
+		return next(chip.SetCollibraInstance(ctx, instance), toolRequest)
+	}))
+	completeOn := func(instance string) ([]string, error) {
+		res, err := session.Complete(t.Context(), &mcp.CompleteParams{
+			Meta:     mcp.Meta{"collibra.com/instance": instance},
+			Ref:      &mcp.CompleteReference{Type: "ref/prompt", Name: "collibra/asset-create"},
+			Argument: mcp.CompleteParamsArgument{Name: "domain", Value: "sal"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		return res.Completion.Values, nil
+	}
+
+	for _, instance := range []string{"qa", "qa", "prod"} {
+		if got, err := completeOn(instance); err != nil || !slices.Equal(got, []string{"Sales Warehouse"}) {
+			t.Fatalf("%s: expected Sales Warehouse, got %v %v", instance, got, err)
+		}
+		if instance == "qa" && requests.Load() != 1 {
+			t.Fatalf("expected one lookup for qa, got %d", requests.Load())
+		}
+	}
+	if requests.Load() != 2 {
+		t.Errorf("expected the names of qa not to be served for prod, got %d lookups", requests.Load())
+	}
+	if got, err := completeOn("unknown"); err != nil || len(got) != 0 {
+		t.Errorf("expected no completion when the middleware fails, got %v %v", got, err)
+	}
+	if !slices.Equal(tools, []string{"search_asset_keyword", "search_asset_keyword", "search_asset_keyword", "search_asset_keyword"}) {
+		t.Errorf("expected every completion to run as search_asset_keyword, got %v", tools)
+	}
+}
+
+func TestRegisterAll_FollowsTheRegisteredTools(t *testing.T) {
+	session, requests := connect(t, &chip.ServerToolConfig{DisabledTools: []string{"list_asset_types", "search_asset_keyword"}})
+
+	if got := complete(t, session, "collibra/asset-create", "assetType", ""); len(got) != 0 {
+		t.Errorf("expected no completion with list_asset_types disabled, got %v", got)
+	}
+	if got := complete(t, session, "collibra/lineage", "asset", ""); len(got) != 0 {
+		t.Errorf("expected no completion with search_asset_keyword disabled, got %v", got)
+	}
+	if n := requests.Load(); n != 0 {
+		t.Errorf("expected no request to Collibra, got %d", n)
+	}
+}
@@ -0,0 +1,206 @@
+// Package dryrun rehearses tool calls without changing the catalog. Its
+// transport answers the requests that could change the catalog itself, with
+// a synthetic success, instead of sending them to Collibra, and its tool
+// middleware reports those requests in the tool result so the agent knows
+// nothing was persisted.
+package dryrun
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/collibra/chip/pkg/chip"
+	"github.com/collibra/chip/pkg/readonly"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// MetaKey is the _meta key of the tool results of a dry run.
+const MetaKey = "collibra.com/dryRun"
+
+// PlaceholderID is the id of the resources a dry run pretends to create, so
+// tools that go on with the created resource keep working.
+const PlaceholderID = "00000000-0000-0000-0000-000000000000"
+
+// Request is a request the dry run did not send.
+type Request struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Body   any    `json:"body,omitempty"`
+}
+
+type recorderKey struct{}
+
+type recorder struct {
+	mu       sync.Mutex
+	requests []Request
+}
+
+func (r *recorder) add(request Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, request)
+}
+
+func (r *recorder) recorded() []Request {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.requests
+}
+
+// Transport wraps an upstream RoundTripper. Requests that only read are sent
+// as usual; the others are recorded for the tool call they belong to and
+// answered with 201 Created for REST creations, 204 No Content for
+// deletions and 200 OK otherwise. The response body echoes the request body,
+// with PlaceholderID as the id of the objects that have none.
+func Transport(next http.RoundTripper) http.RoundTripper {
+	return &transport{next: next}
+}
+
+type transport struct {
+	next http.RoundTripper
+}
+
+func (t *transport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if readonly.Check(request) == nil {
+		return t.next.RoundTrip(request)
+	}
+	var body []byte
+	if request.Body != nil {
+		var err error
+		body, err = io.ReadAll(request.Body)
+		_ = request.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	path := request.URL.Path
+	if request.URL.RawQuery != "" {
+		path += "?" + request.URL.RawQuery
+	}
+	intercepted := Request{Method: request.Method, Path: path}
+	if len(body) > 0 {
+		if json.Valid(body) {
+			intercepted.Body = json.RawMessage(body)
+		} else {
+			intercepted.Body = string(body)
+		}
+	}
+	if r, ok := request.Context().Value(recorderKey{}).(*recorder); ok {
+		r.add(intercepted)
+	}
+	slog.InfoContext(request.Context(), fmt.Sprintf("Dry run, not sending %s %s", request.Method, path))
+	return syntheticResponse(request, body), nil
+}
+
+func syntheticResponse(request *http.Request, body []byte) *http.Response {
+	status := http.StatusOK
+	switch {
+	case request.Method == http.MethodDelete:
+		status = http.StatusNoContent
+	case request.Method == http.MethodPost && strings.HasPrefix(request.URL.Path, "/rest/2.0/"):
+		status = http.StatusCreated
+	}
+	var responseBody []byte
+	if status != http.StatusNoContent {
+		responseBody = echo(body)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(responseBody)),
+		ContentLength: int64(len(responseBody)),
+		Request:       request,
+	}
+}
+
+// echo returns the JSON request body with PlaceholderID as the id of the
+// objects without one, or an empty object when the body is not JSON.
+func echo(body []byte) []byte {
+	var value any
+	if json.Unmarshal(body, &value) != nil {
+		return []byte("{}")
+	}
+	withID := func(v any) {
+		if object, ok := v.(map[string]any); ok {
+			if _, hasID := object["id"]; !hasID {
+				object["id"] = PlaceholderID
+			}
+		}
+	}
+	if array, ok := value.([]any); ok {
+		for _, item := range array {
+			withID(item)
+		}
+	} else {
+		withID(value)
+	}
+	echoed, err := json.Marshal(value)
+	if err != nil {
+		return []byte("{}")
+	}
+	return echoed
+}
+
+// ToolMiddleware collects the requests the transport did not send during a
+// call. When there are any, or the tool is not read-only, the result says
+// nothing was persisted: its _meta holds the requests under MetaKey and a
+// text block lists them for the agent.
+func ToolMiddleware() chip.ToolMiddleware {
+	return chip.ToolMiddlewareFunc(func(ctx context.Context, toolRequest *mcp.CallToolRequest, next chip.CallToolFunc) (*mcp.CallToolResult, error) {
+		r := &recorder{}
+		res, err := next(context.WithValue(ctx, recorderKey{}, r), toolRequest)
+		requests := r.recorded()
+		metadata, _ := chip.GetToolMetadata(ctx)
+		if len(requests) == 0 && (metadata == nil || metadata.ReadOnly()) {
+			return res, err
+		}
+		if err != nil {
+			return res, fmt.Errorf("dry run, nothing was persisted (%d requests not sent): %w", len(requests), err)
+		}
+		if res == nil {
+			res = &mcp.CallToolResult{}
+		}
+		if res.Meta == nil {
+			res.Meta = mcp.Meta{}
+		}
+		res.Meta[MetaKey] = map[string]any{"persisted": false, "requests": requests}
+		return tag(res, requests), nil
+	})
+}
+
+// tag adds the dry-run notice to the content of the result. The SDK only
+// derives the text content from the structured output when there is none,
+// so the output is encoded here first.
+func tag(res *mcp.CallToolResult, requests []Request) *mcp.CallToolResult {
+	if res.Content == nil && res.StructuredContent != nil {
+		if output, err := json.Marshal(res.StructuredContent); err == nil {
+			res.Content = []mcp.Content{&mcp.TextContent{Text: string(output)}}
+		}
+	}
+	var notice strings.Builder
+	notice.WriteString("DRY RUN: nothing was persisted in Collibra.")
+	if len(requests) > 0 {
+		notice.WriteString(" These requests would have been sent:")
+		for _, request := range requests {
+			fmt.Fprintf(&notice, "\n%s %s", request.Method, request.Path)
+			if request.Body != nil {
+				if body, err := json.Marshal(request.Body); err == nil {
+					fmt.Fprintf(&notice, " %s", body)
+				}
+			}
+		}
+	}
+	res.Content = append(res.Content, &mcp.TextContent{Text: notice.String()})
+	return res
+}
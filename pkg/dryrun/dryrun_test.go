@@ -0,0 +1,135 @@
+package dryrun
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/collibra/chip/pkg/chip"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+type createInput struct {
+	Name string `json:"name"`
+}
+
+type createOutput struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// createTool reads an asset type and creates an asset with it, the way
+// create_asset does.
+func createTool(client *http.Client) *chip.Tool[createInput, createOutput] {
+	return &chip.Tool[createInput, createOutput]{
+		Name:        "create_asset",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: false},
+		Handler: func(ctx context.Context, input createInput) (createOutput, error) {
+			request, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/rest/2.0/assetTypes/1", nil)
+			response, err := client.Do(request)
+			if err != nil {
+				return createOutput{}, err
+			}
+			assetType, _ := io.ReadAll(response.Body)
+			_ = response.Body.Close()
+
+			body, _ := json.Marshal(map[string]string{"name": input.Name, "typeId": "1"})
+			request, _ = http.NewRequestWithContext(ctx, http.MethodPost, "/rest/2.0/assets", bytes.NewReader(body))
+			response, err = client.Do(request)
+			if err != nil {
+				return createOutput{}, err
+			}
+			defer func() { _ = response.Body.Close() }()
+			if response.StatusCode != http.StatusCreated {
+				return createOutput{}, fmt.Errorf("unexpected status %d", response.StatusCode)
+			}
+			var created createOutput
+			err = json.NewDecoder(response.Body).Decode(&created)
+			created.Type = string(assetType)
+			return created, err
+		},
+	}
+}
+
+func TestDryRun_FakesWritesAndTagsTheResult(t *testing.T) {
+	upstream := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		if r.Method != http.MethodGet {
+			t.Errorf("expected %s %s not to reach Collibra", r.Method, r.URL.Path)
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("Table"))}, nil
+	})
+	client := &http.Client{Transport: Transport(upstream)}
+	server := chip.NewServer(chip.WithToolMiddleware(ToolMiddleware()))
+	chip.RegisterTool(server, createTool(client))
+
+	t1, t2 := mcp.NewInMemoryTransports()
+	if _, err := server.Connect(t.Context(), t1, nil); err != nil {
+		t.Fatal(err)
+	}
+	session, err := mcp.NewClient(&mcp.Implementation{Name: "client", Version: "v0.0.1"}, nil).Connect(t.Context(), t2, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = session.Close() }()
+
+	res, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: "create_asset", Arguments: map[string]any{"name": "orders"}})
+	if err != nil || res.IsError {
+		t.Fatalf("unexpected failure: %v %+v", err, res)
+	}
+	if output := res.StructuredContent.(map[string]any); output["id"] != PlaceholderID || output["type"] != "Table" {
+		t.Errorf("expected the tool to see a synthetic creation, got %v", output)
+	}
+	dryRun, ok := res.Meta[MetaKey].(map[string]any)
+	if !ok || dryRun["persisted"] != false {
+		t.Fatalf("expected the result to be tagged, got %v", res.Meta)
+	}
+	requests := dryRun["requests"].([]any)
+	if len(requests) != 1 {
+		t.Fatalf("expected one request not to be sent, got %v", requests)
+	}
+	if request := requests[0].(map[string]any); request["method"] != "POST" || request["path"] != "/rest/2.0/assets" || request["body"].(map[string]any)["name"] != "orders" {
+		t.Errorf("unexpected record %v", request)
+	}
+	if len(res.Content) != 2 || !strings.HasPrefix(res.Content[1].(*mcp.TextContent).Text, "DRY RUN: nothing was persisted") {
+		t.Errorf("expected the output and a dry-run notice, got %+v", res.Content)
+	}
+}
+
+func TestTransport_StatusByMethod(t *testing.T) {
+	client := &http.Client{Transport: Transport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		t.Errorf("expected %s %s not to reach Collibra", r.Method, r.URL.Path)
+		return nil, nil
+	}))}
+	tests := []struct {
+		method, path string
+		body         string
+		status       int
+		response     string
+	}{
+		{http.MethodDelete, "/rest/2.0/assets/1", "", http.StatusNoContent, ""},
+		{http.MethodPatch, "/rest/2.0/assets/1", `{"name":"x"}`, http.StatusOK, `{"id":"` + PlaceholderID + `","name":"x"}`},
+		{http.MethodPost, "/rest/catalog/1.0/dataClassification/classificationMatches/bulk", `[{"assetId":"a"}]`, http.StatusOK, `[{"assetId":"a","id":"` + PlaceholderID + `"}]`},
+	}
+	for _, tt := range tests {
+		request, _ := http.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+		response, err := client.Do(request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(response.Body)
+		if response.StatusCode != tt.status || string(body) != tt.response {
+			t.Errorf("%s %s: got %d %s", tt.method, tt.path, response.StatusCode, body)
+		}
+	}
+}
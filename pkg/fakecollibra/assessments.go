@@ -0,0 +1,85 @@
+package fakecollibra
+
+import (
+	"net/http"
+	"strings"
+)
+
+const assessmentsPath = "/rest/assessments/v2"
+
+func (s *Server) registerAssessments() {
+	s.handle("GET "+assessmentsPath+"/assessments", s.listAssessments)
+	s.handle("GET "+assessmentsPath+"/assessments/{id}", s.getAssessment)
+	s.handle("GET "+assessmentsPath+"/templates", s.listAssessmentTemplates)
+}
+
+func (s *Server) assessmentJSON(a *assessment) map[string]any {
+	content := []map[string]any{}
+	for _, q := range a.questions {
+		question := map[string]any{"id": q.ID, "name": q.Name, "description": q.Description}
+		if q.Answer != nil {
+			answerType := q.AnswerType
+			if answerType == "" {
+				answerType = "TEXT"
+			}
+			question["answer"] = map[string]any{"type": answerType, "value": q.Answer}
+		}
+		content = append(content, question)
+	}
+	out := map[string]any{
+		"id":       a.id,
+		"name":     a.name,
+		"status":   a.status,
+		"template": assessmentTemplateJSON(a),
+		"content":  content,
+		"owner":    ref{ID: fakeUserID, Name: fakeUser},
+	}
+	if target := s.store.asset(a.assetID); a.assetID != "" && target != nil {
+		out["asset"] = ref{ID: target.id, Name: target.displayName}
+	}
+	return out
+}
+
+func assessmentTemplateJSON(a *assessment) map[string]any {
+	return map[string]any{"id": a.templateID, "name": a.templateName, "version": 1, "status": "PUBLISHED"}
+}
+
+func (s *Server) listAssessments(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var results []map[string]any
+	for _, a := range s.store.assessments {
+		if !strings.Contains(strings.ToLower(a.name), strings.ToLower(query.Get("name"))) ||
+			(query.Get("status") != "" && !strings.EqualFold(a.status, query.Get("status"))) ||
+			(query.Get("templateId") != "" && a.templateID != query.Get("templateId")) ||
+			(query.Get("assetId") != "" && a.assetID != query.Get("assetId")) {
+			continue
+		}
+		results = append(results, s.assessmentJSON(a))
+	}
+	page, next := cursorPage(r, results, 50)
+	writeJSON(w, http.StatusOK, map[string]any{"results": page, "nextCursor": next})
+}
+
+func (s *Server) getAssessment(w http.ResponseWriter, r *http.Request) {
+	for _, a := range s.store.assessments {
+		if a.id == r.PathValue("id") {
+			writeJSON(w, http.StatusOK, s.assessmentJSON(a))
+			return
+		}
+	}
+	notFound(w, "assessment", r.PathValue("id"))
+}
+
+func (s *Server) listAssessmentTemplates(w http.ResponseWriter, r *http.Request) {
+	seen := map[string]bool{}
+	var results []map[string]any
+	for _, a := range s.store.assessments {
+		if seen[a.templateID] || !strings.Contains(strings.ToLower(a.templateName), strings.ToLower(r.URL.Query().Get("name"))) {
+			continue
+		}
+		seen[a.templateID] = true
+		results = append(results, assessmentTemplateJSON(a))
+	}
+	page, next := cursorPage(r, results, 50)
+	writeJSON(w, http.StatusOK, map[string]any{"results": page, "nextCursor": next})
+}
@@ -0,0 +1,40 @@
+package fakecollibra
+
+import (
+	"net/http"
+)
+
+const dataContractsPath = "/rest/dataProduct/v1/dataContracts"
+
+func (s *Server) registerDataContracts() {
+	s.handle("GET "+dataContractsPath, s.listDataContracts)
+	s.handle("GET "+dataContractsPath+"/{id}/activeVersion/manifest", s.getDataContractManifest)
+}
+
+func (s *Server) listDataContracts(w http.ResponseWriter, r *http.Request) {
+	manifestID := r.URL.Query().Get("manifestId")
+	var items []map[string]any
+	for _, dc := range s.store.dataContracts {
+		if manifestID == "" || dc.ManifestID == manifestID {
+			items = append(items, map[string]any{"id": dc.ID, "domainId": dc.Domain, "manifestId": dc.ManifestID})
+		}
+	}
+	page, next := cursorPage(r, items, 100)
+	writeJSON(w, http.StatusOK, map[string]any{
+		"items":      page,
+		"limit":      queryInt(r, "limit", 100),
+		"nextCursor": next,
+		"total":      len(items),
+	})
+}
+
+func (s *Server) getDataContractManifest(w http.ResponseWriter, r *http.Request) {
+	for _, dc := range s.store.dataContracts {
+		if dc.ID == r.PathValue("id") {
+			w.Header().Set("Content-Type", "application/x-yaml")
+			_, _ = w.Write([]byte(dc.Manifest))
+			return
+		}
+	}
+	notFound(w, "dataContract", r.PathValue("id"))
+}
@@ -0,0 +1,161 @@
+package fakecollibra
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+const dqPath = "/rest/dq/1.0"
+
+func (s *Server) registerDataQuality() {
+	s.handle("GET "+dqPath+"/jobs", s.searchDQJobs)
+	s.handle("GET "+dqPath+"/jobs/{jobName}", s.getDQJob)
+	s.handle("DELETE "+dqPath+"/jobs/{jobName}", s.deleteDQJob)
+	s.handle("GET "+dqPath+"/jobRuns", s.searchDQJobRuns)
+	s.handle("GET "+dqPath+"/jobRuns/{id}", s.getDQJobRun)
+	s.handle("DELETE "+dqPath+"/jobRuns/{id}", s.deleteDQJobRun)
+	s.handle("POST "+dqPath+"/jobRuns/{id}/cancel", s.cancelDQJobRun)
+	s.handle("GET "+dqPath+"/ruleTemplates", s.listDQRuleTemplates)
+}
+
+func dqJobJSON(job *dqJob) map[string]any {
+	return map[string]any{
+		"jobName": job.Name,
+		"jobType": job.JobType,
+		"dataLocation": map[string]any{
+			"edgeSiteName":       "fake-edge",
+			"edgeConnectionName": job.Connection,
+			"dataSourceName":     job.DataSource,
+			"schemaName":         job.Schema,
+			"tableName":          job.Table,
+		},
+		"sourceQuery": job.SourceQuery,
+	}
+}
+
+func dqJobRunJSON(job *dqJob, run *DQJobRun) map[string]any {
+	out := map[string]any{"jobRunId": run.ID, "jobName": job.Name, "status": run.Status}
+	if run.RunDate != "" {
+		out["runDate"] = map[string]any{"kind": "DATE", "value": run.RunDate}
+	}
+	return out
+}
+
+// searchDQJobs matches the jobName query parameter as a case-insensitive
+// substring of the job names.
+func (s *Server) searchDQJobs(w http.ResponseWriter, r *http.Request) {
+	name := strings.ToLower(r.URL.Query().Get("jobName"))
+	var results []map[string]any
+	for _, job := range s.store.dqJobs {
+		if strings.Contains(strings.ToLower(job.Name), name) {
+			results = append(results, dqJobJSON(job))
+		}
+	}
+	writeJSON(w, http.StatusOK, offsetPage(r, results))
+}
+
+func (s *Server) getDQJob(w http.ResponseWriter, r *http.Request) {
+	job := s.store.dqJob(r.PathValue("jobName"))
+	if job == nil {
+		notFound(w, "job", r.PathValue("jobName"))
+		return
+	}
+	writeJSON(w, http.StatusOK, dqJobJSON(job))
+}
+
+func (s *Server) deleteDQJob(w http.ResponseWriter, r *http.Request) {
+	for i, job := range s.store.dqJobs {
+		if job.Name == r.PathValue("jobName") {
+			s.store.dqJobs = slices.Delete(s.store.dqJobs, i, i+1)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	notFound(w, "job", r.PathValue("jobName"))
+}
+
+// dqJobRun returns the run with id and its job.
+func (s *Server) dqJobRun(id string) (*dqJob, *DQJobRun) {
+	for _, job := range s.store.dqJobs {
+		for _, run := range job.runs {
+			if run.ID == id {
+				return job, run
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (s *Server) searchDQJobRuns(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	name := query.Get("jobName")
+	var results []map[string]any
+	for _, job := range s.store.dqJobs {
+		if query.Get("nameMatchMode") == "CONTAINS" {
+			if !strings.Contains(strings.ToLower(job.Name), strings.ToLower(name)) {
+				continue
+			}
+		} else if name != "" && job.Name != name {
+			continue
+		}
+		for _, run := range job.runs {
+			if len(query["status"]) == 0 || slices.Contains(query["status"], run.Status) {
+				results = append(results, dqJobRunJSON(job, run))
+			}
+		}
+	}
+	if results == nil {
+		results = []map[string]any{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"results": results})
+}
+
+func (s *Server) getDQJobRun(w http.ResponseWriter, r *http.Request) {
+	job, run := s.dqJobRun(r.PathValue("id"))
+	if run == nil {
+		notFound(w, "jobRun", r.PathValue("id"))
+		return
+	}
+	writeJSON(w, http.StatusOK, dqJobRunJSON(job, run))
+}
+
+func (s *Server) deleteDQJobRun(w http.ResponseWriter, r *http.Request) {
+	job, run := s.dqJobRun(r.PathValue("id"))
+	if run == nil {
+		notFound(w, "jobRun", r.PathValue("id"))
+		return
+	}
+	job.runs = slices.DeleteFunc(job.runs, func(other *DQJobRun) bool { return other == run })
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) cancelDQJobRun(w http.ResponseWriter, r *http.Request) {
+	_, run := s.dqJobRun(r.PathValue("id"))
+	if run == nil {
+		notFound(w, "jobRun", r.PathValue("id"))
+		return
+	}
+	run.Status = "CANCELLED"
+	w.WriteHeader(http.StatusOK)
+}
+
+// listDQRuleTemplates matches the name query parameter as a case-insensitive
+// substring of the template names.
+func (s *Server) listDQRuleTemplates(w http.ResponseWriter, r *http.Request) {
+	name := strings.ToLower(r.URL.Query().Get("name"))
+	var results []map[string]any
+	for _, template := range s.store.dqRuleTemplates {
+		if strings.Contains(strings.ToLower(template.Name), name) {
+			results = append(results, map[string]any{
+				"id":                template.ID,
+				"ruleTemplateName":  template.Name,
+				"sql":               template.SQL,
+				"dimensions":        template.Dimensions,
+				"isSystem":          template.IsSystem,
+				"deployedRuleCount": 0,
+			})
+		}
+	}
+	writeJSON(w, http.StatusOK, offsetPage(r, results))
+}
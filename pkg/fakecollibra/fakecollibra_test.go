@@ -0,0 +1,207 @@
+package fakecollibra_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/collibra/chip/pkg/chip"
+	"github.com/collibra/chip/pkg/fakecollibra"
+	"github.com/collibra/chip/pkg/tools"
+	"github.com/collibra/chip/pkg/tools/testutil"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// connect serves chip's tools against a fake Collibra seeded from the
+// default fixture and returns a client session to call them.
+func connect(t *testing.T) *mcp.ClientSession {
+	t.Helper()
+	fixture, err := fakecollibra.ParseFixture(fakecollibra.DefaultFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake, err := fakecollibra.New(fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	collibra := httptest.NewServer(fake)
+	t.Cleanup(collibra.Close)
+
+	server := chip.NewServer()
+	toolConfig := &chip.ServerToolConfig{Experimental: []string{tools.DataQualityFeatureName}}
+	if err := tools.RegisterAll(server, testutil.NewClient(collibra), toolConfig); err != nil {
+		t.Fatal(err)
+	}
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	if _, err := server.Connect(t.Context(), serverTransport, nil); err != nil {
+		t.Fatal(err)
+	}
+	session, err := mcp.NewClient(&mcp.Implementation{Name: "test"}, nil).Connect(t.Context(), clientTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = session.Close() })
+	return session
+}
+
+// call calls a tool and returns its structured output as JSON.
+func call(t *testing.T, session *mcp.ClientSession, name string, arguments map[string]any) string {
+	t.Helper()
+	res, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: name, Arguments: arguments})
+	if err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+	if res.IsError {
+		t.Fatalf("%s: %v", name, res.Content[0].(*mcp.TextContent).Text)
+	}
+	output, err := json.Marshal(res.StructuredContent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(output)
+}
+
+// assetID searches the asset called name and returns its id.
+func assetID(t *testing.T, session *mcp.ClientSession, name string) string {
+	t.Helper()
+	var output struct {
+		Results []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"results"`
+	}
+	search := call(t, session, "search_asset_keyword", map[string]any{"query": name, "resourceTypeFilters": []string{"Asset"}})
+	if err := json.Unmarshal([]byte(search), &output); err != nil {
+		t.Fatal(err)
+	}
+	for _, result := range output.Results {
+		if result.Name == name {
+			return result.ID
+		}
+	}
+	t.Fatalf("no asset called %s in %s", name, search)
+	return ""
+}
+
+func TestTools_AgainstTheDefaultFixture(t *testing.T) {
+	session := connect(t)
+	revenue := assetID(t, session, "Revenue")
+	orders := assetID(t, session, "orders")
+
+	tests := []struct {
+		tool      string
+		arguments map[string]any
+		want      []string
+	}{
+		{"search_asset_keyword", map[string]any{"query": "order", "domainFilter": []string{"Sales Warehouse"}}, []string{`"name":"orders"`, `"name":"amount"`}},
+		{"list_asset_types", map[string]any{}, []string{`"name":"Business Term"`, `"name":"Column"`}},
+		{"get_asset_details", map[string]any{"assetId": revenue}, []string{`"displayName":"Revenue"`, `"The income from selling goods`, `"role":"represents"`, `"displayName":"amount"`}},
+		{"get_asset_details", map[string]any{"assetId": orders}, []string{`"numericValue":125000`, `"name":"Accepted"`}},
+		{"get_lineage_entity", map[string]any{"entityId": "orders"}, []string{`"dgcId":"` + orders + `"`}},
+		{"get_lineage_upstream", map[string]any{"entityId": "orders"}, []string{`"sourceEntityId":"raw-orders"`, `"load-orders"`}},
+		{"get_lineage_downstream", map[string]any{"entityId": "orders"}, []string{`"targetEntityId":"revenue-report"`}},
+		{"search_lineage_entities", map[string]any{"nameContains": "report"}, []string{`"name":"revenue_report"`}},
+		{"get_lineage_transformation", map[string]any{"transformationId": "aggregate-revenue"}, []string{`"transformationLogic":"SELECT`}},
+		{"get_assessment", map[string]any{"assetId": orders}, []string{`"name":"Orders privacy review"`, `"value":true`}},
+		{"list_data_contract", map[string]any{}, []string{`"manifestId":"sales-orders"`}},
+		{"dq_delete_job", map[string]any{"jobName": "SALES_ORDERS"}, []string{`"status":"confirm_required"`, `"tableName":"orders"`}},
+	}
+	for _, tt := range tests {
+		output := call(t, session, tt.tool, tt.arguments)
+		for _, want := range tt.want {
+			if !strings.Contains(output, want) {
+				t.Errorf("%s: expected %s in %s", tt.tool, want, output)
+			}
+		}
+	}
+}
+
+func TestTools_ChangesAreKept(t *testing.T) {
+	session := connect(t)
+
+	call(t, session, "dq_delete_job", map[string]any{"jobName": "SALES_ORDERS", "confirm": true})
+	output := call(t, session, "dq_delete_job", map[string]any{"jobName": "SALES_ORDERS"})
+	if !strings.Contains(output, `"status":"error"`) || !strings.Contains(output, `No data-quality job named \"SALES_ORDERS\" was found (HTTP 404).`) {
+		t.Errorf("expected the deleted job not to be found, got %s", output)
+	}
+}
+
+func TestTools_CreateAndEditAnAsset(t *testing.T) {
+	session := connect(t)
+
+	prepared := call(t, session, "prepare_create_asset", map[string]any{"assetType": "Business Term", "domain": "Finance Glossary"})
+	for _, want := range []string{`"status":"ready"`, `"name":"Definition"`, `"role":"represents"`} {
+		if !strings.Contains(prepared, want) {
+			t.Errorf("prepare_create_asset: expected %s in %s", want, prepared)
+		}
+	}
+
+	margin := map[string]any{
+		"name":       "Margin",
+		"assetType":  "Business Term",
+		"domain":     "Finance Glossary",
+		"status":     "Candidate",
+		"attributes": []map[string]any{{"name": "Definition", "value": "Revenue minus costs."}},
+	}
+	var created struct {
+		Status string `json:"status"`
+		Asset  struct {
+			ID string `json:"id"`
+		} `json:"asset"`
+	}
+	if err := json.Unmarshal([]byte(call(t, session, "create_asset", margin)), &created); err != nil {
+		t.Fatal(err)
+	}
+	if created.Status != "success" || created.Asset.ID != assetID(t, session, "Margin") {
+		t.Fatalf("expected Margin to be created, got %+v", created)
+	}
+	if output := call(t, session, "create_asset", margin); !strings.Contains(output, `"status":"duplicate_found"`) {
+		t.Errorf("expected creating Margin again to find it, got %s", output)
+	}
+
+	edited := call(t, session, "edit_asset", map[string]any{
+		"assetId": created.Asset.ID,
+		"operations": []map[string]any{
+			{"type": "set_attribute", "attributeName": "Definition", "value": "Revenue minus the cost of goods sold."},
+			{"type": "update_property", "field": "statusId", "value": "Accepted"},
+			{"type": "add_relation", "relationType": "represents", "targetAssetId": assetID(t, session, "amount")},
+		},
+	})
+	if !strings.Contains(edited, `"status":"success"`) {
+		t.Fatalf("expected every operation to apply, got %s", edited)
+	}
+	details := call(t, session, "get_asset_details", map[string]any{"assetId": created.Asset.ID})
+	for _, want := range []string{`"Revenue minus the cost of goods sold."`, `"name":"Accepted"`, `"role":"represents"`, `"displayName":"amount"`} {
+		if !strings.Contains(details, want) {
+			t.Errorf("get_asset_details: expected %s in %s", want, details)
+		}
+	}
+}
+
+func TestNew_RejectsDanglingReferences(t *testing.T) {
+	fixture, err := fakecollibra.ParseFixture([]byte(`
+assets:
+  - {name: orders, type: Table, domain: Sales}
+relations:
+  - {source: orders, target: customers, type: references}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fakecollibra.New(fixture); err == nil || !strings.Contains(err.Error(), "unknown asset") {
+		t.Errorf("expected an unknown asset error, got %v", err)
+	}
+}
+
+func TestServer_AnswersUnimplementedEndpoints(t *testing.T) {
+	fake, err := fakecollibra.New(&fakecollibra.Fixture{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorder := httptest.NewRecorder()
+	fake.ServeHTTP(recorder, httptest.NewRequest("GET", "/rest/2.0/workflowDefinitions", nil))
+	if recorder.Code != 501 || !strings.Contains(recorder.Body.String(), `"errorCode":"notImplemented"`) {
+		t.Errorf("expected 501 notImplemented, got %d %s", recorder.Code, recorder.Body.String())
+	}
+}
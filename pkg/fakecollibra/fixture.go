@@ -0,0 +1,194 @@
+package fakecollibra
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// DefaultFixture is a small catalog served when no fixture is given: a
+// glossary with a few business terms, a schema with tables and columns, the
+// lineage between them, an assessment, a data contract and DQ jobs.
+//
+//go:embed default.yaml
+var DefaultFixture []byte
+
+// Fixture describes the catalog a fake Collibra serves. Resources refer to
+// each other by name or id; communities, domains, asset types and statuses
+// that are referred to but not listed are created. Ids that are left out are
+// derived from the names, so they are stable across runs.
+type Fixture struct {
+	Communities   []Community    `yaml:"communities"`
+	Domains       []Domain       `yaml:"domains"`
+	AssetTypes    []AssetType    `yaml:"assetTypes"`
+	Statuses      []Status       `yaml:"statuses"`
+	Roles         []Role         `yaml:"roles"`
+	Assets        []Asset        `yaml:"assets"`
+	Relations     []Relation     `yaml:"relations"`
+	Lineage       Lineage        `yaml:"lineage"`
+	Assessments   []Assessment   `yaml:"assessments"`
+	DataContracts []DataContract `yaml:"dataContracts"`
+	DQJobs        []DQJob        `yaml:"dqJobs"`
+	// DQRuleTemplates are the data quality rule templates, with {{column}}
+	// in their SQL.
+	DQRuleTemplates []DQRuleTemplate `yaml:"dqRuleTemplates"`
+	// GlobalPermissions are the global permissions of the current user.
+	GlobalPermissions []string `yaml:"globalPermissions"`
+}
+
+type Community struct {
+	ID          string `yaml:"id"`
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Parent      string `yaml:"parent"`
+}
+
+type Domain struct {
+	ID        string `yaml:"id"`
+	Name      string `yaml:"name"`
+	Community string `yaml:"community"`
+	// Type is the name of the domain type, e.g. "Glossary".
+	Type string `yaml:"type"`
+}
+
+type AssetType struct {
+	ID          string `yaml:"id"`
+	PublicID    string `yaml:"publicId"`
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Parent      string `yaml:"parent"`
+}
+
+type Status struct {
+	ID   string `yaml:"id"`
+	Name string `yaml:"name"`
+}
+
+type Role struct {
+	ID   string `yaml:"id"`
+	Name string `yaml:"name"`
+}
+
+type Asset struct {
+	ID          string `yaml:"id"`
+	Name        string `yaml:"name"`
+	DisplayName string `yaml:"displayName"`
+	Type        string `yaml:"type"`
+	Domain      string `yaml:"domain"`
+	Status      string `yaml:"status"`
+	// Attributes maps attribute type names to values. Strings, numbers and
+	// booleans become string, numeric and boolean attributes.
+	Attributes map[string]any `yaml:"attributes"`
+}
+
+// Relation relates two assets, referred to by name or id. Type is the role of
+// the relation type, e.g. "represents"; TypeID pins the relation type id, for
+// tools that look relations up by well-known relation type ids.
+type Relation struct {
+	ID     string `yaml:"id"`
+	Source string `yaml:"source"`
+	Target string `yaml:"target"`
+	Type   string `yaml:"type"`
+	CoRole string `yaml:"coRole"`
+	TypeID string `yaml:"typeId"`
+}
+
+type Lineage struct {
+	Entities        []LineageEntity         `yaml:"entities"`
+	Relations       []LineageRelation       `yaml:"relations"`
+	Transformations []LineageTransformation `yaml:"transformations"`
+}
+
+type LineageEntity struct {
+	ID     string `yaml:"id"`
+	Name   string `yaml:"name"`
+	Type   string `yaml:"type"`
+	Parent string `yaml:"parent"`
+	// Asset is the catalog asset the entity is stitched to, by name or id.
+	Asset string `yaml:"asset"`
+}
+
+type LineageRelation struct {
+	Source          string   `yaml:"source"`
+	Target          string   `yaml:"target"`
+	Transformations []string `yaml:"transformations"`
+}
+
+type LineageTransformation struct {
+	ID          string `yaml:"id"`
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Logic       string `yaml:"logic"`
+}
+
+type Assessment struct {
+	ID       string `yaml:"id"`
+	Name     string `yaml:"name"`
+	Status   string `yaml:"status"`
+	Template string `yaml:"template"`
+	// Asset is the assessed asset, by name or id.
+	Asset     string     `yaml:"asset"`
+	Questions []Question `yaml:"questions"`
+}
+
+type Question struct {
+	ID          string `yaml:"id"`
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	// AnswerType is the type of the answer, e.g. TEXT or BOOLEAN.
+	AnswerType string `yaml:"answerType"`
+	Answer     any    `yaml:"answer"`
+}
+
+type DataContract struct {
+	ID         string `yaml:"id"`
+	ManifestID string `yaml:"manifestId"`
+	Domain     string `yaml:"domain"`
+	// Manifest is the active version of the manifest.
+	Manifest string `yaml:"manifest"`
+}
+
+type DQJob struct {
+	Name        string     `yaml:"name"`
+	JobType     string     `yaml:"jobType"`
+	Connection  string     `yaml:"connection"`
+	DataSource  string     `yaml:"dataSource"`
+	Schema      string     `yaml:"schema"`
+	Table       string     `yaml:"table"`
+	SourceQuery string     `yaml:"sourceQuery"`
+	Runs        []DQJobRun `yaml:"runs"`
+}
+
+type DQJobRun struct {
+	ID      string `yaml:"id"`
+	Status  string `yaml:"status"`
+	RunDate string `yaml:"runDate"`
+}
+
+type DQRuleTemplate struct {
+	ID         string   `yaml:"id"`
+	Name       string   `yaml:"name"`
+	SQL        string   `yaml:"sql"`
+	Dimensions []string `yaml:"dimensions"`
+	IsSystem   bool     `yaml:"isSystem"`
+}
+
+// ParseFixture parses a YAML or JSON fixture.
+func ParseFixture(data []byte) (*Fixture, error) {
+	var fixture Fixture
+	if err := yaml.Unmarshal(data, &fixture); err != nil {
+		return nil, fmt.Errorf("failed to parse the fixture: %w", err)
+	}
+	return &fixture, nil
+}
+
+// LoadFixture reads a YAML or JSON fixture.
+func LoadFixture(path string) (*Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the fixture: %w", err)
+	}
+	return ParseFixture(data)
+}
@@ -0,0 +1,102 @@
+package fakecollibra
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+func (s *Server) registerGraphQL() {
+	s.handle("POST /graphql/knowledgeGraph/v1", s.graphQL)
+}
+
+// graphQL answers the asset details query of the Knowledge Graph: the assets
+// with the ids in $assetIds, with their attributes and a page of their
+// relations ordered by id. The fake does not parse GraphQL, so other queries
+// are answered with a GraphQL error.
+func (s *Server) graphQL(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		Query     string         `json:"query"`
+		Variables map[string]any `json:"variables"`
+	}
+	if !decode(w, r, &request) {
+		return
+	}
+	assetIDs, ok := request.Variables["assetIds"].([]any)
+	if !ok || !strings.Contains(request.Query, "assets(") {
+		writeJSON(w, http.StatusOK, map[string]any{
+			"errors": []map[string]any{{"message": "fake-collibra only answers the asset details query"}},
+		})
+		return
+	}
+	limit := len(s.store.relations)
+	if relationsLimit, ok := request.Variables["relationsLimit"].(float64); ok {
+		limit = int(relationsLimit)
+	}
+	outgoingCursor, _ := request.Variables["outgoingCursor"].(string)
+	incomingCursor, _ := request.Variables["incomingCursor"].(string)
+
+	assets := []map[string]any{}
+	for _, id := range assetIDs {
+		a := s.store.asset(id.(string))
+		if a == nil || a.id != id {
+			continue
+		}
+		assets = append(assets, s.graphQLAsset(a, limit, outgoingCursor, incomingCursor))
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"data": map[string]any{"assets": assets}})
+}
+
+func (s *Server) graphQLAsset(a *asset, limit int, outgoingCursor, incomingCursor string) map[string]any {
+	name := func(value string) map[string]any { return map[string]any{"name": value} }
+	out := map[string]any{
+		"id":                a.id,
+		"displayName":       a.displayName,
+		"type":              name(s.store.assetType(a.typeID).name),
+		"domain":            name(s.store.domain(a.domainID).name),
+		"stringAttributes":  []any{},
+		"numericAttributes": []any{},
+		"booleanAttributes": []any{},
+		"dateAttributes":    []any{},
+	}
+	if st := s.store.status(a.statusID); a.statusID != "" && st != nil {
+		out["status"] = name(st.name)
+	}
+	for _, attr := range s.store.attributes {
+		if attr.assetID != a.id {
+			continue
+		}
+		t := s.store.attributeType(attr.typeID)
+		field, valueField := "stringAttributes", "stringValue"
+		switch t.kind {
+		case "NumericAttributeType":
+			field, valueField = "numericAttributes", "numericValue"
+		case "BooleanAttributeType":
+			field, valueField = "booleanAttributes", "booleanValue"
+		case "DateAttributeType":
+			field, valueField = "dateAttributes", "dateValue"
+		}
+		out[field] = append(out[field].([]any), map[string]any{valueField: attr.value, "type": name(t.name)})
+	}
+
+	related := func(id string) map[string]any {
+		other := s.store.asset(id)
+		return map[string]any{"id": other.id, "displayName": other.displayName, "type": name(s.store.assetType(other.typeID).name)}
+	}
+	relations := slices.Clone(s.store.relations)
+	slices.SortFunc(relations, func(a, b *relation) int { return strings.Compare(a.id, b.id) })
+	outgoing, incoming := []any{}, []any{}
+	for _, rel := range relations {
+		t := s.store.relationType(rel.typeID)
+		relationType := map[string]any{"id": t.id, "role": t.role}
+		if rel.sourceID == a.id && rel.id > outgoingCursor && len(outgoing) < limit {
+			outgoing = append(outgoing, map[string]any{"type": relationType, "target": related(rel.targetID)})
+		}
+		if rel.targetID == a.id && rel.id > incomingCursor && len(incoming) < limit {
+			incoming = append(incoming, map[string]any{"type": relationType, "source": related(rel.sourceID)})
+		}
+	}
+	out["outgoingRelations"] = outgoing
+	out["incomingRelations"] = incoming
+	return out
+}
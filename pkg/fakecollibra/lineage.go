@@ -0,0 +1,115 @@
+package fakecollibra
+
+import (
+	"net/http"
+	"strings"
+)
+
+const lineagePath = "/technical_lineage_resource/rest/lineageGraphRead/v1"
+
+func (s *Server) registerLineage() {
+	s.handle("GET "+lineagePath+"/entities", s.searchLineageEntities)
+	s.handle("GET "+lineagePath+"/entities/{id}", s.getLineageEntity)
+	s.handle("GET "+lineagePath+"/entities/{id}/upstream", s.lineageDirectional(false))
+	s.handle("GET "+lineagePath+"/entities/{id}/downstream", s.lineageDirectional(true))
+	s.handle("GET "+lineagePath+"/transformations", s.searchTransformations)
+	s.handle("GET "+lineagePath+"/transformations/{id}", s.getTransformation)
+}
+
+func lineageEntityJSON(e *lineageEntity) map[string]any {
+	out := map[string]any{"id": e.id, "name": e.name, "type": e.entityType}
+	if e.dgcID != "" {
+		out["dgcId"] = e.dgcID
+	}
+	if e.parentID != "" {
+		out["parentId"] = e.parentID
+	}
+	return out
+}
+
+func (s *Server) searchLineageEntities(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	nameContains := strings.ToLower(query.Get("nameContains"))
+	var results []map[string]any
+	for _, e := range s.store.lineageEntities {
+		if !strings.Contains(strings.ToLower(e.name), nameContains) ||
+			(query.Get("type") != "" && !strings.EqualFold(e.entityType, query.Get("type"))) ||
+			(query.Get("dgcId") != "" && e.dgcID != query.Get("dgcId")) {
+			continue
+		}
+		results = append(results, lineageEntityJSON(e))
+	}
+	page, next := cursorPage(r, results, 20)
+	writeJSON(w, http.StatusOK, map[string]any{"results": page, "nextCursor": next})
+}
+
+func (s *Server) getLineageEntity(w http.ResponseWriter, r *http.Request) {
+	e := s.store.lineageEntity(r.PathValue("id"))
+	if e == nil || e.id != r.PathValue("id") {
+		notFound(w, "entity", r.PathValue("id"))
+		return
+	}
+	writeJSON(w, http.StatusOK, lineageEntityJSON(e))
+}
+
+// lineageDirectional answers the direct upstream or downstream relations of
+// an entity, optionally only those with an entity of the entityType query
+// parameter at the other end.
+func (s *Server) lineageDirectional(downstream bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+		if e := s.store.lineageEntity(id); e == nil || e.id != id {
+			notFound(w, "entity", id)
+			return
+		}
+		entityType := r.URL.Query().Get("entityType")
+		var relations []map[string]any
+		for _, rel := range s.store.lineageRelations {
+			other := rel.Source
+			if downstream {
+				if rel.Source != id {
+					continue
+				}
+				other = rel.Target
+			} else if rel.Target != id {
+				continue
+			}
+			if entityType != "" && !strings.EqualFold(s.store.lineageEntity(other).entityType, entityType) {
+				continue
+			}
+			relations = append(relations, map[string]any{
+				"sourceEntityId":    rel.Source,
+				"targetEntityId":    rel.Target,
+				"transformationIds": rel.Transformations,
+			})
+		}
+		page, next := cursorPage(r, relations, 20)
+		writeJSON(w, http.StatusOK, map[string]any{"relations": page, "nextCursor": next})
+	}
+}
+
+func (s *Server) searchTransformations(w http.ResponseWriter, r *http.Request) {
+	nameContains := strings.ToLower(r.URL.Query().Get("nameContains"))
+	var results []map[string]any
+	for _, t := range s.store.transformations {
+		if strings.Contains(strings.ToLower(t.Name), nameContains) {
+			results = append(results, map[string]any{"id": t.ID, "name": t.Name, "description": t.Description})
+		}
+	}
+	page, next := cursorPage(r, results, 20)
+	writeJSON(w, http.StatusOK, map[string]any{"results": page, "nextCursor": next})
+}
+
+func (s *Server) getTransformation(w http.ResponseWriter, r *http.Request) {
+	t := s.store.transformation(r.PathValue("id"))
+	if t == nil || t.ID != r.PathValue("id") {
+		notFound(w, "transformation", r.PathValue("id"))
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"id":                  t.ID,
+		"name":                t.Name,
+		"description":         t.Description,
+		"transformationLogic": t.Logic,
+	})
+}
@@ -0,0 +1,750 @@
+package fakecollibra
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const fakeUser = "fake-collibra"
+
+// fakeUserID is the id of the user every request is made as.
+var fakeUserID = stableID("user", fakeUser)
+
+type ref struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+type resourceRef struct {
+	ID           string `json:"id"`
+	Name         string `json:"name,omitempty"`
+	ResourceType string `json:"resourceType"`
+}
+
+func (s *Server) registerREST() {
+	s.handle("POST /rest/2.0/search", s.search)
+	s.handle("GET /rest/2.0/communities", s.listCommunities)
+	s.handle("GET /rest/2.0/communities/{id}", s.getCommunity)
+	s.handle("GET /rest/2.0/domains", s.listDomains)
+	s.handle("GET /rest/2.0/domains/{id}", s.getDomain)
+	s.handle("GET /rest/2.0/domainTypes", s.listDomainTypes)
+	s.handle("GET /rest/2.0/assetTypes", s.listAssetTypes)
+	s.handle("GET /rest/2.0/assetTypes/{id}", s.getAssetType)
+	s.handle("GET /rest/2.0/assetTypes/publicId/{publicId}", s.getAssetTypeByPublicID)
+	s.handle("GET /rest/2.0/statuses", s.listStatuses)
+	s.handle("GET /rest/2.0/roles", s.listRoles)
+	s.handle("GET /rest/2.0/assets", s.listAssets)
+	s.handle("POST /rest/2.0/assets", s.createAsset)
+	s.handle("GET /rest/2.0/assets/{id}", s.getAsset)
+	s.handle("PATCH /rest/2.0/assets/{id}", s.patchAsset)
+	s.handle("GET /rest/2.0/assignments/asset/{id}", s.getAssetAssignment)
+	s.handle("GET /rest/2.0/assignments/assetType/{id}", s.getAssetTypeAssignments)
+	s.handle("GET /rest/2.0/assignments/domain/{id}/assetTypes", s.getDomainAssetTypes)
+	s.handle("GET /rest/2.0/attributeTypes/{id}", s.getAttributeType)
+	s.handle("GET /rest/2.0/attributes", s.listAttributes)
+	s.handle("POST /rest/2.0/attributes", s.createAttribute)
+	s.handle("PATCH /rest/2.0/attributes/{id}", s.patchAttribute)
+	s.handle("DELETE /rest/2.0/attributes/{id}", s.deleteAttribute)
+	s.handle("GET /rest/2.0/relationTypes/{id}", s.getRelationType)
+	s.handle("GET /rest/2.0/relations", s.listRelations)
+	s.handle("POST /rest/2.0/relations", s.createRelation)
+	s.handle("DELETE /rest/2.0/relations/{id}", s.deleteRelation)
+	s.handle("GET /rest/2.0/responsibilities", s.listResponsibilities)
+	s.handle("GET /rest/2.0/users/current", s.currentUser)
+	s.handle("GET /rest/2.0/users/current/globalPermissions", s.globalPermissions)
+}
+
+// matches reports whether value contains the name query parameter of r,
+// ignoring case, as the name filters of the REST 2.0 list endpoints do.
+func matches(r *http.Request, value string) bool {
+	name := r.URL.Query().Get("name")
+	return strings.Contains(strings.ToLower(value), strings.ToLower(name))
+}
+
+// search implements the keyword search over assets, domains and communities.
+// Keywords are matched as a case-insensitive substring of the name or display
+// name, with the wildcards the clients add removed.
+func (s *Server) search(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		Keywords       string `json:"keywords"`
+		SearchInFields []struct {
+			ResourceType string `json:"resourceType"`
+		} `json:"searchInFields"`
+		Filters []struct {
+			Field  string   `json:"field"`
+			Values []string `json:"values"`
+		} `json:"filters"`
+		Limit  int `json:"limit"`
+		Offset int `json:"offset"`
+	}
+	if !decode(w, r, &request) {
+		return
+	}
+	keywords := strings.ToLower(strings.Trim(request.Keywords, "* "))
+	resourceTypes := map[string]bool{}
+	for _, field := range request.SearchInFields {
+		resourceTypes[field.ResourceType] = true
+	}
+	filters := map[string][]string{}
+	for _, filter := range request.Filters {
+		filters[filter.Field] = filter.Values
+	}
+	accepts := func(field, value string) bool {
+		values, ok := filters[field]
+		return !ok || slices.Contains(values, value)
+	}
+
+	type result struct {
+		Resource struct {
+			ResourceType   string `json:"resourceType"`
+			ID             string `json:"id"`
+			CreatedBy      string `json:"createdBy"`
+			CreatedOn      int64  `json:"createdOn"`
+			LastModifiedOn int64  `json:"lastModifiedOn"`
+			Name           string `json:"name"`
+		} `json:"resource"`
+		Highlights []any `json:"highlights"`
+	}
+	var results []result
+	add := func(resourceType, id, name, displayName string, createdOn int64) {
+		if len(resourceTypes) > 0 && !resourceTypes[resourceType] {
+			return
+		}
+		if !strings.Contains(strings.ToLower(name), keywords) && !strings.Contains(strings.ToLower(displayName), keywords) {
+			return
+		}
+		res := result{Highlights: []any{}}
+		res.Resource.ResourceType = resourceType
+		res.Resource.ID = id
+		res.Resource.CreatedBy = fakeUserID
+		res.Resource.CreatedOn = createdOn
+		res.Resource.LastModifiedOn = createdOn
+		res.Resource.Name = displayName
+		results = append(results, res)
+	}
+	onlyAssetFilters := len(filters["assetType"]) > 0 || len(filters["status"]) > 0
+	for _, a := range s.store.assets {
+		d := s.store.domain(a.domainID)
+		if accepts("assetType", a.typeID) && accepts("status", a.statusID) && accepts("domain", a.domainID) &&
+			accepts("community", d.communityID) && accepts("domainType", d.typeID) && accepts("createdBy", fakeUserID) {
+			add("Asset", a.id, a.name, a.displayName, a.createdOn)
+		}
+	}
+	if !onlyAssetFilters {
+		for _, d := range s.store.domains {
+			if accepts("domain", d.id) && accepts("community", d.communityID) && accepts("domainType", d.typeID) {
+				add("Domain", d.id, d.name, d.name, 0)
+			}
+		}
+		if len(filters["domain"]) == 0 && len(filters["domainType"]) == 0 {
+			for _, c := range s.store.communities {
+				if accepts("community", c.id) {
+					add("Community", c.id, c.name, c.name, 0)
+				}
+			}
+		}
+	}
+
+	page := results[min(request.Offset, len(results)):]
+	if request.Limit > 0 && request.Limit < len(page) {
+		page = page[:request.Limit]
+	}
+	if page == nil {
+		page = []result{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"total": len(results), "results": page, "aggregations": []any{}})
+}
+
+func (s *Server) communityJSON(c *community) map[string]any {
+	out := map[string]any{"id": c.id, "name": c.name, "description": c.description, "resourceType": "Community"}
+	if parent := s.store.community(c.parentID); c.parentID != "" && parent != nil {
+		out["parent"] = ref{ID: parent.id, Name: parent.name}
+	}
+	return out
+}
+
+func (s *Server) listCommunities(w http.ResponseWriter, r *http.Request) {
+	var results []map[string]any
+	for _, c := range s.store.communities {
+		if matches(r, c.name) {
+			results = append(results, s.communityJSON(c))
+		}
+	}
+	writeJSON(w, http.StatusOK, offsetPage(r, results))
+}
+
+func (s *Server) getCommunity(w http.ResponseWriter, r *http.Request) {
+	for _, c := range s.store.communities {
+		if c.id == r.PathValue("id") {
+			writeJSON(w, http.StatusOK, s.communityJSON(c))
+			return
+		}
+	}
+	notFound(w, "community", r.PathValue("id"))
+}
+
+func (s *Server) domainJSON(d *domain) map[string]any {
+	c := s.store.community(d.communityID)
+	domainType := find(s.store.domainTypes, d.typeID, namedID, namedName)
+	return map[string]any{
+		"id":           d.id,
+		"name":         d.name,
+		"resourceType": "Domain",
+		"type":         ref{ID: domainType.id, Name: domainType.name},
+		"community":    ref{ID: c.id, Name: c.name},
+	}
+}
+
+func (s *Server) listDomains(w http.ResponseWriter, r *http.Request) {
+	communityID := r.URL.Query().Get("communityId")
+	var results []map[string]any
+	for _, d := range s.store.domains {
+		if matches(r, d.name) && (communityID == "" || d.communityID == communityID) {
+			results = append(results, s.domainJSON(d))
+		}
+	}
+	writeJSON(w, http.StatusOK, offsetPage(r, results))
+}
+
+func (s *Server) getDomain(w http.ResponseWriter, r *http.Request) {
+	for _, d := range s.store.domains {
+		if d.id == r.PathValue("id") {
+			writeJSON(w, http.StatusOK, s.domainJSON(d))
+			return
+		}
+	}
+	notFound(w, "domain", r.PathValue("id"))
+}
+
+func (s *Server) listDomainTypes(w http.ResponseWriter, r *http.Request) {
+	s.listNamed(w, r, s.store.domainTypes)
+}
+
+func (s *Server) listStatuses(w http.ResponseWriter, r *http.Request) {
+	s.listNamed(w, r, s.store.statuses)
+}
+
+func (s *Server) listRoles(w http.ResponseWriter, r *http.Request) {
+	s.listNamed(w, r, s.store.roles)
+}
+
+func (s *Server) listNamed(w http.ResponseWriter, r *http.Request, items []*named) {
+	results := []ref{}
+	for _, item := range items {
+		if matches(r, item.name) {
+			results = append(results, ref{ID: item.id, Name: item.name})
+		}
+	}
+	writeJSON(w, http.StatusOK, offsetPage(r, results))
+}
+
+func (s *Server) assetTypeJSON(t *assetType) map[string]any {
+	out := map[string]any{
+		"id":                 t.id,
+		"name":               t.name,
+		"publicId":           t.publicID,
+		"description":        t.description,
+		"displayNameEnabled": true,
+		"ratingEnabled":      false,
+		"finalType":          false,
+		"system":             false,
+	}
+	if parent := s.store.assetType(t.parentID); t.parentID != "" && parent != nil {
+		out["parent"] = s.assetTypeJSON(parent)
+	}
+	return out
+}
+
+func (s *Server) listAssetTypes(w http.ResponseWriter, r *http.Request) {
+	var results []map[string]any
+	for _, t := range s.store.assetTypes {
+		if matches(r, t.name) {
+			results = append(results, s.assetTypeJSON(t))
+		}
+	}
+	writeJSON(w, http.StatusOK, offsetPage(r, results))
+}
+
+func (s *Server) getAssetType(w http.ResponseWriter, r *http.Request) {
+	for _, t := range s.store.assetTypes {
+		if t.id == r.PathValue("id") {
+			writeJSON(w, http.StatusOK, s.assetTypeJSON(t))
+			return
+		}
+	}
+	notFound(w, "assetType", r.PathValue("id"))
+}
+
+func (s *Server) getAssetTypeByPublicID(w http.ResponseWriter, r *http.Request) {
+	for _, t := range s.store.assetTypes {
+		if t.publicID == r.PathValue("publicId") {
+			writeJSON(w, http.StatusOK, s.assetTypeJSON(t))
+			return
+		}
+	}
+	notFound(w, "assetType", r.PathValue("publicId"))
+}
+
+func (s *Server) assetJSON(a *asset) map[string]any {
+	t := s.store.assetType(a.typeID)
+	d := s.store.domain(a.domainID)
+	out := map[string]any{
+		"id":             a.id,
+		"name":           a.name,
+		"displayName":    a.displayName,
+		"resourceType":   "Asset",
+		"type":           ref{ID: t.id, Name: t.name},
+		"domain":         ref{ID: d.id, Name: d.name},
+		"createdBy":      fakeUserID,
+		"createdOn":      a.createdOn,
+		"lastModifiedBy": fakeUserID,
+		"lastModifiedOn": a.lastModifiedOn,
+	}
+	if st := s.store.status(a.statusID); a.statusID != "" && st != nil {
+		out["status"] = ref{ID: st.id, Name: st.name}
+	}
+	return out
+}
+
+func (s *Server) listAssets(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var results []map[string]any
+	for _, a := range s.store.assets {
+		if !matchName(a.name, query.Get("name"), query.Get("nameMatchMode")) {
+			continue
+		}
+		if query.Has("typePublicIds") && !slices.Contains(query["typePublicIds"], s.store.assetType(a.typeID).publicID) {
+			continue
+		}
+		if (query.Get("typeId") != "" && a.typeID != query.Get("typeId")) ||
+			(query.Get("domainId") != "" && a.domainID != query.Get("domainId")) ||
+			(query.Get("statusId") != "" && a.statusID != query.Get("statusId")) {
+			continue
+		}
+		results = append(results, s.assetJSON(a))
+	}
+	writeJSON(w, http.StatusOK, offsetPage(r, results))
+}
+
+func (s *Server) getAsset(w http.ResponseWriter, r *http.Request) {
+	a := s.store.asset(r.PathValue("id"))
+	if a == nil || a.id != r.PathValue("id") {
+		notFound(w, "asset", r.PathValue("id"))
+		return
+	}
+	writeJSON(w, http.StatusOK, s.assetJSON(a))
+}
+
+func (s *Server) createAsset(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		Name        string `json:"name"`
+		DisplayName string `json:"displayName"`
+		TypeID      string `json:"typeId"`
+		DomainID    string `json:"domainId"`
+		StatusID    string `json:"statusId"`
+	}
+	if !decode(w, r, &request) {
+		return
+	}
+	if request.Name == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]any{
+			"statusCode": http.StatusBadRequest,
+			"errorCode":  "validationError",
+			"errors":     []map[string]string{{"field": "name", "message": "must not be blank"}},
+		})
+		return
+	}
+	if s.store.assetType(request.TypeID) == nil {
+		notFound(w, "assetType", request.TypeID)
+		return
+	}
+	if s.store.domain(request.DomainID) == nil {
+		notFound(w, "domain", request.DomainID)
+		return
+	}
+	for _, a := range s.store.assets {
+		if a.domainID == request.DomainID && strings.EqualFold(a.name, request.Name) {
+			writeError(w, http.StatusConflict, "assetNameAlreadyExists", "an asset named %s already exists in the domain", request.Name)
+			return
+		}
+	}
+	now := time.Now().UnixMilli()
+	created := &asset{
+		id:             uuid.NewString(),
+		name:           request.Name,
+		displayName:    request.DisplayName,
+		typeID:         request.TypeID,
+		domainID:       request.DomainID,
+		statusID:       request.StatusID,
+		createdOn:      now,
+		lastModifiedOn: now,
+	}
+	if created.displayName == "" {
+		created.displayName = created.name
+	}
+	s.store.assets = append(s.store.assets, created)
+	writeJSON(w, http.StatusCreated, s.assetJSON(created))
+}
+
+func (s *Server) patchAsset(w http.ResponseWriter, r *http.Request) {
+	a := s.store.asset(r.PathValue("id"))
+	if a == nil || a.id != r.PathValue("id") {
+		notFound(w, "asset", r.PathValue("id"))
+		return
+	}
+	var request struct {
+		Name        *string `json:"name"`
+		DisplayName *string `json:"displayName"`
+		StatusID    *string `json:"statusId"`
+	}
+	if !decode(w, r, &request) {
+		return
+	}
+	if request.StatusID != nil && s.store.status(*request.StatusID) == nil {
+		notFound(w, "status", *request.StatusID)
+		return
+	}
+	if request.Name != nil {
+		a.name = *request.Name
+	}
+	if request.DisplayName != nil {
+		a.displayName = *request.DisplayName
+	}
+	if request.StatusID != nil {
+		a.statusID = *request.StatusID
+	}
+	a.lastModifiedOn = time.Now().UnixMilli()
+	writeJSON(w, http.StatusOK, s.assetJSON(a))
+}
+
+// getAssetAssignment answers the assignment of an asset: the attribute types
+// that assets of its type have in the fixture, and the relation types that
+// start or end at its type.
+func (s *Server) getAssetAssignment(w http.ResponseWriter, r *http.Request) {
+	a := s.store.asset(r.PathValue("id"))
+	if a == nil || a.id != r.PathValue("id") {
+		notFound(w, "asset", r.PathValue("id"))
+		return
+	}
+	t := s.store.assetType(a.typeID)
+	d := s.store.domain(a.domainID)
+	domainType := find(s.store.domainTypes, d.typeID, namedID, namedName)
+	writeJSON(w, http.StatusOK, s.assignmentJSON(t, []*named{domainType}))
+}
+
+// getAssetTypeAssignments answers the assignment of an asset type, to the
+// types of the domains holding assets of the type.
+func (s *Server) getAssetTypeAssignments(w http.ResponseWriter, r *http.Request) {
+	t := s.store.assetType(r.PathValue("id"))
+	if t == nil || t.id != r.PathValue("id") {
+		notFound(w, "assetType", r.PathValue("id"))
+		return
+	}
+	domainTypes := s.assignedDomainTypes(t)
+	if len(domainTypes) == 0 {
+		writeJSON(w, http.StatusOK, []any{})
+		return
+	}
+	writeJSON(w, http.StatusOK, []any{s.assignmentJSON(t, domainTypes)})
+}
+
+// getDomainAssetTypes answers the asset types assigned to the type of a
+// domain.
+func (s *Server) getDomainAssetTypes(w http.ResponseWriter, r *http.Request) {
+	d := s.store.domain(r.PathValue("id"))
+	if d == nil || d.id != r.PathValue("id") {
+		notFound(w, "domain", r.PathValue("id"))
+		return
+	}
+	results := []map[string]any{}
+	for _, t := range s.store.assetTypes {
+		if slices.ContainsFunc(s.assignedDomainTypes(t), func(domainType *named) bool { return domainType.id == d.typeID }) {
+			results = append(results, s.assetTypeJSON(t))
+		}
+	}
+	writeJSON(w, http.StatusOK, results)
+}
+
+// assignedDomainTypes returns the types of the domains holding assets of t:
+// the fake assigns an asset type where the fixture uses it.
+func (s *Server) assignedDomainTypes(t *assetType) []*named {
+	var domainTypes []*named
+	for _, a := range s.store.assets {
+		if a.typeID != t.id {
+			continue
+		}
+		domainType := find(s.store.domainTypes, s.store.domain(a.domainID).typeID, namedID, namedName)
+		if !slices.Contains(domainTypes, domainType) {
+			domainTypes = append(domainTypes, domainType)
+		}
+	}
+	return domainTypes
+}
+
+// assignmentJSON describes the assignment of t to domainTypes, with the
+// attribute types the fixture sets on assets of t and the relation types
+// from or to t.
+func (s *Server) assignmentJSON(t *assetType, domainTypes []*named) map[string]any {
+	characteristic := func(id, name, discriminator, direction string) map[string]any {
+		out := map[string]any{
+			"id":                        stableID("assignedCharacteristic", t.id+"/"+id+"/"+direction),
+			"assignedResourceReference": map[string]any{"id": id, "name": name, "resourceDiscriminator": discriminator},
+			"minimumOccurrences":        0,
+		}
+		if direction != "" {
+			out["relationTypeDirection"] = direction
+		}
+		return out
+	}
+	characteristics := []map[string]any{}
+	seen := map[string]bool{}
+	for _, attr := range s.store.attributes {
+		owner := s.store.asset(attr.assetID)
+		if owner.typeID != t.id || seen[attr.typeID] {
+			continue
+		}
+		seen[attr.typeID] = true
+		attrType := s.store.attributeType(attr.typeID)
+		characteristics = append(characteristics, characteristic(attrType.id, attrType.name, attrType.kind, ""))
+	}
+	for _, rt := range s.store.relationTypes {
+		if rt.sourceTypeID == t.id {
+			characteristics = append(characteristics, characteristic(rt.id, rt.role, "RelationType", "TO_TARGET"))
+		}
+		if rt.targetTypeID == t.id {
+			characteristics = append(characteristics, characteristic(rt.id, rt.coRole, "RelationType", "TO_SOURCE"))
+		}
+	}
+	refs := make([]ref, len(domainTypes))
+	for i, domainType := range domainTypes {
+		refs[i] = ref{ID: domainType.id, Name: domainType.name}
+	}
+	return map[string]any{
+		"id":                                   stableID("assignment", t.id),
+		"assetType":                            ref{ID: t.id, Name: t.name},
+		"domainTypes":                          refs,
+		"assignedCharacteristicTypeReferences": characteristics,
+	}
+}
+
+func (s *Server) getAttributeType(w http.ResponseWriter, r *http.Request) {
+	for _, t := range s.store.attributeTypes {
+		if t.id == r.PathValue("id") {
+			writeJSON(w, http.StatusOK, map[string]any{
+				"id":                         t.id,
+				"name":                       t.name,
+				"attributeTypeDiscriminator": t.kind,
+			})
+			return
+		}
+	}
+	notFound(w, "attributeType", r.PathValue("id"))
+}
+
+func (s *Server) attributeJSON(a *attribute) map[string]any {
+	t := s.store.attributeType(a.typeID)
+	return map[string]any{
+		"id":    a.id,
+		"type":  ref{ID: t.id, Name: t.name},
+		"asset": ref{ID: a.assetID},
+		"value": a.value,
+	}
+}
+
+func (s *Server) listAttributes(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	typeIDs := append(query["typeIds"], query["attributeTypeId"]...)
+	var results []map[string]any
+	for _, a := range s.store.attributes {
+		if query.Get("assetId") != "" && a.assetID != query.Get("assetId") {
+			continue
+		}
+		if len(typeIDs) > 0 && !slices.Contains(typeIDs, a.typeID) {
+			continue
+		}
+		results = append(results, s.attributeJSON(a))
+	}
+	writeJSON(w, http.StatusOK, offsetPage(r, results))
+}
+
+func (s *Server) createAttribute(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		AssetID string `json:"assetId"`
+		TypeID  string `json:"typeId"`
+		Value   any    `json:"value"`
+	}
+	if !decode(w, r, &request) {
+		return
+	}
+	if a := s.store.asset(request.AssetID); a == nil || a.id != request.AssetID {
+		notFound(w, "asset", request.AssetID)
+		return
+	}
+	if s.store.attributeType(request.TypeID) == nil {
+		notFound(w, "attributeType", request.TypeID)
+		return
+	}
+	created := &attribute{id: uuid.NewString(), assetID: request.AssetID, typeID: request.TypeID, value: request.Value}
+	s.store.attributes = append(s.store.attributes, created)
+	writeJSON(w, http.StatusCreated, s.attributeJSON(created))
+}
+
+func (s *Server) patchAttribute(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		Value any `json:"value"`
+	}
+	if !decode(w, r, &request) {
+		return
+	}
+	for _, a := range s.store.attributes {
+		if a.id == r.PathValue("id") {
+			a.value = request.Value
+			writeJSON(w, http.StatusOK, s.attributeJSON(a))
+			return
+		}
+	}
+	notFound(w, "attribute", r.PathValue("id"))
+}
+
+func (s *Server) deleteAttribute(w http.ResponseWriter, r *http.Request) {
+	for i, a := range s.store.attributes {
+		if a.id == r.PathValue("id") {
+			s.store.attributes = slices.Delete(s.store.attributes, i, i+1)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	notFound(w, "attribute", r.PathValue("id"))
+}
+
+func (s *Server) getRelationType(w http.ResponseWriter, r *http.Request) {
+	for _, t := range s.store.relationTypes {
+		if t.id == r.PathValue("id") {
+			source, target := s.store.assetType(t.sourceTypeID), s.store.assetType(t.targetTypeID)
+			writeJSON(w, http.StatusOK, map[string]any{
+				"id":         t.id,
+				"role":       t.role,
+				"coRole":     t.coRole,
+				"sourceType": ref{ID: source.id, Name: source.name},
+				"targetType": ref{ID: target.id, Name: target.name},
+			})
+			return
+		}
+	}
+	notFound(w, "relationType", r.PathValue("id"))
+}
+
+func (s *Server) relationJSON(rel *relation) map[string]any {
+	t := s.store.relationType(rel.typeID)
+	source, target := s.store.asset(rel.sourceID), s.store.asset(rel.targetID)
+	end := func(a *asset) map[string]any {
+		return map[string]any{
+			"id":           a.id,
+			"name":         a.displayName,
+			"resourceType": "Asset",
+			"typeName":     s.store.assetType(a.typeID).name,
+		}
+	}
+	return map[string]any{
+		"id":     rel.id,
+		"type":   map[string]any{"id": t.id, "role": t.role, "coRole": t.coRole},
+		"source": end(source),
+		"target": end(target),
+	}
+}
+
+func (s *Server) listRelations(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var results []map[string]any
+	for _, rel := range s.store.relations {
+		if (query.Get("sourceId") != "" && rel.sourceID != query.Get("sourceId")) ||
+			(query.Get("targetId") != "" && rel.targetID != query.Get("targetId")) ||
+			(query.Get("relationTypeId") != "" && rel.typeID != query.Get("relationTypeId")) {
+			continue
+		}
+		results = append(results, s.relationJSON(rel))
+	}
+	writeJSON(w, http.StatusOK, offsetPage(r, results))
+}
+
+func (s *Server) createRelation(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		SourceID string `json:"sourceId"`
+		TargetID string `json:"targetId"`
+		TypeID   string `json:"typeId"`
+	}
+	if !decode(w, r, &request) {
+		return
+	}
+	for _, id := range []string{request.SourceID, request.TargetID} {
+		if a := s.store.asset(id); a == nil || a.id != id {
+			notFound(w, "asset", id)
+			return
+		}
+	}
+	if request.TypeID == "" || s.store.relationType(request.TypeID) == nil {
+		notFound(w, "relationType", request.TypeID)
+		return
+	}
+	created := &relation{id: uuid.NewString(), sourceID: request.SourceID, targetID: request.TargetID, typeID: request.TypeID}
+	s.store.relations = append(s.store.relations, created)
+	writeJSON(w, http.StatusCreated, s.relationJSON(created))
+}
+
+func (s *Server) deleteRelation(w http.ResponseWriter, r *http.Request) {
+	for i, rel := range s.store.relations {
+		if rel.id == r.PathValue("id") {
+			s.store.relations = slices.Delete(s.store.relations, i, i+1)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	notFound(w, "relation", r.PathValue("id"))
+}
+
+// listResponsibilities answers that nobody is responsible for anything; the
+// fixture does not describe responsibilities.
+func (s *Server) listResponsibilities(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, offsetPage(r, []any{}))
+}
+
+func (s *Server) currentUser(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"id":           fakeUserID,
+		"userName":     fakeUser,
+		"firstName":    "Fake",
+		"lastName":     "Collibra",
+		"emailAddress": fakeUser + "@example.com",
+		"enabled":      true,
+	})
+}
+
+func (s *Server) globalPermissions(w http.ResponseWriter, _ *http.Request) {
+	permissions := s.store.permissions
+	if permissions == nil {
+		permissions = []string{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"globalPermissions": permissions})
+}
+
+// matchName reports whether name matches the name filter of a list request,
+// ignoring case. Collibra matches anywhere in the name by default; the fake
+// matches the whole name unless the request sets nameMatchMode, as the
+// duplicate checks expect.
+func matchName(name, filter, mode string) bool {
+	name, filter = strings.ToLower(name), strings.ToLower(filter)
+	switch mode {
+	case "START":
+		return strings.HasPrefix(name, filter)
+	case "END":
+		return strings.HasSuffix(name, filter)
+	case "ANYWHERE":
+		return strings.Contains(name, filter)
+	default:
+		return filter == "" || name == filter
+	}
+}
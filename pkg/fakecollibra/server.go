@@ -0,0 +1,130 @@
+// Package fakecollibra is an in-memory Collibra for running chip and its
+// tools end to end without a Collibra instance. It serves the subset of the
+// REST 2.0, Knowledge Graph GraphQL, lineage, assessments, data contract and
+// data quality APIs that chip's clients call, on a catalog seeded from a
+// Fixture. Changes made through the API are kept in memory for the lifetime
+// of the server.
+//
+// The responses are written from the Collibra API contracts, not from the
+// types in pkg/clients, so that a client decoding a response differently
+// from Collibra fails against the fake too. Endpoints that are not
+// implemented answer 501 Not Implemented.
+package fakecollibra
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+// Server is a fake Collibra. It is an http.Handler; serve it on the URL chip
+// is configured with.
+type Server struct {
+	mu    sync.Mutex
+	store *store
+	mux   *http.ServeMux
+}
+
+// New returns a fake Collibra serving the catalog of fixture.
+func New(fixture *Fixture) (*Server, error) {
+	s, err := newStore(fixture)
+	if err != nil {
+		return nil, err
+	}
+	server := &Server{store: s, mux: http.NewServeMux()}
+	server.registerREST()
+	server.registerGraphQL()
+	server.registerLineage()
+	server.registerAssessments()
+	server.registerDataContracts()
+	server.registerDataQuality()
+	server.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		slog.Warn(fmt.Sprintf("fake-collibra: %s %s is not implemented", r.Method, r.URL.Path))
+		writeError(w, http.StatusNotImplemented, "notImplemented", "%s %s is not implemented by fake-collibra", r.Method, r.URL.Path)
+	})
+	return server, nil
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) handle(pattern string, handler func(w http.ResponseWriter, r *http.Request)) {
+	s.mux.HandleFunc(pattern, handler)
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+// writeError writes the standard Collibra error envelope.
+func writeError(w http.ResponseWriter, status int, errorCode string, format string, args ...any) {
+	message := fmt.Sprintf(format, args...)
+	writeJSON(w, status, map[string]any{
+		"statusCode":   status,
+		"errorCode":    errorCode,
+		"titleMessage": http.StatusText(status),
+		"userMessage":  message,
+	})
+}
+
+func notFound(w http.ResponseWriter, kind, id string) {
+	writeError(w, http.StatusNotFound, kind+"NotFound", "%s with id %s not found", kind, id)
+}
+
+// decode reads the JSON body of r into value, answering 400 when it is not
+// valid.
+func decode(w http.ResponseWriter, r *http.Request, value any) bool {
+	if err := json.NewDecoder(r.Body).Decode(value); err != nil {
+		writeError(w, http.StatusBadRequest, "invalidRequestBody", "invalid request body: %v", err)
+		return false
+	}
+	return true
+}
+
+func queryInt(r *http.Request, name string, fallback int) int {
+	value, err := strconv.Atoi(r.URL.Query().Get(name))
+	if err != nil || value < 0 {
+		return fallback
+	}
+	return value
+}
+
+// offsetPage returns the page of items selected by the offset and limit
+// query parameters of r, as the REST 2.0 list endpoints do. A limit of 0
+// returns every item from the offset on.
+func offsetPage[T any](r *http.Request, items []T) map[string]any {
+	offset, limit := queryInt(r, "offset", 0), queryInt(r, "limit", 0)
+	page := items[min(offset, len(items)):]
+	if limit > 0 && limit < len(page) {
+		page = page[:limit]
+	}
+	if page == nil {
+		page = []T{}
+	}
+	return map[string]any{"total": len(items), "offset": offset, "limit": limit, "results": page}
+}
+
+// cursorPage returns the page of items after cursor, as the cursor paged
+// APIs do, and the cursor of the next page. The fake's cursors are offsets.
+func cursorPage[T any](r *http.Request, items []T, defaultLimit int) ([]T, string) {
+	offset := min(queryInt(r, "cursor", 0), len(items))
+	limit := queryInt(r, "limit", defaultLimit)
+	page := items[offset:]
+	next := ""
+	if limit > 0 && limit < len(page) {
+		page = page[:limit]
+		next = strconv.Itoa(offset + limit)
+	}
+	if page == nil {
+		page = []T{}
+	}
+	return page, next
+}
@@ -0,0 +1,442 @@
+package fakecollibra
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Ids of out-of-the-box attribute types that tools look up by id.
+var wellKnownAttributeTypes = map[string]string{
+	"Definition":  "00000000-0000-0000-0000-000000000202",
+	"Description": "00000000-0000-0000-0000-000000003114",
+}
+
+// defaultCommunity holds the domains that are referred to without a
+// community.
+const defaultCommunity = "Fake Community"
+
+type community struct {
+	id, name, description, parentID string
+}
+
+type domain struct {
+	id, name, communityID, typeID string
+}
+
+type named struct {
+	id, name string
+}
+
+type assetType struct {
+	id, publicID, name, description, parentID string
+}
+
+type asset struct {
+	id, name, displayName, typeID, domainID, statusID string
+	createdOn, lastModifiedOn                         int64
+}
+
+type attributeType struct {
+	id, name, kind string
+}
+
+type attribute struct {
+	id, assetID, typeID string
+	value               any
+}
+
+type relationType struct {
+	id, role, coRole, sourceTypeID, targetTypeID string
+}
+
+type relation struct {
+	id, sourceID, targetID, typeID string
+}
+
+type lineageEntity struct {
+	id, name, entityType, parentID, dgcID string
+}
+
+type assessment struct {
+	id, name, status, templateID, templateName, assetID string
+	questions                                           []Question
+}
+
+type dqJob struct {
+	DQJob
+	runs []*DQJobRun
+}
+
+// store is the catalog of a fake Collibra. The server guards it with its
+// mutex.
+type store struct {
+	communities      []*community
+	domains          []*domain
+	domainTypes      []*named
+	assetTypes       []*assetType
+	statuses         []*named
+	roles            []*named
+	assets           []*asset
+	attributeTypes   []*attributeType
+	attributes       []*attribute
+	relationTypes    []*relationType
+	relations        []*relation
+	lineageEntities  []*lineageEntity
+	lineageRelations []LineageRelation
+	transformations  []LineageTransformation
+	assessments      []*assessment
+	dataContracts    []DataContract
+	dqJobs           []*dqJob
+	dqRuleTemplates  []DQRuleTemplate
+	permissions      []string
+}
+
+// stableID derives the id of a resource from its kind and name, so fixtures
+// can leave ids out and still be referred to by id across runs.
+func stableID(kind, name string) string {
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte("fakecollibra/"+kind+"/"+name)).String()
+}
+
+func idOr(id, kind, name string) string {
+	if id != "" {
+		return id
+	}
+	return stableID(kind, name)
+}
+
+// find returns the item whose id or name is ref.
+func find[T any](items []*T, ref string, id, name func(*T) string) *T {
+	for _, item := range items {
+		if id(item) == ref {
+			return item
+		}
+	}
+	for _, item := range items {
+		if strings.EqualFold(name(item), ref) {
+			return item
+		}
+	}
+	return nil
+}
+
+func newStore(fixture *Fixture) (*store, error) {
+	s := &store{permissions: fixture.GlobalPermissions}
+	for _, st := range fixture.Statuses {
+		s.statusRef(idOr(st.ID, "status", st.Name), st.Name)
+	}
+	for _, role := range fixture.Roles {
+		s.roles = append(s.roles, &named{id: idOr(role.ID, "role", role.Name), name: role.Name})
+	}
+	for _, at := range fixture.AssetTypes {
+		t := s.assetTypeRef(at.ID, at.Name)
+		t.description = at.Description
+		if at.PublicID != "" {
+			t.publicID = at.PublicID
+		}
+		if at.Parent != "" {
+			t.parentID = s.assetTypeRef("", at.Parent).id
+		}
+	}
+	for _, c := range fixture.Communities {
+		com := s.communityRef(c.ID, c.Name)
+		com.description = c.Description
+		if c.Parent != "" {
+			com.parentID = s.communityRef("", c.Parent).id
+		}
+	}
+	for _, d := range fixture.Domains {
+		s.domainRef(d.ID, d.Name, d.Community, d.Type)
+	}
+
+	now := time.Now().UnixMilli()
+	for _, a := range fixture.Assets {
+		if a.Name == "" || a.Type == "" || a.Domain == "" {
+			return nil, fmt.Errorf("asset %q: name, type and domain are required", a.Name)
+		}
+		domain := s.domainRef("", a.Domain, "", "")
+		created := &asset{
+			id:             idOr(a.ID, "asset", domain.name+"/"+a.Name),
+			name:           a.Name,
+			displayName:    a.DisplayName,
+			typeID:         s.assetTypeRef("", a.Type).id,
+			domainID:       domain.id,
+			createdOn:      now,
+			lastModifiedOn: now,
+		}
+		if created.displayName == "" {
+			created.displayName = a.Name
+		}
+		if a.Status != "" {
+			created.statusID = s.statusRef("", a.Status).id
+		}
+		s.assets = append(s.assets, created)
+		for _, name := range slices.Sorted(maps.Keys(a.Attributes)) {
+			value := a.Attributes[name]
+			s.attributes = append(s.attributes, &attribute{
+				id:      stableID("attribute", created.id+"/"+name),
+				assetID: created.id,
+				typeID:  s.attributeTypeRef(name, value).id,
+				value:   value,
+			})
+		}
+	}
+
+	for _, r := range fixture.Relations {
+		source, target := s.asset(r.Source), s.asset(r.Target)
+		if source == nil || target == nil {
+			return nil, fmt.Errorf("relation %s -> %s: unknown asset", r.Source, r.Target)
+		}
+		if r.Type == "" && r.TypeID == "" {
+			return nil, fmt.Errorf("relation %s -> %s: type or typeId is required", r.Source, r.Target)
+		}
+		rt := s.relationTypeRef(r.TypeID, r.Type, r.CoRole, source.typeID, target.typeID)
+		s.relations = append(s.relations, &relation{
+			id:       idOr(r.ID, "relation", source.id+"/"+rt.id+"/"+target.id),
+			sourceID: source.id,
+			targetID: target.id,
+			typeID:   rt.id,
+		})
+	}
+
+	for _, e := range fixture.Lineage.Entities {
+		entity := &lineageEntity{id: e.ID, name: e.Name, entityType: e.Type, parentID: e.Parent}
+		if entity.id == "" {
+			entity.id = stableID("lineageEntity", e.Name)
+		}
+		if e.Asset != "" {
+			stitched := s.asset(e.Asset)
+			if stitched == nil {
+				return nil, fmt.Errorf("lineage entity %s: unknown asset %s", e.Name, e.Asset)
+			}
+			entity.dgcID = stitched.id
+		}
+		s.lineageEntities = append(s.lineageEntities, entity)
+	}
+	for _, t := range fixture.Lineage.Transformations {
+		t.ID = idOr(t.ID, "transformation", t.Name)
+		s.transformations = append(s.transformations, t)
+	}
+	for _, r := range fixture.Lineage.Relations {
+		source, target := s.lineageEntity(r.Source), s.lineageEntity(r.Target)
+		if source == nil || target == nil {
+			return nil, fmt.Errorf("lineage relation %s -> %s: unknown entity", r.Source, r.Target)
+		}
+		transformationIDs := []string{}
+		for _, ref := range r.Transformations {
+			t := s.transformation(ref)
+			if t == nil {
+				return nil, fmt.Errorf("lineage relation %s -> %s: unknown transformation %s", r.Source, r.Target, ref)
+			}
+			transformationIDs = append(transformationIDs, t.ID)
+		}
+		s.lineageRelations = append(s.lineageRelations, LineageRelation{Source: source.id, Target: target.id, Transformations: transformationIDs})
+	}
+
+	for _, a := range fixture.Assessments {
+		created := &assessment{
+			id:           idOr(a.ID, "assessment", a.Name),
+			name:         a.Name,
+			status:       a.Status,
+			templateID:   stableID("assessmentTemplate", a.Template),
+			templateName: a.Template,
+			questions:    a.Questions,
+		}
+		if created.status == "" {
+			created.status = "DRAFT"
+		}
+		if a.Asset != "" {
+			assessed := s.asset(a.Asset)
+			if assessed == nil {
+				return nil, fmt.Errorf("assessment %s: unknown asset %s", a.Name, a.Asset)
+			}
+			created.assetID = assessed.id
+		}
+		for i := range created.questions {
+			if created.questions[i].ID == "" {
+				created.questions[i].ID = stableID("question", created.id+"/"+created.questions[i].Name)
+			}
+		}
+		s.assessments = append(s.assessments, created)
+	}
+
+	for _, dc := range fixture.DataContracts {
+		dc.ID = idOr(dc.ID, "dataContract", dc.ManifestID)
+		dc.Domain = s.domainRef("", dc.Domain, "", "").id
+		s.dataContracts = append(s.dataContracts, dc)
+	}
+
+	for _, job := range fixture.DQJobs {
+		created := &dqJob{DQJob: job}
+		if created.JobType == "" {
+			created.JobType = "PUSHDOWN"
+		}
+		for i := range job.Runs {
+			run := job.Runs[i]
+			if run.ID == "" {
+				run.ID = stableID("dqJobRun", job.Name+"/"+run.RunDate)
+			}
+			created.runs = append(created.runs, &run)
+		}
+		s.dqJobs = append(s.dqJobs, created)
+	}
+
+	for _, template := range fixture.DQRuleTemplates {
+		template.ID = idOr(template.ID, "dqRuleTemplate", template.Name)
+		s.dqRuleTemplates = append(s.dqRuleTemplates, template)
+	}
+	return s, nil
+}
+
+func (s *store) statusRef(id, name string) *named {
+	if st := find(s.statuses, name, namedID, namedName); st != nil {
+		return st
+	}
+	st := &named{id: idOr(id, "status", name), name: name}
+	s.statuses = append(s.statuses, st)
+	return st
+}
+
+func (s *store) assetTypeRef(id, name string) *assetType {
+	if t := s.assetType(name); t != nil {
+		return t
+	}
+	t := &assetType{id: idOr(id, "assetType", name), publicID: strings.ReplaceAll(name, " ", ""), name: name}
+	s.assetTypes = append(s.assetTypes, t)
+	return t
+}
+
+func (s *store) communityRef(id, name string) *community {
+	if c := find(s.communities, name, func(c *community) string { return c.id }, func(c *community) string { return c.name }); c != nil {
+		return c
+	}
+	c := &community{id: idOr(id, "community", name), name: name}
+	s.communities = append(s.communities, c)
+	return c
+}
+
+func (s *store) domainRef(id, name, communityName, typeName string) *domain {
+	if d := s.domain(name); d != nil {
+		return d
+	}
+	if communityName == "" {
+		communityName = defaultCommunity
+	}
+	if typeName == "" {
+		typeName = "Business Asset Domain"
+	}
+	domainType := find(s.domainTypes, typeName, namedID, namedName)
+	if domainType == nil {
+		domainType = &named{id: stableID("domainType", typeName), name: typeName}
+		s.domainTypes = append(s.domainTypes, domainType)
+	}
+	d := &domain{
+		id:          idOr(id, "domain", name),
+		name:        name,
+		communityID: s.communityRef("", communityName).id,
+		typeID:      domainType.id,
+	}
+	s.domains = append(s.domains, d)
+	return d
+}
+
+// attributeTypeRef returns the attribute type called name, creating it with
+// the kind of value.
+func (s *store) attributeTypeRef(name string, value any) *attributeType {
+	if t := s.attributeType(name); t != nil {
+		return t
+	}
+	kind := "StringAttributeType"
+	switch value.(type) {
+	case int, int64, float64:
+		kind = "NumericAttributeType"
+	case bool:
+		kind = "BooleanAttributeType"
+	}
+	id, ok := wellKnownAttributeTypes[name]
+	if !ok {
+		id = stableID("attributeType", name)
+	}
+	t := &attributeType{id: id, name: name, kind: kind}
+	s.attributeTypes = append(s.attributeTypes, t)
+	return t
+}
+
+func (s *store) relationTypeRef(id, role, coRole, sourceTypeID, targetTypeID string) *relationType {
+	for _, rt := range s.relationTypes {
+		if (id != "" && rt.id == id) || (id == "" && strings.EqualFold(rt.role, role)) {
+			return rt
+		}
+	}
+	rt := &relationType{
+		id:           idOr(id, "relationType", role),
+		role:         role,
+		coRole:       coRole,
+		sourceTypeID: sourceTypeID,
+		targetTypeID: targetTypeID,
+	}
+	s.relationTypes = append(s.relationTypes, rt)
+	return rt
+}
+
+func namedID(n *named) string   { return n.id }
+func namedName(n *named) string { return n.name }
+
+func (s *store) asset(ref string) *asset {
+	return find(s.assets, ref, func(a *asset) string { return a.id }, func(a *asset) string { return a.name })
+}
+
+func (s *store) assetType(ref string) *assetType {
+	return find(s.assetTypes, ref, func(t *assetType) string { return t.id }, func(t *assetType) string { return t.name })
+}
+
+func (s *store) domain(ref string) *domain {
+	return find(s.domains, ref, func(d *domain) string { return d.id }, func(d *domain) string { return d.name })
+}
+
+func (s *store) community(ref string) *community {
+	return find(s.communities, ref, func(c *community) string { return c.id }, func(c *community) string { return c.name })
+}
+
+func (s *store) status(ref string) *named {
+	return find(s.statuses, ref, namedID, namedName)
+}
+
+func (s *store) attributeType(ref string) *attributeType {
+	return find(s.attributeTypes, ref, func(t *attributeType) string { return t.id }, func(t *attributeType) string { return t.name })
+}
+
+func (s *store) relationType(id string) *relationType {
+	for _, t := range s.relationTypes {
+		if t.id == id {
+			return t
+		}
+	}
+	return nil
+}
+
+func (s *store) lineageEntity(ref string) *lineageEntity {
+	return find(s.lineageEntities, ref, func(e *lineageEntity) string { return e.id }, func(e *lineageEntity) string { return e.name })
+}
+
+func (s *store) transformation(ref string) *LineageTransformation {
+	for i, t := range s.transformations {
+		if t.ID == ref || strings.EqualFold(t.Name, ref) {
+			return &s.transformations[i]
+		}
+	}
+	return nil
+}
+
+func (s *store) dqJob(name string) *dqJob {
+	for _, job := range s.dqJobs {
+		if job.Name == name {
+			return job
+		}
+	}
+	return nil
+}
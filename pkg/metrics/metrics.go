@@ -0,0 +1,127 @@
+// Package metrics exposes Prometheus metrics for chip: per-tool call counts,
+// latencies and errors observed in the tool middleware chain, and per-API
+// family status codes and latencies of the upstream Collibra requests made
+// by the tools.
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/collibra/chip/pkg/chip"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "chip"
+
+// Metrics owns a dedicated Prometheus registry so chip's metrics do not mix
+// with anything registered on the global default registry by dependencies.
+type Metrics struct {
+	registry         *prometheus.Registry
+	toolCalls        *prometheus.CounterVec
+	toolErrors       *prometheus.CounterVec
+	toolDuration     *prometheus.HistogramVec
+	upstreamRequests *prometheus.CounterVec
+	upstreamDuration *prometheus.HistogramVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		toolCalls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tool_calls_total",
+			Help:      "Number of MCP tool calls, by tool and outcome (success or error).",
+		}, []string{"tool", "outcome"}),
+		toolErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tool_errors_total",
+			Help:      "Number of MCP tool calls that returned an error, by tool and error code (see chip.ErrorCode).",
+		}, []string{"tool", "code"}),
+		toolDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "tool_call_duration_seconds",
+			Help:      "Latency of MCP tool calls, by tool.",
+			// Tools range from a single lookup to fan-outs of dozens of
+			// upstream requests, so the buckets extend to a minute.
+			Buckets: []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+		}, []string{"tool"}),
+		upstreamRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "upstream_requests_total",
+			Help:      "Number of requests sent to Collibra, by API family, method and HTTP status (\"error\" for transport failures).",
+		}, []string{"api_family", "method", "status"}),
+		upstreamDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "upstream_request_duration_seconds",
+			Help:      "Latency of requests sent to Collibra, by API family and method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"api_family", "method"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.toolCalls,
+		m.toolErrors,
+		m.toolDuration,
+		m.upstreamRequests,
+		m.upstreamDuration,
+	)
+	return m
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// ToolMiddleware records every tool call. Install it first so its timing
+// covers the rest of the middleware chain. A call counts as an error when
+// the handler returns a Go error or an isError result; the error is counted
+// under its chip.ErrorCode.
+func (m *Metrics) ToolMiddleware() chip.ToolMiddleware {
+	return chip.ToolMiddlewareFunc(func(ctx context.Context, toolRequest *mcp.CallToolRequest, next chip.CallToolFunc) (*mcp.CallToolResult, error) {
+		tool := toolRequest.Params.Name
+		start := time.Now()
+		res, err := next(ctx, toolRequest)
+		m.toolDuration.WithLabelValues(tool).Observe(time.Since(start).Seconds())
+
+		outcome := "success"
+		if err != nil || (res != nil && res.IsError) {
+			outcome = "error"
+			m.toolErrors.WithLabelValues(tool, string(chip.ResultErrorCode(res, err))).Inc()
+		}
+		m.toolCalls.WithLabelValues(tool, outcome).Inc()
+		return res, err
+	})
+}
+
+// Transport wraps an upstream RoundTripper and records the status and
+// latency of every request it sends, grouped by chip.APIFamily.
+func (m *Metrics) Transport(next http.RoundTripper) http.RoundTripper {
+	return &transport{metrics: m, next: next}
+}
+
+type transport struct {
+	metrics *Metrics
+	next    http.RoundTripper
+}
+
+func (t *transport) RoundTrip(request *http.Request) (*http.Response, error) {
+	family := chip.APIFamily(request.URL.Path)
+	start := time.Now()
+	response, err := t.next.RoundTrip(request)
+	t.metrics.upstreamDuration.WithLabelValues(family, request.Method).Observe(time.Since(start).Seconds())
+
+	status := "error"
+	if err == nil {
+		status = strconv.Itoa(response.StatusCode)
+	}
+	t.metrics.upstreamRequests.WithLabelValues(family, request.Method, status).Inc()
+	return response, err
+}
@@ -0,0 +1,100 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/collibra/chip/pkg/chip"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func scrape(t *testing.T, m *Metrics) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, _ := io.ReadAll(rec.Body)
+	return string(body)
+}
+
+func assertContains(t *testing.T, body string, lines ...string) {
+	t.Helper()
+	for _, line := range lines {
+		if !strings.Contains(body, line) {
+			t.Errorf("metrics output missing %q", line)
+		}
+	}
+}
+
+func TestToolMiddleware_RecordsCallsAndErrors(t *testing.T) {
+	m := New()
+	mw := m.ToolMiddleware()
+	request := &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: "search_asset_keyword"}}
+
+	ok := func(context.Context, *mcp.CallToolRequest) (*mcp.CallToolResult, error) { return nil, nil }
+	failed := func(context.Context, *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return nil, errors.New("boom")
+	}
+	isError := func(context.Context, *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return &mcp.CallToolResult{IsError: true}, nil
+	}
+	notFound := func(context.Context, *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return nil, notFoundError{}
+	}
+	denied := func(context.Context, *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return &mcp.CallToolResult{
+			IsError: true,
+			Meta:    mcp.Meta{chip.ErrorMetaKey: map[string]any{"code": chip.ErrorPermissionDenied}},
+		}, nil
+	}
+
+	for _, next := range []chip.CallToolFunc{ok, ok, failed, isError, notFound, denied} {
+		_, _ = mw.ToolHandle(t.Context(), request, next)
+	}
+
+	assertContains(t, scrape(t, m),
+		`chip_tool_calls_total{outcome="success",tool="search_asset_keyword"} 2`,
+		`chip_tool_calls_total{outcome="error",tool="search_asset_keyword"} 4`,
+		`chip_tool_errors_total{code="internal",tool="search_asset_keyword"} 2`,
+		`chip_tool_errors_total{code="not_found",tool="search_asset_keyword"} 1`,
+		`chip_tool_errors_total{code="permission_denied",tool="search_asset_keyword"} 1`,
+		`chip_tool_call_duration_seconds_count{tool="search_asset_keyword"} 6`,
+	)
+}
+
+type notFoundError struct{}
+
+func (notFoundError) Error() string { return "asset not found" }
+
+func (notFoundError) ErrorCode() chip.ErrorCode { return chip.ErrorNotFound }
+
+func TestTransport_RecordsUpstreamStatusByAPIFamily(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/rest/dq") {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	m := New()
+	client := &http.Client{Transport: m.Transport(http.DefaultTransport)}
+	for _, path := range []string{"/rest/2.0/assets", "/rest/2.0/search", "/rest/dq/1.0/jobs"} {
+		resp, err := client.Get(server.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+	}
+	_, _ = client.Get("http://127.0.0.1:0/graphql/knowledgeGraph/v1")
+
+	assertContains(t, scrape(t, m),
+		`chip_upstream_requests_total{api_family="/rest/2.0",method="GET",status="200"} 2`,
+		`chip_upstream_requests_total{api_family="/rest/dq",method="GET",status="503"} 1`,
+		`chip_upstream_requests_total{api_family="/graphql/knowledgeGraph",method="GET",status="error"} 1`,
+		`chip_upstream_request_duration_seconds_count{api_family="/rest/2.0",method="GET"} 2`,
+	)
+}
@@ -0,0 +1,222 @@
+// Package permissions checks the Collibra global permissions of the caller
+// against the permissions a tool requires (chip.ToolMetadata.Permissions), so
+// a call the caller is not allowed to make fails before reaching Collibra, and
+// tools/list only shows the tools the caller can use.
+package permissions
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/collibra/chip/pkg/chip"
+	"github.com/collibra/chip/pkg/clients"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// DefaultTTL is how long the permissions of a caller are reused before they
+// are fetched again.
+const DefaultTTL = 5 * time.Minute
+
+// maxCachedCallers bounds the number of callers whose permissions are kept.
+const maxCachedCallers = 1000
+
+// globalPermissions maps the permissions of the tools
+// (chip.ToolMetadata.Permissions) to the Collibra global permissions they
+// stand for. A tool permission must be listed here to be checked.
+var globalPermissions = map[string]string{
+	"dgc.ai-copilot":        "AI_COPILOT",
+	"dgc.catalog":           "CATALOG",
+	"dgc.classify":          "CLASSIFY",
+	"dgc.data-classes-edit": "DATA_CLASSES_EDIT",
+	"dgc.data-classes-read": "DATA_CLASSES_READ",
+	"dgc.data-contract":     "DATA_CONTRACT",
+}
+
+// GlobalPermission returns the Collibra global permission identifier a tool
+// permission stands for, and false when the permission is not known.
+func GlobalPermission(permission string) (string, bool) {
+	global, ok := globalPermissions[permission]
+	return global, ok
+}
+
+// MissingPermissionError reports the permissions a caller lacks to use a
+// tool.
+type MissingPermissionError struct {
+	Tool    string
+	Missing []string
+}
+
+func (e *MissingPermissionError) Error() string {
+	global := make([]string, len(e.Missing))
+	for i, permission := range e.Missing {
+		global[i], _ = GlobalPermission(permission)
+	}
+	return fmt.Sprintf("missing permission %s (%s) to use %s", strings.Join(e.Missing, ", "), strings.Join(global, ", "), e.Tool)
+}
+
+func (e *MissingPermissionError) ErrorCode() chip.ErrorCode {
+	return chip.ErrorPermissionDenied
+}
+
+func (e *MissingPermissionError) ErrorDetails() map[string]any {
+	return map[string]any{"permissions": e.Missing}
+}
+
+// Checker resolves the global permissions of callers and caches them per
+// Collibra instance and credentials.
+type Checker struct {
+	client *http.Client
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu      sync.Mutex
+	callers map[string]cachedPermissions
+}
+
+type cachedPermissions struct {
+	permissions []string
+	fetchedAt   time.Time
+}
+
+// New returns a Checker that fetches permissions with client, the same
+// client the tools call Collibra with.
+func New(client *http.Client, ttl time.Duration) *Checker {
+	return &Checker{client: client, ttl: ttl, now: time.Now, callers: make(map[string]cachedPermissions)}
+}
+
+// ToolMiddleware fails calls to tools whose permissions the caller lacks with
+// a *MissingPermissionError. When the permissions cannot be resolved, e.g.
+// because the call carries no credentials, the call goes ahead and Collibra
+// decides.
+func (c *Checker) ToolMiddleware() chip.ToolMiddleware {
+	return chip.ToolMiddlewareFunc(func(ctx context.Context, toolRequest *mcp.CallToolRequest, next chip.CallToolFunc) (*mcp.CallToolResult, error) {
+		metadata, ok := chip.GetToolMetadata(ctx)
+		if !ok || len(metadata.Permissions) == 0 {
+			return next(ctx, toolRequest)
+		}
+		granted, err := c.permissions(ctx, toolRequest)
+		if err != nil {
+			slog.WarnContext(ctx, fmt.Sprintf("Unable to check the permissions for %s: %v", metadata.Name, err))
+			return next(ctx, toolRequest)
+		}
+		if missing := missingPermissions(metadata.Permissions, granted); len(missing) > 0 {
+			return nil, &MissingPermissionError{Tool: metadata.Name, Missing: missing}
+		}
+		return next(ctx, toolRequest)
+	})
+}
+
+// FilterTools is a receiving middleware that removes the tools the caller
+// lacks permissions for from tools/list results. Only install it for
+// stateful sessions, where the listed tools are those the session can call.
+// The permissions are checked on the Collibra instance that route, the tool
+// middleware that routes tool calls, selects for the list request, e.g. from
+// its _meta or headers.
+func (c *Checker) FilterTools(server *chip.Server, route chip.ToolMiddleware) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			res, err := next(ctx, method, req)
+			listRequest, ok := req.(*mcp.ListToolsRequest)
+			if err != nil || !ok {
+				return res, err
+			}
+			listResult, ok := res.(*mcp.ListToolsResult)
+			if !ok {
+				return res, err
+			}
+			// The Collibra client expects a tool call in the context; it
+			// forwards the credentials of the list request.
+			toolRequest := &mcp.CallToolRequest{
+				Session: listRequest.Session,
+				Params:  &mcp.CallToolParamsRaw{Name: method},
+				Extra:   listRequest.Extra,
+			}
+			if listRequest.Params != nil {
+				toolRequest.Params.Meta = listRequest.Params.Meta
+			}
+			var granted []string
+			_, permErr := route.ToolHandle(chip.SetCallToolRequest(ctx, toolRequest), toolRequest, func(ctx context.Context, toolRequest *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				var err error
+				granted, err = c.permissions(ctx, toolRequest)
+				return &mcp.CallToolResult{}, err
+			})
+			if permErr != nil {
+				slog.WarnContext(ctx, fmt.Sprintf("Listing every tool, unable to check permissions: %v", permErr))
+				return res, err
+			}
+			listResult.Tools = slices.DeleteFunc(slices.Clone(listResult.Tools), func(tool *mcp.Tool) bool {
+				metadata := server.GetToolMetadata(tool.Name)
+				return metadata != nil && len(missingPermissions(metadata.Permissions, granted)) > 0
+			})
+			return listResult, nil
+		}
+	}
+}
+
+// permissions returns the global permissions of the caller of toolRequest.
+func (c *Checker) permissions(ctx context.Context, toolRequest *mcp.CallToolRequest) ([]string, error) {
+	key := callerKey(ctx, toolRequest)
+	c.mu.Lock()
+	cached, ok := c.callers[key]
+	c.mu.Unlock()
+	if ok && c.now().Sub(cached.fetchedAt) < c.ttl {
+		return cached.permissions, nil
+	}
+
+	granted, err := clients.GetCurrentUserGlobalPermissions(ctx, c.client)
+	if err != nil {
+		return nil, err
+	}
+	c.store(key, cachedPermissions{permissions: granted, fetchedAt: c.now()})
+	return granted, nil
+}
+
+// store caches the permissions of a caller. The expired permissions of other
+// callers are dropped and, when too many callers are still cached, those
+// fetched first.
+func (c *Checker) store(key string, cached cachedPermissions) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k, other := range c.callers {
+		if cached.fetchedAt.Sub(other.fetchedAt) >= c.ttl {
+			delete(c.callers, k)
+		}
+	}
+	for len(c.callers) >= maxCachedCallers {
+		oldest := ""
+		for k, other := range c.callers {
+			if oldest == "" || other.fetchedAt.Before(c.callers[oldest].fetchedAt) {
+				oldest = k
+			}
+		}
+		delete(c.callers, oldest)
+	}
+	c.callers[key] = cached
+}
+
+// callerKey identifies whose permissions apply: the Collibra instance the
+// call is routed to and the credentials it carries. Calls without
+// credentials share the server-wide account of the instance.
+func callerKey(ctx context.Context, toolRequest *mcp.CallToolRequest) string {
+	instance, _ := chip.GetCollibraInstance(ctx)
+	return instance + ":" + chip.CallerIdentity(ctx, toolRequest.GetExtra())
+}
+
+// missingPermissions returns the permissions of required that granted lacks.
+// Unknown permissions cannot be checked and are left to Collibra.
+func missingPermissions(required, granted []string) []string {
+	var missing []string
+	for _, permission := range required {
+		global, ok := GlobalPermission(permission)
+		if ok && !clients.HasPermission(granted, global) {
+			missing = append(missing, permission)
+		}
+	}
+	return missing
+}
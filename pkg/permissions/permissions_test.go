@@ -0,0 +1,255 @@
+package permissions
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/collibra/chip/pkg/chip"
+	"github.com/collibra/chip/pkg/tools"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// collibraServer answers the global permissions of the caller: "Bearer
+// steward" may classify, any other caller may only read data classes.
+func collibraServer(t *testing.T, calls *atomic.Int32) *http.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.URL.Path != "/rest/2.0/users/current/globalPermissions" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		permissions := []string{"DATA_CLASSES_READ"}
+		if r.Header.Get("Authorization") == "Bearer steward" {
+			permissions = append(permissions, "CLASSIFY", "CATALOG")
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"globalPermissions": permissions})
+	}))
+	t.Cleanup(server.Close)
+	baseURL, _ := url.Parse(server.URL)
+	return &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		r = r.Clone(r.Context())
+		r.URL.Scheme, r.URL.Host = baseURL.Scheme, baseURL.Host
+		if toolRequest, ok := chip.GetCallToolRequest(r.Context()); ok && toolRequest.Extra != nil {
+			r.Header.Set("Authorization", toolRequest.Extra.Header.Get("Authorization"))
+		}
+		return http.DefaultTransport.RoundTrip(r)
+	})}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+type input struct{}
+
+type output struct {
+	Done bool `json:"done"`
+}
+
+func newTool(name string, permissions ...string) *chip.Tool[input, output] {
+	return &chip.Tool[input, output]{
+		Name:        name,
+		Permissions: permissions,
+		Handler: func(context.Context, input) (output, error) {
+			return output{Done: true}, nil
+		},
+	}
+}
+
+func callAs(t *testing.T, checker *Checker, authorization string, metadata *chip.ToolMetadata) error {
+	t.Helper()
+	toolRequest := &mcp.CallToolRequest{
+		Params: &mcp.CallToolParamsRaw{Name: metadata.Name},
+		Extra:  &mcp.RequestExtra{Header: http.Header{"Authorization": {authorization}}},
+	}
+	ctx := chip.SetToolMetadata(chip.SetCallToolRequest(t.Context(), toolRequest), metadata)
+	_, err := checker.ToolMiddleware().ToolHandle(ctx, toolRequest, func(context.Context, *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return &mcp.CallToolResult{}, nil
+	})
+	return err
+}
+
+func TestToolMiddleware_FailsFastAndCachesPerCaller(t *testing.T) {
+	var calls atomic.Int32
+	checker := New(collibraServer(t, &calls), DefaultTTL)
+	now := time.Now()
+	checker.now = func() time.Time { return now }
+	classify := &chip.ToolMetadata{Name: "add_data_classification_match", Permissions: []string{"dgc.classify", "dgc.catalog"}}
+
+	if err := callAs(t, checker, "Bearer steward", classify); err != nil {
+		t.Fatalf("expected the steward to be allowed, got %v", err)
+	}
+	err := callAs(t, checker, "Bearer analyst", classify)
+	var missing *MissingPermissionError
+	if !errors.As(err, &missing) || len(missing.Missing) != 2 || missing.Missing[0] != "dgc.classify" {
+		t.Fatalf("expected a missing permission error, got %v", err)
+	}
+	if err.Error() != "missing permission dgc.classify, dgc.catalog (CLASSIFY, CATALOG) to use add_data_classification_match" {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+	_ = callAs(t, checker, "Bearer analyst", classify)
+	if err := callAs(t, checker, "Bearer analyst", &chip.ToolMetadata{Name: "search_asset_keyword"}); err != nil {
+		t.Errorf("expected a tool without permissions to be allowed, got %v", err)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("expected one lookup per caller, got %d", calls.Load())
+	}
+
+	now = now.Add(DefaultTTL)
+	_ = callAs(t, checker, "Bearer analyst", classify)
+	if calls.Load() != 3 {
+		t.Errorf("expected expired permissions to be fetched again, got %d lookups", calls.Load())
+	}
+}
+
+func TestChecker_EvictsExpiredAndExcessCallers(t *testing.T) {
+	checker := New(nil, DefaultTTL)
+	now := time.Now()
+	checker.store("expired", cachedPermissions{fetchedAt: now.Add(-DefaultTTL)})
+	for i := range maxCachedCallers {
+		checker.store(strconv.Itoa(i), cachedPermissions{fetchedAt: now.Add(time.Duration(i) * time.Millisecond)})
+	}
+	if _, ok := checker.callers["expired"]; ok {
+		t.Error("expected the expired permissions to be dropped")
+	}
+	checker.store("new", cachedPermissions{fetchedAt: now.Add(time.Second)})
+	if len(checker.callers) != maxCachedCallers {
+		t.Errorf("expected at most %d cached callers, got %d", maxCachedCallers, len(checker.callers))
+	}
+	if _, ok := checker.callers["0"]; ok {
+		t.Error("expected the permissions fetched first to be dropped")
+	}
+	if _, ok := checker.callers["new"]; !ok {
+		t.Error("expected the new permissions to be cached")
+	}
+}
+
+func TestToolMiddleware_LetsTheCallThroughWhenPermissionsAreUnavailable(t *testing.T) {
+	client := &http.Client{Transport: roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	})}
+	err := callAs(t, New(client, DefaultTTL), "", &chip.ToolMetadata{Name: "discover_data_assets", Permissions: []string{"dgc.ai-copilot"}})
+	if err != nil {
+		t.Errorf("expected the call to go ahead, got %v", err)
+	}
+}
+
+// routeByMeta stands for the instance routing of chip: it routes the request
+// to the instance named by the "instance" key of its _meta, or to "default".
+var routeByMeta = chip.ToolMiddlewareFunc(func(ctx context.Context, toolRequest *mcp.CallToolRequest, next chip.CallToolFunc) (*mcp.CallToolResult, error) {
+	instance, ok := toolRequest.Params.GetMeta()["instance"].(string)
+	if !ok {
+		instance = "default"
+	}
+	return next(chip.SetCollibraInstance(ctx, instance), toolRequest)
+})
+
+// listToolsSession connects a client to a server with the tools of
+// TestFilterTools, whose tools/list results checker filters.
+func listToolsSession(t *testing.T, checker *Checker) *mcp.ClientSession {
+	t.Helper()
+	server := chip.NewServer()
+	chip.RegisterTool(server, newTool("search_asset_keyword"))
+	chip.RegisterTool(server, newTool("search_data_classes", "dgc.data-classes-read"))
+	chip.RegisterTool(server, newTool("add_data_classification_match", "dgc.classify", "dgc.catalog"))
+	server.AddReceivingMiddleware(checker.FilterTools(server, routeByMeta))
+
+	t1, t2 := mcp.NewInMemoryTransports()
+	if _, err := server.Connect(t.Context(), t1, nil); err != nil {
+		t.Fatal(err)
+	}
+	session, err := mcp.NewClient(&mcp.Implementation{Name: "client", Version: "v0.0.1"}, nil).Connect(t.Context(), t2, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = session.Close() })
+	return session
+}
+
+func listToolNames(t *testing.T, session *mcp.ClientSession, params *mcp.ListToolsParams) []string {
+	t.Helper()
+	tools, err := session.ListTools(t.Context(), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, tool := range tools.Tools {
+		names = append(names, tool.Name)
+	}
+	return names
+}
+
+func TestFilterTools(t *testing.T) {
+	var calls atomic.Int32
+	session := listToolsSession(t, New(collibraServer(t, &calls), DefaultTTL))
+
+	names := listToolNames(t, session, &mcp.ListToolsParams{})
+	if !slices.Equal(names, []string{"search_asset_keyword", "search_data_classes"}) {
+		t.Errorf("expected the tools the caller may use, got %v", names)
+	}
+}
+
+func TestFilterTools_ChecksTheInstanceTheListRequestSelects(t *testing.T) {
+	// The caller is a steward on prod only.
+	var calls atomic.Int32
+	client := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		calls.Add(1)
+		permissions := []string{"DATA_CLASSES_READ"}
+		if instance, _ := chip.GetCollibraInstance(r.Context()); instance == "prod" {
+			permissions = append(permissions, "CLASSIFY", "CATALOG")
+		}
+		body, _ := json.Marshal(map[string]any{"globalPermissions": permissions})
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{"Content-Type": {"application/json"}}, Body: io.NopCloser(bytes.NewReader(body)), Request: r}, nil
+	})}
+	session := listToolsSession(t, New(client, DefaultTTL))
+
+	if names := listToolNames(t, session, &mcp.ListToolsParams{}); slices.Contains(names, "add_data_classification_match") {
+		t.Errorf("expected the permissions on the default instance to apply, got %v", names)
+	}
+	if names := listToolNames(t, session, &mcp.ListToolsParams{Meta: mcp.Meta{"instance": "prod"}}); !slices.Contains(names, "add_data_classification_match") {
+		t.Errorf("expected the permissions on the selected instance to apply, got %v", names)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("expected the permissions to be fetched once per instance, got %d requests", calls.Load())
+	}
+}
+
+func TestGlobalPermission(t *testing.T) {
+	if got, ok := GlobalPermission("dgc.data-classes-edit"); !ok || got != "DATA_CLASSES_EDIT" {
+		t.Errorf("GlobalPermission = %s, %v", got, ok)
+	}
+	if got, ok := GlobalPermission("dgc.unknown"); ok {
+		t.Errorf("expected an unknown permission, got %s", got)
+	}
+}
+
+func TestGlobalPermission_KnowsThePermissionsOfEveryTool(t *testing.T) {
+	server := chip.NewServer()
+	toolConfig := &chip.ServerToolConfig{
+		EnableDebugTools: true,
+		Experimental:     []string{tools.ContextSpecificationsFeature, tools.DataQualityFeatureName, "skills"},
+	}
+	if err := tools.RegisterAll(server, http.DefaultClient, toolConfig); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range server.ToolNames() {
+		for _, permission := range server.GetToolMetadata(name).Permissions {
+			if _, ok := GlobalPermission(permission); !ok {
+				t.Errorf("%s: no global permission for %s", name, permission)
+			}
+		}
+	}
+}
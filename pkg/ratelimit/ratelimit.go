@@ -0,0 +1,198 @@
+// Package ratelimit throttles the requests chip sends to Collibra. Tools such
+// as get_table_semantics or the scoped-assignment walk can fan out to dozens
+// of requests at once; a token bucket bounds their rate and a semaphore the
+// number in flight, both globally and per API path prefix, so bursts queue
+// inside chip instead of being throttled by the Collibra tenant.
+package ratelimit
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// Limit bounds a class of requests. Zero values mean unlimited.
+type Limit struct {
+	// RequestsPerSecond is the sustained rate of the token bucket.
+	RequestsPerSecond float64
+	// Burst is the bucket size. When zero it defaults to RequestsPerSecond
+	// rounded up, so one second's worth of requests can go out at once.
+	Burst int
+	// MaxInFlight caps the requests whose response has not been fully read.
+	MaxInFlight int
+}
+
+// IsZero reports whether the limit does not restrict anything.
+func (l Limit) IsZero() bool {
+	return l.RequestsPerSecond <= 0 && l.MaxInFlight <= 0
+}
+
+// PathLimit applies a Limit to the requests whose path starts with Prefix,
+// e.g. "/rest/dq" or "/graphql/knowledgeGraph".
+type PathLimit struct {
+	Prefix string
+	Limit
+}
+
+// Config holds the global limit, shared by every request, and the per path
+// prefix limits. A request must satisfy both the global limit and the limit
+// of the longest prefix matching its path.
+type Config struct {
+	Global Limit
+	Paths  []PathLimit
+}
+
+// IsZero reports whether the configuration does not restrict anything.
+func (c Config) IsZero() bool {
+	if !c.Global.IsZero() {
+		return false
+	}
+	for _, p := range c.Paths {
+		if !p.IsZero() {
+			return false
+		}
+	}
+	return true
+}
+
+type Limiter struct {
+	global *bucket
+	paths  []pathBucket // longest prefix first
+}
+
+type pathBucket struct {
+	prefix string
+	*bucket
+}
+
+func New(config Config) *Limiter {
+	l := &Limiter{global: newBucket(config.Global)}
+	for _, p := range config.Paths {
+		l.paths = append(l.paths, pathBucket{prefix: strings.TrimSuffix(p.Prefix, "/"), bucket: newBucket(p.Limit)})
+	}
+	sort.SliceStable(l.paths, func(i, j int) bool { return len(l.paths[i].prefix) > len(l.paths[j].prefix) })
+	return l
+}
+
+// Transport wraps an upstream RoundTripper. Requests wait for a free
+// in-flight slot and a rate token before being sent; waiting ends early with
+// the context's error when the request is cancelled. A slot is released when
+// the response body is closed.
+func (l *Limiter) Transport(next http.RoundTripper) http.RoundTripper {
+	return &transport{limiter: l, next: next}
+}
+
+// bucketsFor returns the buckets a request must pass, narrowest first.
+func (l *Limiter) bucketsFor(path string) []*bucket {
+	buckets := make([]*bucket, 0, 2)
+	for _, p := range l.paths {
+		if path == p.prefix || strings.HasPrefix(path, p.prefix+"/") {
+			buckets = append(buckets, p.bucket)
+			break
+		}
+	}
+	return append(buckets, l.global)
+}
+
+type transport struct {
+	limiter *Limiter
+	next    http.RoundTripper
+}
+
+func (t *transport) RoundTrip(request *http.Request) (*http.Response, error) {
+	ctx := request.Context()
+	buckets := t.limiter.bucketsFor(request.URL.Path)
+
+	var acquired []*bucket
+	release := func() {
+		for _, b := range acquired {
+			b.release()
+		}
+	}
+	for _, b := range buckets {
+		if err := b.acquire(ctx); err != nil {
+			release()
+			return nil, err
+		}
+		acquired = append(acquired, b)
+	}
+	for _, b := range buckets {
+		if err := b.wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+
+	response, err := t.next.RoundTrip(request)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	response.Body = &releasingBody{ReadCloser: response.Body, release: release}
+	return response, nil
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	inFlight chan struct{}
+}
+
+func newBucket(limit Limit) *bucket {
+	b := &bucket{}
+	if limit.RequestsPerSecond > 0 {
+		burst := limit.Burst
+		if burst <= 0 {
+			burst = int(math.Ceil(limit.RequestsPerSecond))
+		}
+		b.limiter = rate.NewLimiter(rate.Limit(limit.RequestsPerSecond), burst)
+	}
+	if limit.MaxInFlight > 0 {
+		b.inFlight = make(chan struct{}, limit.MaxInFlight)
+	}
+	return b
+}
+
+func (b *bucket) acquire(ctx context.Context) error {
+	if b.inFlight == nil {
+		return nil
+	}
+	select {
+	case b.inFlight <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (b *bucket) release() {
+	if b.inFlight != nil {
+		<-b.inFlight
+	}
+}
+
+func (b *bucket) wait(ctx context.Context) error {
+	if b.limiter == nil {
+		return nil
+	}
+	return b.limiter.Wait(ctx)
+}
+
+// releasingBody frees the request's in-flight slots exactly once, when the
+// caller closes the response body.
+type releasingBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
@@ -0,0 +1,144 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// blockingServer holds every request until release is closed and records the
+// highest number of concurrent requests per path.
+func blockingServer(t *testing.T) (server *httptest.Server, release chan struct{}, peak func(path string) int32) {
+	t.Helper()
+	release = make(chan struct{})
+	var mu sync.Mutex
+	current, highest := map[string]int32{}, map[string]int32{}
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		current[r.URL.Path]++
+		highest[r.URL.Path] = max(highest[r.URL.Path], current[r.URL.Path])
+		mu.Unlock()
+		<-release
+		mu.Lock()
+		current[r.URL.Path]--
+		mu.Unlock()
+	}))
+	t.Cleanup(server.Close)
+	return server, release, func(path string) int32 {
+		mu.Lock()
+		defer mu.Unlock()
+		return highest[path]
+	}
+}
+
+func TestTransport_CapsInFlightRequestsPerPrefix(t *testing.T) {
+	server, release, peak := blockingServer(t)
+	limiter := New(Config{Paths: []PathLimit{{Prefix: "/rest/dq", Limit: Limit{MaxInFlight: 2}}}})
+	client := &http.Client{Transport: limiter.Transport(http.DefaultTransport)}
+
+	var wg sync.WaitGroup
+	for _, path := range []string{"/rest/dq/1.0/jobs", "/rest/dq/1.0/jobs", "/rest/dq/1.0/jobs", "/rest/dq/1.0/jobs", "/rest/2.0/assets", "/rest/2.0/assets", "/rest/2.0/assets"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(server.URL + path)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			_ = resp.Body.Close()
+		}()
+	}
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := peak("/rest/dq/1.0/jobs"); got != 2 {
+		t.Errorf("expected at most 2 concurrent /rest/dq requests, peak was %d", got)
+	}
+	if got := peak("/rest/2.0/assets"); got != 3 {
+		t.Errorf("expected /rest/2.0 requests to be unaffected, peak was %d", got)
+	}
+}
+
+func TestTransport_RateLimitsGlobally(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) { calls.Add(1) }))
+	defer server.Close()
+	limiter := New(Config{Global: Limit{RequestsPerSecond: 20, Burst: 1}})
+	client := &http.Client{Transport: limiter.Transport(http.DefaultTransport)}
+
+	start := time.Now()
+	for range 5 {
+		resp, err := client.Get(server.URL + "/graphql/knowledgeGraph/v1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+	}
+	// The first request uses the burst, the other four wait 50ms each.
+	if elapsed := time.Since(start); elapsed < 180*time.Millisecond {
+		t.Errorf("expected 5 requests at 20/s to take about 200ms, took %s", elapsed)
+	}
+	if calls.Load() != 5 {
+		t.Errorf("expected 5 requests, got %d", calls.Load())
+	}
+}
+
+func TestTransport_QueueingRespectsCancellation(t *testing.T) {
+	server, release, _ := blockingServer(t)
+	defer close(release)
+	limiter := New(Config{Global: Limit{MaxInFlight: 1}})
+	client := &http.Client{Transport: limiter.Transport(http.DefaultTransport)}
+
+	go func() {
+		if resp, err := client.Get(server.URL + "/rest/2.0/assets"); err == nil {
+			_ = resp.Body.Close()
+		}
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/rest/2.0/assets", nil)
+	_, err := client.Do(req)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the queued request to end with its context, got %v", err)
+	}
+}
+
+func TestBucketsFor_MatchesLongestPrefixOnSegmentBoundary(t *testing.T) {
+	limiter := New(Config{Paths: []PathLimit{
+		{Prefix: "/rest", Limit: Limit{MaxInFlight: 1}},
+		{Prefix: "/rest/2.0/", Limit: Limit{MaxInFlight: 1}},
+	}})
+	rest, rest20 := limiter.paths[1].bucket, limiter.paths[0].bucket
+
+	tests := []struct {
+		path string
+		want *bucket
+	}{
+		{"/rest/2.0/assets", rest20},
+		{"/rest/2.0", rest20},
+		{"/rest/dq/1.0/jobs", rest},
+		{"/restricted", nil},
+	}
+	for _, tt := range tests {
+		buckets := limiter.bucketsFor(tt.path)
+		if buckets[len(buckets)-1] != limiter.global {
+			t.Errorf("%s: expected the global bucket last", tt.path)
+		}
+		var got *bucket
+		if len(buckets) == 2 {
+			got = buckets[0]
+		}
+		if got != tt.want {
+			t.Errorf("%s: matched the wrong prefix", tt.path)
+		}
+	}
+}
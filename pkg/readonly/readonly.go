@@ -0,0 +1,195 @@
+// Package readonly keeps chip from changing the Collibra catalog. Its
+// transport lets through the requests that only read: GET, HEAD and OPTIONS,
+// POSTs to the endpoints that take their query in the body (search, copilot,
+// data quality lookups), and GraphQL documents without mutations.
+package readonly
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/collibra/chip/pkg/chip"
+)
+
+// DeniedError is a request refused because it could change the catalog.
+type DeniedError struct {
+	Reason string
+}
+
+func (e *DeniedError) Error() string {
+	return "read-only mode: " + e.Reason
+}
+
+func (e *DeniedError) ErrorCode() chip.ErrorCode {
+	return chip.ErrorPermissionDenied
+}
+
+// AllowedPosts lists the endpoints that only read even though they are called
+// with POST. A path ending in "/" allows every path below it.
+var AllowedPosts = []string{
+	"/rest/2.0/search",
+	"/rest/aiCopilot/v1/tools/",
+	"/rest/contextEngine/v1/contexts/generate",
+	"/rest/dq/internal/v1/ai/text2sql",
+	"/rest/dq/internal/v1/monitoring/monitors/dashboard",
+	"/rest/dq/internal/v1/rules/validate",
+}
+
+// GraphQLEndpoints lists the GraphQL endpoints. POSTs to them are allowed when
+// the document only holds queries.
+var GraphQLEndpoints = []string{
+	"/graphql",
+	"/graphql/knowledgeGraph/v1",
+}
+
+// Transport wraps an upstream RoundTripper and fails every request that could
+// change the catalog without sending it.
+func Transport(next http.RoundTripper) http.RoundTripper {
+	return &transport{next: next}
+}
+
+type transport struct {
+	next http.RoundTripper
+}
+
+func (t *transport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if err := Check(request); err != nil {
+		if request.Body != nil {
+			_ = request.Body.Close()
+		}
+		return nil, err
+	}
+	return t.next.RoundTrip(request)
+}
+
+// Check returns an error when the request could change the catalog. Reading
+// a GraphQL body leaves it in place for the next transport.
+func Check(request *http.Request) error {
+	switch request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return nil
+	case http.MethodPost:
+	default:
+		return denied(request)
+	}
+	p := path.Clean(request.URL.Path)
+	for _, allowed := range AllowedPosts {
+		if p == strings.TrimSuffix(allowed, "/") || (strings.HasSuffix(allowed, "/") && strings.HasPrefix(p, allowed)) {
+			return nil
+		}
+	}
+	for _, endpoint := range GraphQLEndpoints {
+		if p == endpoint {
+			return checkGraphQL(request)
+		}
+	}
+	return denied(request)
+}
+
+// checkGraphQL reads the GraphQL request body, puts it back for the next
+// transport, and fails when the document holds anything but queries.
+func checkGraphQL(request *http.Request) error {
+	if request.Body == nil {
+		return denied(request)
+	}
+	body, err := io.ReadAll(request.Body)
+	_ = request.Body.Close()
+	if err != nil {
+		return err
+	}
+	request.Body = io.NopCloser(bytes.NewReader(body))
+	request.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+
+	var graphQLRequest struct {
+		Query string `json:"query"`
+	}
+	if err := json.Unmarshal(body, &graphQLRequest); err != nil || graphQLRequest.Query == "" {
+		return &DeniedError{Reason: fmt.Sprintf("%s %s is not a GraphQL query", request.Method, request.URL.Path)}
+	}
+	operation, ok := writeOperation(graphQLRequest.Query)
+	if !ok {
+		return &DeniedError{Reason: fmt.Sprintf("GraphQL document on %s has an unterminated string or unbalanced braces", request.URL.Path)}
+	}
+	if operation != "" {
+		return &DeniedError{Reason: fmt.Sprintf("GraphQL %s on %s is not allowed", operation, request.URL.Path)}
+	}
+	return nil
+}
+
+// writeOperation returns "mutation" or "subscription" when the document
+// defines such an operation, and "" when it only holds queries and fragments.
+// Operation types are the only names outside of braces, so it is enough to
+// skip strings, comments and everything nested in a selection set. It
+// returns false when the document has an unterminated string or unbalanced
+// braces: what follows could not be told apart from a mutation.
+func writeOperation(document string) (string, bool) {
+	depth := 0
+	for i := 0; i < len(document); i++ {
+		c := document[i]
+		switch {
+		case c == '#':
+			for i < len(document) && document[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(document[i:], `"""`):
+			// \""" is the only escape sequence of block strings.
+			for i += 3; !strings.HasPrefix(document[i:], `"""`); i++ {
+				if i >= len(document) {
+					return "", false
+				}
+				if strings.HasPrefix(document[i:], `\"""`) {
+					i += 3
+				}
+			}
+			i += 2
+		case c == '"':
+			for i++; i >= len(document) || document[i] != '"'; i++ {
+				switch {
+				case i >= len(document) || document[i] == '\n':
+					return "", false
+				case document[i] == '\\':
+					i++
+				}
+			}
+		case c == '$' || c == '@':
+			// Variables and directives may be named like operation types.
+			for i+1 < len(document) && isNameContinue(document[i+1]) {
+				i++
+			}
+		case c == '{':
+			depth++
+		case c == '}':
+			if depth--; depth < 0 {
+				return "", false
+			}
+		case depth == 0 && isNameStart(c):
+			start := i
+			for i+1 < len(document) && isNameContinue(document[i+1]) {
+				i++
+			}
+			if name := document[start : i+1]; name == "mutation" || name == "subscription" {
+				return name, true
+			}
+		}
+	}
+	return "", depth == 0
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNameContinue(c byte) bool {
+	return isNameStart(c) || (c >= '0' && c <= '9')
+}
+
+func denied(request *http.Request) error {
+	return &DeniedError{Reason: fmt.Sprintf("%s %s is not allowed", request.Method, request.URL.Path)}
+}
@@ -0,0 +1,72 @@
+package readonly
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type recordingTransport struct {
+	bodies []string
+}
+
+func (r *recordingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	body := ""
+	if request.Body != nil {
+		b, _ := io.ReadAll(request.Body)
+		body = string(b)
+	}
+	r.bodies = append(r.bodies, body)
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: request}, nil
+}
+
+func TestTransport(t *testing.T) {
+	tests := []struct {
+		name    string
+		method  string
+		path    string
+		body    string
+		allowed bool
+	}{
+		{"get", http.MethodGet, "/rest/2.0/assets", "", true},
+		{"search", http.MethodPost, "/rest/2.0/search", `{"keywords":"x"}`, true},
+		{"copilot", http.MethodPost, "/rest/aiCopilot/v1/tools/askDad", `{}`, true},
+		{"create asset", http.MethodPost, "/rest/2.0/assets", `{}`, false},
+		{"escaping the allowlist", http.MethodPost, "/rest/2.0/search/../assets", `{}`, false},
+		{"patch", http.MethodPatch, "/rest/2.0/assets/1", `{}`, false},
+		{"delete", http.MethodDelete, "/rest/dq/1.0/jobs/x", "", false},
+		{"graphql query", http.MethodPost, "/graphql/knowledgeGraph/v1", `{"query":"query Q($mutation: ID) { assets(where: {id: $mutation}) { id } }"}`, true},
+		{"anonymous query", http.MethodPost, "/graphql", `{"query":"# no mutation here\n{ a(name: \"mutation\") }"}`, true},
+		{"graphql mutation", http.MethodPost, "/graphql", `{"query":"fragment F on A { id } mutation { deleteAsset(id: 1) { ...F } }"}`, false},
+		{"block string", http.MethodPost, "/graphql", `{"query":"query Q($a: String = \"\"\"mutation { x } \\\"\"\" \"\"\") { a(b: $a) }"}`, true},
+		{"mutation after an escaped block quote", http.MethodPost, "/graphql", `{"query":"query Q($a: String = \"\"\"x\\\"\"\" \"\"\") { a } mutation M { deleteX }"}`, false},
+		{"mutation after a string default", http.MethodPost, "/graphql", `{"query":"query Q($a: String = \"}\\\"{\") { a } mutation M { deleteX }"}`, false},
+		{"unterminated block string", http.MethodPost, "/graphql", `{"query":"query Q($a: String = \"\"\"x\\\"\"\") { a } mutation M { deleteX }"}`, false},
+		{"unterminated string", http.MethodPost, "/graphql", `{"query":"query Q($a: String = \"x\\\") { a } mutation M { deleteX }"}`, false},
+		{"unbalanced braces", http.MethodPost, "/graphql", `{"query":"{ a } } mutation M { deleteX }"}`, false},
+		{"not graphql", http.MethodPost, "/graphql", `[{"query":"{ a }"}]`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := &recordingTransport{}
+			request, _ := http.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			_, err := Transport(next).RoundTrip(request)
+			if tt.allowed {
+				if err != nil {
+					t.Fatalf("expected the request to be sent, got %v", err)
+				}
+				if len(next.bodies) != 1 || next.bodies[0] != tt.body {
+					t.Errorf("expected the body to reach Collibra unchanged, got %q", next.bodies)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), "read-only mode") {
+				t.Errorf("expected the request to be refused, got %v", err)
+			}
+			if len(next.bodies) != 0 {
+				t.Error("expected a refused request not to reach Collibra")
+			}
+		})
+	}
+}
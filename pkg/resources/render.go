@@ -0,0 +1,197 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/collibra/chip/pkg/clients"
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/yosida95/uritemplate/v3"
+)
+
+// domainAssetsLimit bounds the assets listed in a domain resource.
+const domainAssetsLimit = 100
+
+func readAsset(ctx context.Context, client *http.Client, uri string, id uuid.UUID, vars uritemplate.Values) (*mcp.ResourceContents, string, error) {
+	assets, err := clients.GetAssetSummary(ctx, client, id, "", "")
+	if err != nil || len(assets) == 0 {
+		return nil, "", err
+	}
+	asset := assets[0]
+	contents, err := render(uri, vars, func() string { return assetMarkdown(&asset) }, asset)
+	return contents, asset.DisplayName, err
+}
+
+func assetMarkdown(asset *clients.Asset) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", asset.DisplayName)
+	if asset.Type != nil {
+		fmt.Fprintf(&b, "- **Type:** %s\n", asset.Type.Name)
+	}
+	if asset.Domain != nil {
+		fmt.Fprintf(&b, "- **Domain:** %s\n", asset.Domain.Name)
+	}
+	if asset.Status != nil {
+		fmt.Fprintf(&b, "- **Status:** %s\n", asset.Status.Name)
+	}
+	fmt.Fprintf(&b, "- **ID:** %s\n", asset.ID)
+
+	var attributes [][2]string
+	for _, a := range asset.StringAttributes {
+		attributes = append(attributes, [2]string{attributeName(a.Type), a.Value})
+	}
+	for _, a := range asset.NumericAttributes {
+		attributes = append(attributes, [2]string{attributeName(a.Type), strconv.FormatFloat(a.Value, 'f', -1, 64)})
+	}
+	for _, a := range asset.BooleanAttributes {
+		attributes = append(attributes, [2]string{attributeName(a.Type), strconv.FormatBool(a.Value)})
+	}
+	for _, a := range asset.DateAttributes {
+		attributes = append(attributes, [2]string{attributeName(a.Type), a.Value})
+	}
+	if len(attributes) > 0 {
+		b.WriteString("\n## Attributes\n")
+		for _, a := range attributes {
+			fmt.Fprintf(&b, "\n### %s\n\n%s\n", a[0], a[1])
+		}
+	}
+
+	if len(asset.OutgoingRelations) > 0 || len(asset.IncomingRelations) > 0 {
+		b.WriteString("\n## Relations\n\n")
+		for _, r := range asset.OutgoingRelations {
+			if r.Target != nil {
+				fmt.Fprintf(&b, "- %s %s\n", relationRole(r.Type), assetLink(r.Target))
+			}
+		}
+		for _, r := range asset.IncomingRelations {
+			if r.Source != nil {
+				fmt.Fprintf(&b, "- %s %s %s\n", assetLink(r.Source), relationRole(r.Type), asset.DisplayName)
+			}
+		}
+	}
+	return b.String()
+}
+
+func attributeName(t *clients.AttributeType) string {
+	if t == nil {
+		return "Attribute"
+	}
+	return t.Name
+}
+
+func relationRole(t *clients.RelationType) string {
+	if t == nil || t.Role == "" {
+		return "related to"
+	}
+	return t.Role
+}
+
+func assetLink(asset *clients.RelatedAsset) string {
+	link := fmt.Sprintf("[%s](collibra://asset/%s)", asset.DisplayName, asset.ID)
+	if asset.Type != nil {
+		link += " (" + asset.Type.Name + ")"
+	}
+	return link
+}
+
+// domain is the content of a domain resource.
+type domain struct {
+	*clients.PrepareCreateDomain
+	Assets []clients.SearchResource `json:"assets"`
+	// TotalAssets counts the assets of the domain, of which Assets lists the
+	// first domainAssetsLimit.
+	TotalAssets int `json:"totalAssets"`
+}
+
+func readDomain(ctx context.Context, client *http.Client, uri string, id uuid.UUID, vars uritemplate.Values) (*mcp.ResourceContents, string, error) {
+	d, err := clients.GetDomainByID(ctx, client, id.String())
+	if err != nil {
+		return nil, "", err
+	}
+	search, err := clients.SearchKeyword(ctx, client, "", []string{"Asset"},
+		[]clients.SearchFilter{{Field: "domain", Values: []string{d.ID}}}, domainAssetsLimit, 0)
+	if err != nil {
+		return nil, "", err
+	}
+	content := domain{PrepareCreateDomain: d, TotalAssets: search.Total}
+	for _, result := range search.Results {
+		content.Assets = append(content.Assets, result.Resource)
+	}
+	rendered, err := render(uri, vars, func() string { return domainMarkdown(&content) }, content)
+	return rendered, d.Name, err
+}
+
+func domainMarkdown(d *domain) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", d.Name)
+	if d.Type != nil {
+		fmt.Fprintf(&b, "- **Type:** %s\n", d.Type.Name)
+	}
+	fmt.Fprintf(&b, "- **ID:** %s\n", d.ID)
+	fmt.Fprintf(&b, "\n## Assets (%d)\n\n", d.TotalAssets)
+	for _, a := range d.Assets {
+		fmt.Fprintf(&b, "- [%s](collibra://asset/%s)\n", a.Name, a.ID)
+	}
+	if len(d.Assets) < d.TotalAssets {
+		fmt.Fprintf(&b, "\nOnly the first %d assets are listed.\n", len(d.Assets))
+	}
+	return b.String()
+}
+
+func readAssessment(ctx context.Context, client *http.Client, uri string, id uuid.UUID, vars uritemplate.Values) (*mcp.ResourceContents, string, error) {
+	assessment, err := clients.GetAssessment(ctx, client, id.String())
+	if err != nil || assessment == nil {
+		return nil, "", err
+	}
+	contents, err := render(uri, vars, func() string { return assessmentMarkdown(assessment) }, assessment)
+	return contents, assessment.Name, err
+}
+
+func assessmentMarkdown(assessment *clients.Assessment) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", assessment.Name)
+	if assessment.Status != "" {
+		fmt.Fprintf(&b, "- **Status:** %s\n", assessment.Status)
+	}
+	if assessment.Template != nil {
+		fmt.Fprintf(&b, "- **Template:** %s\n", assessment.Template.Name)
+	}
+	if assessment.Asset != nil {
+		name := assessment.Asset.Name
+		if name == "" {
+			name = assessment.Asset.ID
+		}
+		fmt.Fprintf(&b, "- **Asset:** [%s](collibra://asset/%s)\n", name, assessment.Asset.ID)
+	}
+	fmt.Fprintf(&b, "- **ID:** %s\n", assessment.ID)
+	if len(assessment.Content) > 0 {
+		b.WriteString("\n## Questions\n")
+		for _, q := range assessment.Content {
+			fmt.Fprintf(&b, "\n### %s\n\n", q.Name)
+			if q.Description != "" {
+				fmt.Fprintf(&b, "%s\n\n", q.Description)
+			}
+			if q.Answer == nil || q.Answer.Value == nil {
+				b.WriteString("_Not answered._\n")
+			} else {
+				fmt.Fprintf(&b, "%v\n", q.Answer.Value)
+			}
+			if q.Comments != "" {
+				fmt.Fprintf(&b, "\n> %s\n", q.Comments)
+			}
+		}
+	}
+	return b.String()
+}
+
+func readDataContractManifest(ctx context.Context, client *http.Client, uri string, id uuid.UUID, _ uritemplate.Values) (*mcp.ResourceContents, string, error) {
+	manifest, err := clients.PullActiveDataContractManifest(ctx, client, id.String())
+	if err != nil {
+		return nil, "", err
+	}
+	return &mcp.ResourceContents{URI: uri, MIMEType: yamlMIMEType, Text: string(manifest)}, "", nil
+}
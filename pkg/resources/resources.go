@@ -0,0 +1,227 @@
+// Package resources exposes catalog items as MCP resources, so clients can
+// attach a Collibra asset, domain, assessment or data contract manifest as
+// context or let the user browse it, instead of going through a tool call.
+// Items are addressed by URI templates such as collibra://asset/{id} and
+// rendered as Markdown, or as JSON with ?format=json.
+//
+// resources/list returns the items the session touched, through a resource
+// read or as an argument of a tool call, most recent first.
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/collibra/chip/pkg/chip"
+	"github.com/collibra/chip/pkg/clients"
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/yosida95/uritemplate/v3"
+)
+
+const (
+	markdownMIMEType = "text/markdown"
+	jsonMIMEType     = "application/json"
+	yamlMIMEType     = "application/yaml"
+)
+
+// template is a resource template and the tool whose data it exposes. The
+// template is only registered while that tool is enabled, so resources never
+// reach data the configured tools do not.
+type template struct {
+	tool     string
+	resource *mcp.ResourceTemplate
+	// argument is the tool argument that names an item of the template.
+	argument string
+	// uri builds the URI of the item with id, as recorded in resources/list.
+	uri func(id string) string
+	// read reads the item id. Only UUIDs reach it: they are safe to put in
+	// the path of a Collibra endpoint.
+	read func(ctx context.Context, client *http.Client, uri string, id uuid.UUID, vars uritemplate.Values) (*mcp.ResourceContents, string, error)
+}
+
+// templates lists the resource templates chip serves.
+var templates = []*template{
+	{
+		tool: "get_asset_details",
+		resource: &mcp.ResourceTemplate{
+			URITemplate: "collibra://asset/{id}{?format}",
+			Name:        "asset",
+			Title:       "Collibra asset",
+			Description: "An asset with its type, domain, status, attributes and first page of relations. Markdown, or JSON with ?format=json.",
+			MIMEType:    markdownMIMEType,
+		},
+		argument: "assetId",
+		uri:      func(id string) string { return "collibra://asset/" + id },
+		read:     readAsset,
+	},
+	{
+		tool: "search_asset_keyword",
+		resource: &mcp.ResourceTemplate{
+			URITemplate: "collibra://domain/{id}{?format}",
+			Name:        "domain",
+			Title:       "Collibra domain",
+			Description: "A domain with its type and the assets it holds. Markdown, or JSON with ?format=json.",
+			MIMEType:    markdownMIMEType,
+		},
+		argument: "domainId",
+		uri:      func(id string) string { return "collibra://domain/" + id },
+		read:     readDomain,
+	},
+	{
+		tool: "get_assessment",
+		resource: &mcp.ResourceTemplate{
+			URITemplate: "collibra://assessment/{id}{?format}",
+			Name:        "assessment",
+			Title:       "Collibra assessment",
+			Description: "An assessment with its template, asset and answers. Markdown, or JSON with ?format=json.",
+			MIMEType:    markdownMIMEType,
+		},
+		argument: "assessmentId",
+		uri:      func(id string) string { return "collibra://assessment/" + id },
+		read:     readAssessment,
+	},
+	{
+		tool: "pull_data_contract_manifest",
+		resource: &mcp.ResourceTemplate{
+			URITemplate: "collibra://data-contract/{id}/manifest",
+			Name:        "data-contract-manifest",
+			Title:       "Collibra data contract manifest",
+			Description: "The active manifest of a data contract, as YAML.",
+			MIMEType:    yamlMIMEType,
+		},
+		argument: "dataContractId",
+		uri:      func(id string) string { return "collibra://data-contract/" + id + "/manifest" },
+		read:     readDataContractManifest,
+	},
+}
+
+// RegisterAll registers the resource templates whose tool is enabled and
+// removes the others, so it can be called again when the enabled tools
+// change.
+func RegisterAll(server *chip.Server, client *http.Client, enabled func(toolName string) bool) {
+	for _, t := range templates {
+		if !enabled(t.tool) {
+			server.RemoveResourceTemplates(t.resource.URITemplate)
+			server.RemoveCompletions(t.resource.URITemplate, "id")
+			continue
+		}
+		slog.Info(fmt.Sprintf("Registering resource template: %s", t.resource.URITemplate))
+		server.AddResourceTemplate(t.resource, t.handler(server, client))
+		server.AddCompletion(t.resource.URITemplate, "id", t.completeID)
+	}
+}
+
+// completeID completes the id of the template with the ids of the items of
+// the template the session touched. Ids are not worth typing, nor looking
+// up by prefix.
+func (t *template) completeID(ctx context.Context, _ *mcp.CompleteRequest) ([]string, error) {
+	prefix, suffix, _ := strings.Cut(t.uri("{id}"), "{id}")
+	var ids []string
+	for _, resource := range chip.RecentResources(ctx) {
+		id, ok := strings.CutPrefix(resource.URI, prefix)
+		if id, hasSuffix := strings.CutSuffix(id, suffix); ok && hasSuffix {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// handler reads the items of the template as a call of its tool, so that the
+// read goes to the Collibra instance selected by the _meta of the request or
+// its header, and requires the permissions of the tool.
+func (t *template) handler(server *chip.Server, client *http.Client) mcp.ResourceHandler {
+	parsed := uritemplate.MustNew(t.resource.URITemplate)
+	return func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		uri := req.Params.URI
+		vars := parsed.Match(uri)
+		id, err := uuid.Parse(vars.Get("id").String())
+		if err != nil {
+			return nil, mcp.ResourceNotFoundError(uri)
+		}
+		// The Collibra client expects a tool call in the context; it
+		// forwards the credentials of the read request.
+		toolRequest := &mcp.CallToolRequest{
+			Session: req.Session,
+			Params:  &mcp.CallToolParamsRaw{Meta: req.Params.Meta, Name: "resources/read"},
+			Extra:   req.Extra,
+		}
+		var contents *mcp.ResourceContents
+		var title string
+		_, err = server.RunAsTool(ctx, t.tool, toolRequest, func(ctx context.Context, _ *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			var err error
+			contents, title, err = t.read(ctx, client, uri, id, vars)
+			if err != nil {
+				return nil, err
+			}
+			return &mcp.CallToolResult{}, nil
+		})
+		var apiErr *clients.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			return nil, mcp.ResourceNotFoundError(uri)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if contents == nil {
+			return nil, mcp.ResourceNotFoundError(uri)
+		}
+		chip.TouchResource(ctx, &mcp.Resource{
+			URI:      t.uri(id.String()),
+			Name:     t.resource.Name + " " + id.String(),
+			Title:    title,
+			MIMEType: t.resource.MIMEType,
+		})
+		return &mcp.ReadResourceResult{Contents: []*mcp.ResourceContents{contents}}, nil
+	}
+}
+
+// render returns the Markdown of an item, or value as JSON when the URI asks
+// for ?format=json.
+func render(uri string, vars uritemplate.Values, markdown func() string, value any) (*mcp.ResourceContents, error) {
+	switch format := vars.Get("format").String(); format {
+	case "", "markdown":
+		return &mcp.ResourceContents{URI: uri, MIMEType: markdownMIMEType, Text: markdown()}, nil
+	case "json":
+		data, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return &mcp.ResourceContents{URI: uri, MIMEType: jsonMIMEType, Text: string(data)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported format %q, expected markdown or json", format)
+	}
+}
+
+// ToolMiddleware records the catalog items named by the assetId, domainId,
+// assessmentId and dataContractId arguments of successful tool calls as
+// touched by the session, so clients list them as resources. Arguments that
+// are not UUIDs are ignored.
+func ToolMiddleware() chip.ToolMiddleware {
+	return chip.ToolMiddlewareFunc(func(ctx context.Context, toolRequest *mcp.CallToolRequest, next chip.CallToolFunc) (*mcp.CallToolResult, error) {
+		res, err := next(ctx, toolRequest)
+		if err != nil || res == nil || res.IsError || len(toolRequest.Params.Arguments) == 0 {
+			return res, err
+		}
+		var arguments map[string]any
+		if json.Unmarshal(toolRequest.Params.Arguments, &arguments) != nil {
+			return res, err
+		}
+		for _, t := range templates {
+			argument, _ := arguments[t.argument].(string)
+			if id, err := uuid.Parse(strings.TrimSpace(argument)); err == nil {
+				chip.TouchResource(ctx, &mcp.Resource{
+					URI:      t.uri(id.String()),
+					Name:     t.resource.Name + " " + id.String(),
+					MIMEType: t.resource.MIMEType,
+				})
+			}
+		}
+		return res, err
+	})
+}
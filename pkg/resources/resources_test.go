@@ -0,0 +1,287 @@
+package resources_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/collibra/chip/pkg/chip"
+	"github.com/collibra/chip/pkg/fakecollibra"
+	"github.com/collibra/chip/pkg/resources"
+	"github.com/collibra/chip/pkg/tools"
+	"github.com/collibra/chip/pkg/tools/testutil"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// connect serves chip against a fake Collibra seeded from the default
+// fixture, with session state and the given tool middlewares, and returns a
+// client session.
+func connect(t *testing.T, toolConfig *chip.ServerToolConfig, middlewares ...chip.ToolMiddleware) *mcp.ClientSession {
+	t.Helper()
+	fixture, err := fakecollibra.ParseFixture(fakecollibra.DefaultFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake, err := fakecollibra.New(fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	collibra := httptest.NewServer(fake)
+	t.Cleanup(collibra.Close)
+
+	opts := []chip.ServerOption{chip.WithSessionStore(chip.NewMemorySessionStore(0)), chip.WithToolMiddleware(resources.ToolMiddleware())}
+	for _, middleware := range middlewares {
+		opts = append(opts, chip.WithToolMiddleware(middleware))
+	}
+	server := chip.NewServer(opts...)
+	if err := tools.RegisterAll(server, testutil.NewClient(collibra), toolConfig); err != nil {
+		t.Fatal(err)
+	}
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	if _, err := server.Connect(t.Context(), serverTransport, nil); err != nil {
+		t.Fatal(err)
+	}
+	session, err := mcp.NewClient(&mcp.Implementation{Name: "test"}, nil).Connect(t.Context(), clientTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = session.Close() })
+	return session
+}
+
+// call calls a tool and decodes its structured output into output.
+func call(t *testing.T, session *mcp.ClientSession, name string, arguments map[string]any, output any) {
+	t.Helper()
+	res, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: name, Arguments: arguments})
+	if err != nil || res.IsError {
+		t.Fatalf("%s: %v %+v", name, err, res)
+	}
+	data, _ := json.Marshal(res.StructuredContent)
+	if err := json.Unmarshal(data, output); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func read(t *testing.T, session *mcp.ClientSession, uri string) *mcp.ResourceContents {
+	t.Helper()
+	res, err := session.ReadResource(t.Context(), &mcp.ReadResourceParams{URI: uri})
+	if err != nil {
+		t.Fatalf("%s: %v", uri, err)
+	}
+	return res.Contents[0]
+}
+
+// ids looks up the ids of the items of the default fixture the resources
+// are read for.
+func ids(t *testing.T, session *mcp.ClientSession) (asset, domain, assessment, dataContract string) {
+	t.Helper()
+	var search struct {
+		Results []struct {
+			ID           string `json:"id"`
+			Name         string `json:"name"`
+			ResourceType string `json:"resourceType"`
+		} `json:"results"`
+	}
+	call(t, session, "search_asset_keyword", map[string]any{"query": "Revenue"}, &search)
+	for _, result := range search.Results {
+		if result.Name == "Revenue" {
+			asset = result.ID
+		}
+	}
+	call(t, session, "search_asset_keyword", map[string]any{"query": "Sales Warehouse", "resourceTypeFilters": []string{"Domain"}}, &search)
+	domain = search.Results[0].ID
+
+	var assessments struct {
+		Assessment struct {
+			ID string `json:"id"`
+		} `json:"assessment"`
+	}
+	call(t, session, "get_assessment", map[string]any{"name": "Orders privacy review"}, &assessments)
+	assessment = assessments.Assessment.ID
+
+	var contracts struct {
+		Contracts []struct {
+			ID string `json:"id"`
+		} `json:"contracts"`
+	}
+	call(t, session, "list_data_contract", map[string]any{}, &contracts)
+	dataContract = contracts.Contracts[0].ID
+	return asset, domain, assessment, dataContract
+}
+
+func TestRead(t *testing.T) {
+	session := connect(t, &chip.ServerToolConfig{})
+	asset, domain, assessment, dataContract := ids(t, session)
+
+	tests := []struct {
+		uri      string
+		mimeType string
+		want     []string
+	}{
+		{"collibra://asset/" + asset, "text/markdown", []string{"# Revenue\n", "- **Status:** Accepted", "### Definition\n\nThe income", "- represents [amount](collibra://asset/"}},
+		{"collibra://asset/" + asset + "?format=json", "application/json", []string{`"displayName": "Revenue"`, `"stringValue": "The income`}},
+		{"collibra://domain/" + domain, "text/markdown", []string{"# Sales Warehouse\n", "- **Type:** Physical Data Dictionary", "## Assets (4)", "[orders](collibra://asset/"}},
+		{"collibra://assessment/" + assessment, "text/markdown", []string{"# Orders privacy review\n", "- **Template:** Privacy Impact Assessment", "### Does the table hold personal data?\n\ntrue\n"}},
+		{"collibra://data-contract/" + dataContract + "/manifest", "application/yaml", []string{"kind: DataContract\n"}},
+	}
+	for _, tt := range tests {
+		contents := read(t, session, tt.uri)
+		if contents.MIMEType != tt.mimeType {
+			t.Errorf("%s: expected %s, got %s", tt.uri, tt.mimeType, contents.MIMEType)
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(contents.Text, want) {
+				t.Errorf("%s: expected %q in\n%s", tt.uri, want, contents.Text)
+			}
+		}
+	}
+}
+
+func TestRead_UnknownItem(t *testing.T) {
+	session := connect(t, &chip.ServerToolConfig{})
+
+	for _, uri := range []string{
+		"collibra://asset/00000000-0000-0000-0000-000000000001",
+		"collibra://asset/not-a-uuid",
+		"collibra://assessment/missing",
+		"collibra://domain/..%2F2.0%2Fusers%2Fcurrent",
+		"collibra://data-contract/..%2F..%2F..%2F2.0%2Fusers/manifest",
+	} {
+		if _, err := session.ReadResource(t.Context(), &mcp.ReadResourceParams{URI: uri}); err == nil || !strings.Contains(err.Error(), "not found") {
+			t.Errorf("%s: expected a resource not found error, got %v", uri, err)
+		}
+	}
+}
+
+func TestRead_GoesThroughTheToolMiddlewares(t *testing.T) {
+	var reads []string
+	session := connect(t, &chip.ServerToolConfig{}, chip.ToolMiddlewareFunc(func(ctx context.Context, toolRequest *mcp.CallToolRequest, next chip.CallToolFunc) (*mcp.CallToolResult, error) {
+		if toolRequest.Params.Name != "resources/read" {
+			return next(ctx, toolRequest)
+		}
+		metadata, _ := chip.GetToolMetadata(ctx)
+		instance, _ := toolRequest.Params.Meta["collibra.com/instance"].(string)
+		reads = append(reads, metadata.Name+"@"+instance)
+		if instance == "qa" {
+			return nil, errors.New("unknown Collibra instance")
+		}
+		return next(ctx, toolRequest)
+	}))
+	asset, _, assessment, _ := ids(t, session)
+
+	for _, params := range []*mcp.ReadResourceParams{
+		{URI: "collibra://asset/" + asset},
+		{URI: "collibra://assessment/" + assessment, Meta: mcp.Meta{"collibra.com/instance": "prod"}},
+	} {
+		if _, err := session.ReadResource(t.Context(), params); err != nil {
+			t.Fatalf("%s: %v", params.URI, err)
+		}
+	}
+	if _, err := session.ReadResource(t.Context(), &mcp.ReadResourceParams{URI: "collibra://asset/" + asset, Meta: mcp.Meta{"collibra.com/instance": "qa"}}); err == nil {
+		t.Error("expected the read rejected by a middleware to fail")
+	}
+	if want := []string{"get_asset_details@", "get_assessment@prod", "get_asset_details@qa"}; !slices.Equal(reads, want) {
+		t.Errorf("expected the reads to run as calls of their tool %v, got %v", want, reads)
+	}
+}
+
+func TestList_ReturnsTheItemsTheSessionTouched(t *testing.T) {
+	session := connect(t, &chip.ServerToolConfig{})
+	asset, domain, _, _ := ids(t, session)
+
+	read(t, session, "collibra://domain/"+domain)
+	var details map[string]any
+	call(t, session, "get_asset_details", map[string]any{"assetId": asset}, &details)
+
+	res, err := session.ListResources(t.Context(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var uris []string
+	for _, resource := range res.Resources {
+		uris = append(uris, resource.URI)
+	}
+	want := []string{"collibra://asset/" + asset, "collibra://domain/" + domain}
+	if strings.Join(uris, " ") != strings.Join(want, " ") {
+		t.Errorf("expected %v, got %v", want, uris)
+	}
+	if res.Resources[1].Title != "Sales Warehouse" {
+		t.Errorf("expected the title of the read domain, got %q", res.Resources[1].Title)
+	}
+}
+
+func TestList_IgnoresArgumentsThatAreNotUUIDs(t *testing.T) {
+	succeed := chip.ToolMiddlewareFunc(func(context.Context, *mcp.CallToolRequest, chip.CallToolFunc) (*mcp.CallToolResult, error) {
+		return &mcp.CallToolResult{}, nil
+	})
+	session := connect(t, &chip.ServerToolConfig{}, succeed)
+
+	for _, assetID := range []string{"../../2.0/users", "{8F4C1A0E-0D5B-4C57-9E8E-3A4B2D9F1C77}"} {
+		if _, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: "get_asset_details", Arguments: map[string]any{"assetId": assetID}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	res, err := session.ListResources(t.Context(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Resources) != 1 || res.Resources[0].URI != "collibra://asset/8f4c1a0e-0d5b-4c57-9e8e-3a4b2d9f1c77" {
+		t.Errorf("expected only the UUID to be recorded, got %+v", res.Resources)
+	}
+}
+
+func TestComplete_SuggestsTheIdsTheSessionTouched(t *testing.T) {
+	session := connect(t, &chip.ServerToolConfig{})
+	asset, domain, _, _ := ids(t, session)
+
+	read(t, session, "collibra://domain/"+domain)
+	var details map[string]any
+	call(t, session, "get_asset_details", map[string]any{"assetId": asset}, &details)
+
+	res, err := session.ListResourceTemplates(t.Context(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"asset": asset, "domain": domain, "assessment": ""}
+	for _, template := range res.ResourceTemplates {
+		id, ok := want[template.Name]
+		if !ok {
+			continue
+		}
+		completion, err := session.Complete(t.Context(), &mcp.CompleteParams{
+			Ref:      &mcp.CompleteReference{Type: "ref/resource", URI: template.URITemplate},
+			Argument: mcp.CompleteParamsArgument{Name: "id", Value: ""},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := strings.Join(completion.Completion.Values, " "); got != id {
+			t.Errorf("%s: expected %q, got %q", template.URITemplate, id, got)
+		}
+	}
+}
+
+func TestRegisterAll_FollowsTheEnabledTools(t *testing.T) {
+	session := connect(t, &chip.ServerToolConfig{DisabledTools: []string{"assessments", "get_asset_details"}})
+
+	res, err := session.ListResourceTemplates(t.Context(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var templates []string
+	for _, template := range res.ResourceTemplates {
+		templates = append(templates, template.URITemplate)
+	}
+	for _, disabled := range []string{"collibra://asset/{id}{?format}", "collibra://assessment/{id}{?format}"} {
+		if strings.Contains(strings.Join(templates, " "), disabled) {
+			t.Errorf("expected %s to be disabled, got %v", disabled, templates)
+		}
+	}
+	if len(templates) != 2 {
+		t.Errorf("expected the domain and data contract templates, got %v", templates)
+	}
+}
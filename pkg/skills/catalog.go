@@ -20,15 +20,26 @@ var embeddedFS embed.FS
 
 // Skill is one entry in the catalog. A skill bundles a Markdown body, a
 // short description, optional cross-references to related skills, and an
-// optional set of reference files that can be loaded on demand.
+// optional set of reference files that can be loaded on demand. Skills are
+// also served as MCP prompts, under Title and with Arguments.
 type Skill struct {
 	Name        string
+	Title       string
 	Description string
 	Related     []string
+	Arguments   []Argument
 	Body        string
 	Resources   []Resource
 }
 
+// Argument is an argument of the prompt of a skill. Its value replaces the
+// {{name}} placeholders of the body.
+type Argument struct {
+	Name        string
+	Description string
+	Required    bool
+}
+
 // Resource is a bundled file (e.g. references/column-lineage-workaround.md)
 // that a skill can offer for progressive disclosure.
 type Resource struct {
@@ -138,8 +149,10 @@ func loadSkill(fsys fs.FS, name, dir, sharedDir string) (*Skill, error) {
 	sort.Slice(resources, func(i, j int) bool { return resources[i].Path < resources[j].Path })
 	return &Skill{
 		Name:        name,
+		Title:       meta.title,
 		Description: meta.description,
 		Related:     meta.related,
+		Arguments:   meta.arguments,
 		Body:        body,
 		Resources:   resources,
 	}, nil
@@ -221,3 +234,38 @@ func (s *Skill) ResourcePaths() []string {
 	}
 	return out
 }
+
+// Render returns the body with the {{name}} placeholders of the arguments
+// replaced by their values. A line with a placeholder of an argument that
+// has no value is left out, so the body reads naturally without the
+// arguments, as when it is loaded through load_collibra_skill.
+func (s *Skill) Render(values map[string]string) string {
+	if len(s.Arguments) == 0 {
+		return s.Body
+	}
+	lines := strings.Split(s.Body, "\n")
+	out := lines[:0]
+	dropped := false
+lines:
+	for _, line := range lines {
+		// Do not leave two blank lines where a paragraph was left out.
+		if dropped && strings.TrimSpace(line) == "" && len(out) > 0 && strings.TrimSpace(out[len(out)-1]) == "" {
+			continue
+		}
+		dropped = false
+		for _, argument := range s.Arguments {
+			placeholder := "{{" + argument.Name + "}}"
+			if !strings.Contains(line, placeholder) {
+				continue
+			}
+			value := strings.TrimSpace(values[argument.Name])
+			if value == "" {
+				dropped = true
+				continue lines
+			}
+			line = strings.ReplaceAll(line, placeholder, value)
+		}
+		out = append(out, line)
+	}
+	return strings.Join(out, "\n")
+}
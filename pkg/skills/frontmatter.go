@@ -3,16 +3,18 @@ package skills
 import "strings"
 
 type frontmatter struct {
+	title       string
 	description string
 	related     []string
 	shared      []string
+	arguments   []Argument
 }
 
 // parseFrontmatter extracts a minimal YAML-like header delimited by `---`
-// lines at the top of the document. Recognized keys: description, related,
-// shared (the latter two comma-separated). Anything after the closing `---`
-// is returned as the body verbatim. If no frontmatter is present, the whole
-// input is the body.
+// lines at the top of the document. Recognized keys: title, description,
+// related, shared (the latter two comma-separated) and arguments (see
+// parseArguments). Anything after the closing `---` is returned as the body
+// verbatim. If no frontmatter is present, the whole input is the body.
 func parseFrontmatter(raw string) (frontmatter, string) {
 	lines := strings.SplitN(raw, "\n", 2)
 	if len(lines) < 2 || strings.TrimSpace(lines[0]) != "---" {
@@ -36,12 +38,16 @@ func parseFrontmatter(raw string) (frontmatter, string) {
 		key = strings.TrimSpace(key)
 		value = strings.TrimSpace(value)
 		switch key {
+		case "title":
+			meta.title = value
 		case "description":
 			meta.description = value
 		case "related":
 			meta.related = splitCSV(value)
 		case "shared":
 			meta.shared = splitCSV(value)
+		case "arguments":
+			meta.arguments = parseArguments(value)
 		}
 	}
 	return meta, body
@@ -61,3 +67,38 @@ func splitCSV(s string) []string {
 	}
 	return out
 }
+
+// parseArguments parses a comma-separated list of prompt arguments, each a
+// name with an optional description in parentheses. A name ending in "?" is
+// optional: `asset (the asset to trace), direction? (upstream or downstream)`.
+// Commas inside the parentheses do not separate arguments.
+func parseArguments(s string) []Argument {
+	var out []Argument
+	depth, start := 0, 0
+	add := func(entry string) {
+		entry = strings.TrimSpace(entry)
+		name, description, _ := strings.Cut(entry, "(")
+		name = strings.TrimSpace(name)
+		description = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(description), ")"))
+		optional := strings.HasSuffix(name, "?")
+		name = strings.TrimSuffix(name, "?")
+		if name != "" {
+			out = append(out, Argument{Name: name, Description: description, Required: !optional})
+		}
+	}
+	for i, r := range s {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				add(s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	add(s[start:])
+	return out
+}
@@ -95,3 +95,23 @@ body`,
 		})
 	}
 }
+
+func TestParseFrontmatter_titleAndArguments(t *testing.T) {
+	meta, _ := parseFrontmatter(`---
+title: Trace lineage for…
+arguments: asset (name, UUID or entity ID), direction? (upstream or downstream), depth?
+---
+
+body`)
+	if meta.title != "Trace lineage for…" {
+		t.Errorf("title = %q", meta.title)
+	}
+	want := []Argument{
+		{Name: "asset", Description: "name, UUID or entity ID", Required: true},
+		{Name: "direction", Description: "upstream or downstream"},
+		{Name: "depth"},
+	}
+	if !slices.Equal(meta.arguments, want) {
+		t.Errorf("arguments = %+v, want %+v", meta.arguments, want)
+	}
+}
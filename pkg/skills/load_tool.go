@@ -16,13 +16,11 @@ type loadInput struct {
 }
 
 type loadOutput struct {
-	Found       bool     `json:"found" jsonschema:"True if the skill (and resource, if requested) exists."`
 	Name        string   `json:"name,omitempty" jsonschema:"The resolved skill name."`
 	Description string   `json:"description,omitempty" jsonschema:"One-line summary."`
 	Related     []string `json:"related,omitempty" jsonschema:"Related skill names worth loading together."`
 	Resources   []string `json:"resources,omitempty" jsonschema:"Relative paths of bundled reference files on this skill."`
 	Content     string   `json:"content,omitempty" jsonschema:"The Markdown body of the skill or the requested resource. Empty when headerOnly is true."`
-	Error       string   `json:"error,omitempty" jsonschema:"Set when found is false. Explains why the skill or resource was not located."`
 }
 
 // NewLoadTool returns the load_collibra_skill tool wired to the given catalog.
@@ -48,55 +46,50 @@ func loadHandler(catalog *Catalog) chip.ToolHandlerFunc[loadInput, loadOutput] {
 	return func(_ context.Context, input loadInput) (loadOutput, error) {
 		skill := catalog.Get(input.SkillName)
 		if skill == nil {
-			return loadOutput{
-				Error: fmt.Sprintf("unknown skill %q. Call list_collibra_skills to see available names.", input.SkillName),
-			}, nil
+			return loadOutput{}, chip.Errorf(chip.ErrorNotFound, "unknown skill %q. Call list_collibra_skills to see available names.", input.SkillName)
 		}
 		if input.ResourcePath != "" {
-			return loadResource(skill, input.ResourcePath), nil
+			return loadResource(skill, input.ResourcePath)
 		}
 		return loadSkillContent(skill, input.HeaderOnly), nil
 	}
 }
 
-func loadResource(skill *Skill, path string) loadOutput {
+func loadResource(skill *Skill, path string) (loadOutput, error) {
 	resource := skill.Resource(path)
 	if resource == nil {
 		available := "none"
 		if paths := skill.ResourcePaths(); len(paths) > 0 {
 			available = strings.Join(paths, ", ")
 		}
-		return loadOutput{
-			Error: fmt.Sprintf("skill %q has no resource %q. Available: %s",
-				skill.Name, path, available),
-		}
+		return loadOutput{}, chip.Errorf(chip.ErrorNotFound, "skill %q has no resource %q. Available: %s",
+			skill.Name, path, available)
 	}
 	return loadOutput{
-		Found:   true,
 		Name:    skill.Name,
 		Content: resource.Content,
-	}
+	}, nil
 }
 
 func loadSkillContent(skill *Skill, headerOnly bool) loadOutput {
 	out := loadOutput{
-		Found:       true,
 		Name:        skill.Name,
 		Description: skill.Description,
 		Related:     skill.Related,
 		Resources:   skill.ResourcePaths(),
 	}
 	if !headerOnly {
-		out.Content = renderBody(skill)
+		out.Content = renderBody(skill, nil)
 	}
 	return out
 }
 
-// renderBody appends a trailer with bundled resources and related skills.
-// The body stays free of catalog metadata so authors only update one place.
-func renderBody(skill *Skill) string {
+// renderBody renders the body with the given argument values and appends a
+// trailer with bundled resources and related skills. The body stays free of
+// catalog metadata so authors only update one place.
+func renderBody(skill *Skill, values map[string]string) string {
 	var b strings.Builder
-	b.WriteString(skill.Body)
+	b.WriteString(skill.Render(values))
 	if len(skill.Resources) > 0 {
 		b.WriteString("\n\n---\nBundled resources (load via `load_collibra_skill` with `resourcePath`):\n")
 		for _, r := range skill.Resources {
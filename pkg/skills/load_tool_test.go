@@ -3,6 +3,8 @@ package skills
 import (
 	"strings"
 	"testing"
+
+	"github.com/collibra/chip/pkg/chip"
 )
 
 func TestLoadHandler_fullBodyAppendsTrailer(t *testing.T) {
@@ -11,9 +13,6 @@ func TestLoadHandler_fullBodyAppendsTrailer(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if !out.Found {
-		t.Fatal("expected found=true")
-	}
 	if !strings.Contains(out.Content, "body") {
 		t.Errorf("content missing body: %q", out.Content)
 	}
@@ -31,9 +30,6 @@ func TestLoadHandler_headerOnlyOmitsBody(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if !out.Found {
-		t.Fatal("expected found=true")
-	}
 	if out.Content != "" {
 		t.Errorf("header-only should not include content, got %q", out.Content)
 	}
@@ -51,9 +47,6 @@ func TestLoadHandler_resourcePathReturnsResourceContent(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if !out.Found {
-		t.Fatal("expected found=true")
-	}
 	if out.Content != "notes" {
 		t.Errorf("content = %q, want %q", out.Content, "notes")
 	}
@@ -61,49 +54,31 @@ func TestLoadHandler_resourcePathReturnsResourceContent(t *testing.T) {
 
 func TestLoadHandler_unknownSkillReturnsError(t *testing.T) {
 	cat := newTestCatalog(t)
-	out, err := loadHandler(cat)(t.Context(), loadInput{SkillName: "collibra/missing"})
-	if err != nil {
-		t.Fatal(err)
-	}
-	if out.Found {
-		t.Fatal("expected found=false")
-	}
-	if out.Error == "" {
-		t.Error("expected error message")
+	_, err := loadHandler(cat)(t.Context(), loadInput{SkillName: "collibra/missing"})
+	if chip.ErrorCodeOf(err) != chip.ErrorNotFound {
+		t.Fatalf("expected a not found error, got %v", err)
 	}
 }
 
 func TestLoadHandler_unknownResourceListsAvailable(t *testing.T) {
 	cat := newTestCatalog(t)
-	out, err := loadHandler(cat)(t.Context(), loadInput{
+	_, err := loadHandler(cat)(t.Context(), loadInput{
 		SkillName:    "collibra/lineage",
 		ResourcePath: "references/nope.md",
 	})
-	if err != nil {
-		t.Fatal(err)
-	}
-	if out.Found {
-		t.Fatal("expected found=false")
-	}
-	if !strings.Contains(out.Error, "references/notes.md") {
-		t.Errorf("error should list available resources, got %q", out.Error)
+	if chip.ErrorCodeOf(err) != chip.ErrorNotFound || !strings.Contains(err.Error(), "references/notes.md") {
+		t.Errorf("error should list available resources, got %v", err)
 	}
 }
 
 func TestLoadHandler_resourcePathOnSkillWithoutResources(t *testing.T) {
 	cat := newTestCatalog(t)
-	out, err := loadHandler(cat)(t.Context(), loadInput{
+	_, err := loadHandler(cat)(t.Context(), loadInput{
 		SkillName:    "collibra/discovery",
 		ResourcePath: "references/anything.md",
 	})
-	if err != nil {
-		t.Fatal(err)
-	}
-	if out.Found {
-		t.Fatal("expected found=false")
-	}
-	if !strings.Contains(out.Error, "Available: none") {
-		t.Errorf("error should say 'Available: none' when skill has no resources, got %q", out.Error)
+	if chip.ErrorCodeOf(err) != chip.ErrorNotFound || !strings.Contains(err.Error(), "Available: none") {
+		t.Errorf("error should say 'Available: none' when skill has no resources, got %v", err)
 	}
 }
 
@@ -117,9 +92,6 @@ func TestLoadHandler_resourcePathTakesPrecedenceOverHeaderOnly(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if !out.Found {
-		t.Fatal("expected found=true")
-	}
 	if out.Content != "notes" {
 		t.Errorf("resourcePath should win over headerOnly; content = %q, want %q", out.Content, "notes")
 	}
@@ -131,9 +103,6 @@ func TestLoadHandler_skillWithoutRelatedOrResources(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if !out.Found {
-		t.Fatal("expected found=true")
-	}
 	if strings.Contains(out.Content, "Bundled resources") {
 		t.Error("should not include resources trailer when no resources exist")
 	}
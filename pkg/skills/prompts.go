@@ -0,0 +1,94 @@
+package skills
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/collibra/chip/pkg/chip"
+	"github.com/modelcontextprotocol/go-sdk/jsonrpc"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// RelatedMetaKey is the _meta key listing the related skills of a prompt.
+const RelatedMetaKey = "collibra.com/relatedSkills"
+
+// registeredPrompts remembers the prompt names registered on each server, so
+// prompts of skills that are gone, or of a disabled feature, can be removed.
+var registeredPrompts sync.Map // *chip.Server -> []string
+
+// registerPrompts registers every skill of catalog as an MCP prompt, so users
+// can pick a workflow from their client's prompt menu, and removes the
+// prompts of skills no longer in the catalog.
+func registerPrompts(server *chip.Server, catalog *Catalog) {
+	var names []string
+	for _, skill := range catalog.List() {
+		server.AddPrompt(newPrompt(skill), promptHandler(skill))
+		names = append(names, skill.Name)
+	}
+	if previous, ok := registeredPrompts.Swap(server, names); ok {
+		var gone []string
+		for _, name := range previous.([]string) {
+			if catalog.Get(name) == nil {
+				gone = append(gone, name)
+			}
+		}
+		if len(gone) > 0 {
+			server.RemovePrompts(gone...)
+		}
+	}
+}
+
+// RemovePrompts removes the prompts registered by RegisterAll, for when the
+// skills feature is turned off while chip runs.
+func RemovePrompts(server *chip.Server) {
+	if previous, ok := registeredPrompts.LoadAndDelete(server); ok {
+		server.RemovePrompts(previous.([]string)...)
+	}
+}
+
+func newPrompt(skill *Skill) *mcp.Prompt {
+	prompt := &mcp.Prompt{
+		Name:        skill.Name,
+		Title:       skill.Title,
+		Description: skill.Description,
+	}
+	for _, argument := range skill.Arguments {
+		prompt.Arguments = append(prompt.Arguments, &mcp.PromptArgument{
+			Name:        argument.Name,
+			Description: argument.Description,
+			Required:    argument.Required,
+		})
+	}
+	if len(skill.Related) > 0 {
+		prompt.Meta = mcp.Meta{RelatedMetaKey: skill.Related}
+	}
+	return prompt
+}
+
+// promptHandler returns the skill body, with the arguments substituted, as
+// a user message.
+func promptHandler(skill *Skill) mcp.PromptHandler {
+	return func(_ context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		var missing []string
+		for _, argument := range skill.Arguments {
+			if argument.Required && strings.TrimSpace(req.Params.Arguments[argument.Name]) == "" {
+				missing = append(missing, argument.Name)
+			}
+		}
+		if len(missing) > 0 {
+			return nil, &jsonrpc.Error{
+				Code:    jsonrpc.CodeInvalidParams,
+				Message: fmt.Sprintf("prompt %q requires the arguments: %s", skill.Name, strings.Join(missing, ", ")),
+			}
+		}
+		return &mcp.GetPromptResult{
+			Description: skill.Description,
+			Messages: []*mcp.PromptMessage{{
+				Role:    "user",
+				Content: &mcp.TextContent{Text: renderBody(skill, req.Params.Arguments)},
+			}},
+		}, nil
+	}
+}
@@ -0,0 +1,97 @@
+package skills
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/collibra/chip/pkg/chip"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func connectPrompts(t *testing.T, server *chip.Server) *mcp.ClientSession {
+	t.Helper()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	if _, err := server.Connect(t.Context(), serverTransport, nil); err != nil {
+		t.Fatal(err)
+	}
+	session, err := mcp.NewClient(&mcp.Implementation{Name: "test"}, nil).Connect(t.Context(), clientTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = session.Close() })
+	return session
+}
+
+func TestRegisterAll_servesSkillsAsPrompts(t *testing.T) {
+	server := chip.NewServer()
+	if err := RegisterAll(server, ""); err != nil {
+		t.Fatal(err)
+	}
+	session := connectPrompts(t, server)
+
+	res, err := session.ListPrompts(t.Context(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var lineage *mcp.Prompt
+	for _, prompt := range res.Prompts {
+		if prompt.Name == "collibra/lineage" {
+			lineage = prompt
+		}
+	}
+	if lineage == nil {
+		t.Fatalf("expected a collibra/lineage prompt, got %d prompts", len(res.Prompts))
+	}
+	if lineage.Title != "Trace lineage for…" || lineage.Description == "" {
+		t.Errorf("unexpected title or description: %+v", lineage)
+	}
+	if len(lineage.Arguments) != 2 || !lineage.Arguments[0].Required || lineage.Arguments[1].Required {
+		t.Errorf("expected a required asset and an optional direction argument, got %+v", lineage.Arguments)
+	}
+	if related, _ := lineage.Meta[RelatedMetaKey].([]any); len(related) == 0 {
+		t.Errorf("expected the related skills in _meta, got %v", lineage.Meta)
+	}
+
+	got, err := session.GetPrompt(t.Context(), &mcp.GetPromptParams{Name: "collibra/lineage", Arguments: map[string]string{"asset": "orders"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := got.Messages[0].Content.(*mcp.TextContent).Text
+	if !strings.Contains(text, "trace the lineage of orders.") {
+		t.Errorf("expected the asset to be substituted, got %q", text[:200])
+	}
+	if strings.Contains(text, "{{") || strings.Contains(text, "**Direction:**") {
+		t.Errorf("expected the line of the missing direction to be left out, got %q", text[:200])
+	}
+
+	if _, err := session.GetPrompt(t.Context(), &mcp.GetPromptParams{Name: "collibra/lineage"}); err == nil || !strings.Contains(err.Error(), "asset") {
+		t.Errorf("expected an error naming the missing asset argument, got %v", err)
+	}
+}
+
+func TestRemovePrompts(t *testing.T) {
+	server := chip.NewServer()
+	if err := RegisterAll(server, ""); err != nil {
+		t.Fatal(err)
+	}
+	RemovePrompts(server)
+	session := connectPrompts(t, server)
+
+	res, err := session.ListPrompts(t.Context(), nil)
+	if err == nil && len(res.Prompts) > 0 {
+		t.Errorf("expected no prompts, got %d", len(res.Prompts))
+	}
+}
+
+func TestSkillRender_leavesOutLinesWithoutValues(t *testing.T) {
+	skill := &Skill{
+		Arguments: []Argument{{Name: "asset", Required: true}, {Name: "direction"}},
+		Body:      "# Lineage\n\nTrace {{asset}}.\nDirection: {{direction}}.\n\nRest.",
+	}
+	if got, want := skill.Render(map[string]string{"asset": "orders"}), "# Lineage\n\nTrace orders.\n\nRest."; got != want {
+		t.Errorf("Render = %q, want %q", got, want)
+	}
+	if got, want := skill.Render(nil), "# Lineage\n\nRest."; got != want {
+		t.Errorf("Render(nil) = %q, want %q", got, want)
+	}
+}
@@ -58,9 +58,9 @@ func Enabled(toolConfig *chip.ServerToolConfig) bool {
 }
 
 // RegisterAll loads the embedded catalog, optionally overlays it with
-// skills from externalDir, and registers the two skill tools. An empty
-// externalDir means embedded-only. Callers must check Enabled first;
-// RegisterAll does not re-gate.
+// skills from externalDir, and registers the two skill tools and a prompt
+// per skill. An empty externalDir means embedded-only. Callers must check
+// Enabled first; RegisterAll does not re-gate.
 func RegisterAll(server *chip.Server, externalDir string) error {
 	catalog, err := LoadWith(externalDir)
 	if err != nil {
@@ -68,5 +68,6 @@ func RegisterAll(server *chip.Server, externalDir string) error {
 	}
 	chip.RegisterTool(server, NewListTool(catalog))
 	chip.RegisterTool(server, NewLoadTool(catalog))
+	registerPrompts(server, catalog)
 	return nil
 }
@@ -19,7 +19,6 @@ type Input struct {
 type Output struct {
 	Match   *clients.DataClassificationMatch `json:"match,omitempty" jsonschema:"The created classification match with all its properties"`
 	Success bool                             `json:"success" jsonschema:"Whether the classification was successfully applied to the asset"`
-	Error   string                           `json:"error,omitempty" jsonschema:"Error message if the operation failed"`
 }
 
 func NewTool(collibraClient *http.Client) *chip.Tool[Input, Output] {
@@ -49,10 +48,7 @@ func handler(collibraClient *http.Client) chip.ToolHandlerFunc[Input, Output] {
 
 		match, err := clients.AddDataClassificationMatch(ctx, collibraClient, request)
 		if err != nil {
-			return Output{
-				Success: false,
-				Error:   fmt.Sprintf("Failed to add classification match: %s", err.Error()),
-			}, nil
+			return Output{}, fmt.Errorf("failed to add classification match: %w", err)
 		}
 
 		return Output{
@@ -5,6 +5,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/collibra/chip/pkg/chip"
 	tools "github.com/collibra/chip/pkg/tools/add_data_classification_match"
 	"github.com/collibra/chip/pkg/tools/testutil"
 )
@@ -54,7 +55,7 @@ func TestAddClassificationMatch_Success(t *testing.T) {
 	}
 
 	if !output.Success {
-		t.Errorf("Expected success=true, got false. Error: %s", output.Error)
+		t.Error("Expected success=true, got false")
 	}
 
 	if output.Match == nil {
@@ -117,18 +118,9 @@ func TestAddClassificationMatch_AssetNotFound(t *testing.T) {
 		ClassificationID: "be45c001-b173-48ff-ac91-3f6e45868c8b",
 	}
 
-	output, err := tools.NewTool(client).Handler(t.Context(), input)
-
-	if err != nil {
-		t.Fatalf("Expected no error, got %v", err)
-	}
-
-	if output.Success {
-		t.Error("Expected success=false for asset not found")
-	}
-
-	if output.Error == "" {
-		t.Error("Expected error message for asset not found")
+	_, err := tools.NewTool(client).Handler(t.Context(), input)
+	if chip.ErrorCodeOf(err) != chip.ErrorNotFound {
+		t.Errorf("expected a not found error, got %v", err)
 	}
 }
 
@@ -149,17 +141,8 @@ func TestAddClassificationMatch_AlreadyExists(t *testing.T) {
 		ClassificationID: "be45c001-b173-48ff-ac91-3f6e45868c8b",
 	}
 
-	output, err := tools.NewTool(client).Handler(t.Context(), input)
-
-	if err != nil {
-		t.Fatalf("Expected no error, got %v", err)
-	}
-
-	if output.Success {
-		t.Error("Expected success=false for already existing match")
-	}
-
-	if output.Error == "" {
-		t.Error("Expected error message for already existing match")
+	_, err := tools.NewTool(client).Handler(t.Context(), input)
+	if chip.ErrorCodeOf(err) != chip.ErrorValidation {
+		t.Errorf("expected a validation error, got %v", err)
 	}
 }
@@ -38,7 +38,6 @@ type InputAssignee struct {
 // Output is the typed response.
 type Output struct {
 	Assessment *AssessmentSummary `json:"assessment,omitempty" jsonschema:"the created assessment, on success. Its questions are unanswered — fill them in with edit_assessment."`
-	Error      string             `json:"error,omitempty" jsonschema:"error message when creation failed."`
 }
 
 // AssessmentSummary is the post-create snapshot of the assessment.
@@ -124,7 +123,7 @@ func handler(collibraClient *http.Client) chip.ToolHandlerFunc[Input, Output] {
 
 		assessment, err := clients.CreateAssessment(ctx, collibraClient, req)
 		if err != nil {
-			return Output{Error: fmt.Sprintf("Could not create assessment: %v", err)}, nil
+			return Output{}, fmt.Errorf("could not create assessment: %w", err)
 		}
 
 		return Output{Assessment: summarise(assessment)}, nil
@@ -47,9 +47,6 @@ const (
 	// any write — invalid asset type/domain, type not allowed in domain,
 	// unknown attribute, etc. The message includes suggestions.
 	StatusValidationError OutputStatus = "validation_error"
-	// StatusError means the asset itself could not be created due to a
-	// downstream Collibra error.
-	StatusError OutputStatus = "error"
 )
 
 // Input is the tool's typed input.
@@ -73,7 +70,7 @@ type InputAttribute struct {
 
 // Output is the typed response.
 type Output struct {
-	Status           OutputStatus      `json:"status" jsonschema:"success when the asset was created; duplicate_found when a same-named asset exists and allowDuplicate is false; validation_error for unresolved inputs; error for downstream Collibra failures."`
+	Status           OutputStatus      `json:"status" jsonschema:"success when the asset was created; duplicate_found when a same-named asset exists and allowDuplicate is false; validation_error for unresolved inputs. Downstream Collibra failures fail the call instead."`
 	Message          string            `json:"message" jsonschema:"Human-readable summary, including suggestions when validation fails."`
 	Asset            *AssetSummary     `json:"asset,omitempty" jsonschema:"The newly created asset, on success."`
 	Duplicates       []DuplicateInfo   `json:"duplicates,omitempty" jsonschema:"Existing assets that would conflict, on duplicate_found."`
@@ -181,7 +178,7 @@ func handler(collibraClient *http.Client) chip.ToolHandlerFunc[Input, Output] {
 			ExcludeFromAutoHyperlinking: input.ExcludeFromAutoHyperlinking,
 		})
 		if err != nil {
-			return Output{Status: StatusError, Message: fmt.Sprintf("Could not create asset: %v", err)}, nil
+			return Output{}, fmt.Errorf("could not create asset: %w", err)
 		}
 
 		attrResults := writeAttributes(ctx, collibraClient, assetResp.ID, resolvedAttrs)
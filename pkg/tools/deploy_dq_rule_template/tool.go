@@ -126,10 +126,18 @@ func handler(collibraClient *http.Client) chip.ToolHandlerFunc[Input, Output] {
 			}, nil
 		}
 
+		// The DQ service deploys every target in one request, so progress
+		// moves from none to all targets once it answers. A cancelled call
+		// deploys nothing.
+		if err := ctx.Err(); err != nil {
+			return Output{}, err
+		}
+		chip.NotifyProgress(ctx, 0, len(targets), fmt.Sprintf("Deploying template %q to %d target(s)", ruleTemplateName, len(targets)))
 		result, err := clients.DeployDQRuleTemplate(ctx, collibraClient, ruleTemplateName, targets)
 		if err != nil {
 			return Output{Status: StatusError, Message: fmt.Sprintf("Could not deploy template: %v", err)}, nil
 		}
+		chip.NotifyProgress(ctx, len(targets), len(targets), "Deployed")
 
 		// Partial-success: surface each target's outcome and tally deployed vs
 		// skipped. A target counts as deployed when the server assigned it a rule
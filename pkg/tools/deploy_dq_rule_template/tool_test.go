@@ -1,7 +1,9 @@
 package deploy_dq_rule_template_test
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -134,6 +136,29 @@ func TestDeployDQRuleTemplate_DownstreamErrorSurfaces(t *testing.T) {
 	}
 }
 
+func TestDeployDQRuleTemplate_CancelledCallDeploysNothing(t *testing.T) {
+	var rec capture
+	c := server(t, http.StatusOK, nil, &rec)
+	// The DQ service deploys all targets in one request, so the only point
+	// the client can cancel at is before it is sent.
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	_, err := deploy_dq_rule_template.NewTool(c).Handler(ctx, deploy_dq_rule_template.Input{
+		RuleTemplateName: "t1",
+		Targets: []deploy_dq_rule_template.Target{
+			{JobName: "PUBLIC.CUSTOMERS", ColumnName: "email"},
+			{JobName: "PUBLIC.CUSTOMERS", ColumnName: "name"},
+		},
+		Confirm: true,
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the call to fail as cancelled, got %v", err)
+	}
+	if rec.path != "" {
+		t.Fatalf("expected no deploy request after cancellation, got %s", rec.path)
+	}
+}
+
 func TestDeployDQRuleTemplate_PreviewByDefault_DeploysNothing(t *testing.T) {
 	var rec capture
 	c := server(t, http.StatusOK, nil, &rec)
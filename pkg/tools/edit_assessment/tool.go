@@ -97,12 +97,12 @@ const (
 
 // Output is the tool's typed output. Mirrors edit_asset: an overall status,
 // per-operation results, the updated assessment on success, and an Error string
-// for a whole-request failure.
+// when operations failed validation. A failed API call fails the tool call.
 type Output struct {
-	Status     OutputStatus        `json:"status" jsonschema:"Overall status: success if the PATCH applied, error if any operation failed validation or the API call failed. The PATCH is atomic — there is no partial success."`
-	Results    []OperationResult   `json:"results" jsonschema:"Per-operation outcomes, in the same order as the input operations. On validation failure the failing operations carry an error message; on API failure every operation is marked error."`
+	Status     OutputStatus        `json:"status" jsonschema:"Overall status: success if the PATCH applied, error if any operation failed validation. The PATCH is atomic — there is no partial success."`
+	Results    []OperationResult   `json:"results" jsonschema:"Per-operation outcomes, in the same order as the input operations. On validation failure the failing operations carry an error message."`
 	Assessment *clients.Assessment `json:"assessment,omitempty" jsonschema:"The assessment's state after the update. Present only on success."`
-	Error      string              `json:"error,omitempty" jsonschema:"Populated when the overall request could not be completed (assessment not found, validation failure, or API/permission error). Per-operation detail lives in Results."`
+	Error      string              `json:"error,omitempty" jsonschema:"Populated when operations failed validation and nothing was applied. Per-operation detail lives in Results."`
 }
 
 // OperationResult is the outcome of a single operation.
@@ -160,7 +160,7 @@ func handler(collibraClient *http.Client) chip.ToolHandlerFunc[Input, Output] {
 		// Phase 1: fetch the assessment once — source of truth for question types.
 		assessment, err := clients.GetAssessment(ctx, collibraClient, assessmentID)
 		if err != nil {
-			return Output{Status: StatusError, Error: fmt.Sprintf("assessment %s not found or unreadable: %s", assessmentID, err.Error())}, nil
+			return Output{}, fmt.Errorf("assessment %s not found or unreadable: %w", assessmentID, err)
 		}
 
 		// Index questions by id so set_answer can look up the answer type.
@@ -286,14 +286,7 @@ func handler(collibraClient *http.Client) chip.ToolHandlerFunc[Input, Output] {
 		// Phase 4: single PATCH.
 		updated, err := clients.UpdateAssessment(ctx, collibraClient, assessmentID, req)
 		if err != nil {
-			for i := range results {
-				results[i].Status = "error"
-			}
-			return Output{
-				Status:  StatusError,
-				Results: results,
-				Error:   fmt.Sprintf("failed to update assessment: %s", err.Error()),
-			}, nil
+			return Output{}, fmt.Errorf("failed to update assessment: %w", err)
 		}
 
 		return Output{
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/collibra/chip/pkg/chip"
 	"github.com/collibra/chip/pkg/clients"
 )
 
@@ -24,10 +25,12 @@ func executeValidPlans(ctx context.Context, client *http.Client, ec *editContext
 	// add_attribute is always a create (append). Grouping by resolved action
 	// keeps the create/patch bulk endpoints correct regardless of tool choice.
 	var createAttrIdx, patchAttrIdx, addRelIdx []int
+	pending := 0
 	for i, p := range plans {
 		if p.result.Status == "error" {
 			continue
 		}
+		pending++
 		switch p.op.Type {
 		case OpAddAttribute:
 			createAttrIdx = append(createAttrIdx, i)
@@ -42,22 +45,37 @@ func executeValidPlans(ctx context.Context, client *http.Client, ec *editContext
 		}
 	}
 
+	// Report progress per executed op, and once the client cancels the call
+	// fail the ops not yet sent instead of issuing their requests.
+	done := 0
+	run := func(indices []int, message string, execute func()) {
+		if err := ctx.Err(); err != nil {
+			for _, idx := range indices {
+				plans[idx].result = newErrorResult(plans[idx].op, err.Error())
+			}
+			return
+		}
+		execute()
+		done += len(indices)
+		chip.NotifyProgress(ctx, done, pending, message)
+	}
+
 	// Bulk-eligible groups: dispatch as bulk if at-or-above threshold.
 	bulked := map[int]bool{}
 	if len(createAttrIdx) >= bulkThreshold {
-		executeBulkAddAttributes(ctx, client, ec, plans, createAttrIdx)
+		run(createAttrIdx, "Created attributes", func() { executeBulkAddAttributes(ctx, client, ec, plans, createAttrIdx) })
 		for _, i := range createAttrIdx {
 			bulked[i] = true
 		}
 	}
 	if len(patchAttrIdx) >= bulkThreshold {
-		executeBulkUpdateAttributes(ctx, client, plans, patchAttrIdx)
+		run(patchAttrIdx, "Updated attributes", func() { executeBulkUpdateAttributes(ctx, client, plans, patchAttrIdx) })
 		for _, i := range patchAttrIdx {
 			bulked[i] = true
 		}
 	}
 	if len(addRelIdx) >= bulkThreshold {
-		executeBulkAddRelations(ctx, client, ec, plans, addRelIdx)
+		run(addRelIdx, "Added relations", func() { executeBulkAddRelations(ctx, client, ec, plans, addRelIdx) })
 		for _, i := range addRelIdx {
 			bulked[i] = true
 		}
@@ -68,7 +86,7 @@ func executeValidPlans(ctx context.Context, client *http.Client, ec *editContext
 		if plans[i].result.Status == "error" || bulked[i] {
 			continue
 		}
-		plans[i] = executePlan(ctx, client, ec, plans[i])
+		run([]int{i}, "Applied "+string(plans[i].op.Type), func() { plans[i] = executePlan(ctx, client, ec, plans[i]) })
 	}
 }
 
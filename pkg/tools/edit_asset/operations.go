@@ -510,6 +510,10 @@ func executeRemoveResponsibility(ctx context.Context, client *http.Client, ec *e
 // left untouched. A failed attribute-type lookup falls back to the raw value.
 func resolveAttributeWriteValues(ctx context.Context, client *http.Client, plans []opPlan) {
 	for i := range plans {
+		// A cancelled call executes none of the plans, so stop fetching.
+		if ctx.Err() != nil {
+			return
+		}
 		p := &plans[i]
 		if p.result.Status == "error" {
 			continue
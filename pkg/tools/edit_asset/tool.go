@@ -109,10 +109,9 @@ const (
 
 // Output is the tool's typed output.
 type Output struct {
-	Status  OutputStatus      `json:"status" jsonschema:"Overall status: success if every operation applied, partial_success if some succeeded and some failed, error if every operation failed or the request could not be executed."`
+	Status  OutputStatus      `json:"status" jsonschema:"Overall status: success if every operation applied, partial_success if some succeeded and some failed, error if every operation failed. A request that could not start (e.g. the asset was not found) fails the tool call instead."`
 	Results []OperationResult `json:"results" jsonschema:"Per-operation outcomes, in the same order as the input operations."`
 	Asset   *AssetSummary     `json:"asset,omitempty" jsonschema:"The asset's state after applying successful operations. Present on success or partial_success."`
-	Error   string            `json:"error,omitempty" jsonschema:"Populated only when the overall request could not start (e.g. the asset was not found). Per-operation errors live in Results."`
 }
 
 // AssetSummary is the post-edit snapshot of the asset.
@@ -175,12 +174,12 @@ func handler(collibraClient *http.Client) chip.ToolHandlerFunc[Input, Output] {
 			return Output{}, err
 		}
 		if len(input.Operations) == 0 {
-			return Output{}, fmt.Errorf("operations must not be empty")
+			return Output{}, chip.Errorf(chip.ErrorValidation, "operations must not be empty")
 		}
 
 		ec, err := newEditContext(ctx, collibraClient, input.AssetID, input.Operations)
 		if err != nil {
-			return Output{Status: StatusError, Error: err.Error()}, nil
+			return Output{}, err
 		}
 
 		// Two-phase execution: validate every op first, then run the ones that
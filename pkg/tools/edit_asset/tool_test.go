@@ -1,12 +1,14 @@
 package edit_asset_test
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 
+	"github.com/collibra/chip/pkg/chip"
 	"github.com/collibra/chip/pkg/clients"
 	"github.com/collibra/chip/pkg/tools/edit_asset"
 	"github.com/collibra/chip/pkg/tools/testutil"
@@ -930,17 +932,14 @@ func TestEditAsset_UnsupportedPropertyField(t *testing.T) {
 func TestEditAsset_AssetNotFound(t *testing.T) {
 	s := newStub()
 	s.assetNotFound = true
-	out, err := runTool(t, s, edit_asset.Input{
+	_, err := runTool(t, s, edit_asset.Input{
 		AssetID: testAssetID,
 		Operations: []edit_asset.Operation{{
 			Type: edit_asset.OpUpdateAttribute, AttributeName: "Definition", Value: "x",
 		}},
 	})
-	if err != nil {
-		t.Fatalf("unexpected error: %v", err)
-	}
-	if out.Status != edit_asset.StatusError || out.Error == "" {
-		t.Fatalf("expected top-level error on asset not found, got %+v", out)
+	if chip.ErrorCodeOf(err) != chip.ErrorNotFound {
+		t.Fatalf("expected a not found error, got %v", err)
 	}
 }
 
@@ -1609,6 +1608,39 @@ func TestEditAsset_BulkAddAttributes(t *testing.T) {
 	}
 }
 
+func TestEditAsset_CancelledCallSendsNoFurtherOperations(t *testing.T) {
+	s := newStub()
+	ctx, cancel := context.WithCancel(t.Context())
+	mux := http.NewServeMux()
+	s.install(mux, t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The client cancels the call while the bulk create is in flight.
+		if r.Method == http.MethodPost && r.URL.Path == "/rest/2.0/attributes/bulk" {
+			cancel()
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	out, err := edit_asset.NewTool(testutil.NewClient(srv)).Handler(ctx, edit_asset.Input{
+		AssetID: testAssetID,
+		Operations: []edit_asset.Operation{
+			{Type: edit_asset.OpAddAttribute, AttributeName: "Note", Value: "one"},
+			{Type: edit_asset.OpAddAttribute, AttributeName: "Acronym", Value: "CR"},
+			{Type: edit_asset.OpAddTag, Tag: "finance"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(s.addedTags) != 0 {
+		t.Fatalf("expected no tag request after cancellation, got %+v", s.addedTags)
+	}
+	if r := out.Results[2]; r.Status != "error" || !strings.Contains(r.Error, context.Canceled.Error()) {
+		t.Fatalf("expected the tag op to fail as cancelled, got %+v", r)
+	}
+}
+
 func TestEditAsset_SingleAddAttributeUsesIndividualEndpoint(t *testing.T) {
 	s := newStub()
 	_, err := runTool(t, s, edit_asset.Input{
@@ -39,7 +39,6 @@ type Output struct {
 	// Assessments is set for a filtered lookup that matches more than one.
 	Assessments []clients.Assessment `json:"assessments,omitempty" jsonschema:"the matching assessments when a filtered lookup returns more than one. Each has the same shape as the assessment field."`
 	NextCursor  string               `json:"nextCursor,omitempty" jsonschema:"cursor for the next page of a filtered lookup, if more results exist; pass it back as 'cursor'."`
-	Error       string               `json:"error,omitempty" jsonschema:"error message if no assessment was found or an API error occurred"`
 	Found       bool                 `json:"found" jsonschema:"whether at least one assessment was found"`
 }
 
@@ -89,14 +88,14 @@ func handler(collibraClient *http.Client) chip.ToolHandlerFunc[Input, Output] {
 		if input.AssessmentID != "" {
 			assessment, err := clients.GetAssessment(ctx, collibraClient, input.AssessmentID)
 			if err != nil {
-				return notFound(err), nil
+				return Output{}, fmt.Errorf("failed to retrieve assessment: %w", err)
 			}
 			return Output{Assessment: assessment, Found: true}, nil
 		}
 		if input.AssessmentReviewID != "" {
 			assessment, err := clients.GetAssessmentByReview(ctx, collibraClient, input.AssessmentReviewID)
 			if err != nil {
-				return notFound(err), nil
+				return Output{}, fmt.Errorf("failed to retrieve assessment: %w", err)
 			}
 			return Output{Assessment: assessment, Found: true}, nil
 		}
@@ -125,9 +124,9 @@ func handler(collibraClient *http.Client) chip.ToolHandlerFunc[Input, Output] {
 			Cursor:           input.Cursor,
 		})
 		if err != nil {
-			return notFound(err), nil
+			return Output{}, fmt.Errorf("failed to retrieve assessments: %w", err)
 		}
-		out := fromList(paged.Results, "No assessments matched the given filters")
+		out := fromList(paged.Results)
 		out.NextCursor = paged.NextCursor
 		return out, nil
 	}
@@ -141,17 +140,13 @@ func hasFilters(in Input) bool {
 
 // fromList shapes a list lookup: one match returns as the single Assessment,
 // several as Assessments, none as a not-found result.
-func fromList(results []clients.Assessment, emptyMsg string) Output {
+func fromList(results []clients.Assessment) Output {
 	switch len(results) {
 	case 0:
-		return Output{Error: emptyMsg, Found: false}
+		return Output{Found: false}
 	case 1:
 		return Output{Assessment: &results[0], Found: true}
 	default:
 		return Output{Assessments: results, Found: true}
 	}
 }
-
-func notFound(err error) Output {
-	return Output{Error: fmt.Sprintf("Failed to retrieve assessment: %s", err.Error()), Found: false}
-}
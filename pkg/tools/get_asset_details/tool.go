@@ -30,7 +30,6 @@ type Output struct {
 	AssetContext           string                `json:"assetContext,omitempty" jsonschema:"the generated YAML context from the executed Context Specification. Only present when contextSpecificationId was provided and context generation succeeded."`
 	AssetContextError      string                `json:"assetContextError,omitempty" jsonschema:"error if context generation failed; main asset details are still returned."`
 	Link                   string                `json:"link,omitempty" jsonschema:"the link you can navigate to in Collibra to view the asset"`
-	Error                  string                `json:"error,omitempty" jsonschema:"error message if asset not found or other error occurred"`
 	Found                  bool                  `json:"found" jsonschema:"whether the asset was found"`
 }
 
@@ -71,11 +70,11 @@ func handler(collibraClient *http.Client, contextSpecsEnabled bool) chip.ToolHan
 
 		assets, err := clients.GetAssetSummary(ctx, collibraClient, assetUUID, input.OutgoingRelationsCursor, input.IncomingRelationsCursor)
 		if err != nil {
-			return Output{Error: fmt.Sprintf("Failed to retrieve asset details: %s", err.Error()), Found: false}, nil
+			return Output{}, fmt.Errorf("failed to retrieve asset details: %w", err)
 		}
 
 		if len(assets) == 0 {
-			return Output{Error: "Asset not found", Found: false}, nil
+			return Output{}, chip.Errorf(chip.ErrorNotFound, "asset %s not found", assetUUID)
 		}
 
 		collibraHost, ok := chip.GetCollibraHost(ctx)
@@ -2,6 +2,7 @@ package get_business_term_data
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
 	"github.com/collibra/chip/pkg/chip"
@@ -32,7 +33,6 @@ type Input struct {
 type Output struct {
 	BusinessTermID        string      `json:"businessTermId" jsonschema:"The Business Term asset ID."`
 	ConnectedPhysicalData []Attribute `json:"connectedPhysicalData" jsonschema:"The data attributes with their connected columns and tables."`
-	Error                 string      `json:"error,omitempty" jsonschema:"Error message if the operation failed."`
 }
 
 type Attribute struct {
@@ -65,8 +65,14 @@ func handler(collibraClient *http.Client) chip.ToolHandlerFunc[Input, Output] {
 			return Output{}, err
 		}
 
+		// Each data attribute costs several upstream calls, so report progress
+		// per data attribute and stop as soon as the client cancels the call.
 		physicalData := make([]Attribute, 0, len(dataAttributes))
-		for _, da := range dataAttributes {
+		for i, da := range dataAttributes {
+			if err := ctx.Err(); err != nil {
+				return Output{}, err
+			}
+			chip.NotifyProgress(ctx, i, len(dataAttributes), fmt.Sprintf("Data attribute %s", da.Name))
 			daDescription := clients.FetchDescription(ctx, collibraClient, da.ID)
 
 			columns, err := clients.FindColumnsForDataAttribute(ctx, collibraClient, da.ID)
@@ -76,6 +82,9 @@ func handler(collibraClient *http.Client) chip.ToolHandlerFunc[Input, Output] {
 
 			columnsWithDetails := make([]ColumnWithTable, 0, len(columns))
 			for _, col := range columns {
+				if err := ctx.Err(); err != nil {
+					return Output{}, err
+				}
 				colDetail := ColumnWithTable{
 					ID:          col.ID,
 					Name:        col.Name,
@@ -108,6 +117,7 @@ func handler(collibraClient *http.Client) chip.ToolHandlerFunc[Input, Output] {
 				ConnectedColumns: columnsWithDetails,
 			})
 		}
+		chip.NotifyProgress(ctx, len(dataAttributes), len(dataAttributes), "Done")
 
 		return Output{
 			BusinessTermID:        input.BusinessTermID,
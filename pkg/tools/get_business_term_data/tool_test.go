@@ -0,0 +1,58 @@
+package get_business_term_data_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/collibra/chip/pkg/clients"
+	"github.com/collibra/chip/pkg/tools/get_business_term_data"
+	"github.com/collibra/chip/pkg/tools/testutil"
+)
+
+const (
+	businessTermID  = "00000000-0000-0000-0000-00000000b001"
+	dataAttributeID = "00000000-0000-0000-0000-00000000d001"
+	columnID        = "00000000-0000-0000-0000-00000000c001"
+)
+
+func TestGetBusinessTermData_CancelledCallSendsNoFurtherRequests(t *testing.T) {
+	handler := http.NewServeMux()
+	handler.Handle("/rest/2.0/relations", testutil.JsonHandlerOut(func(r *http.Request) (int, map[string]any) {
+		query := r.URL.Query()
+		switch {
+		case query.Get("sourceId") == businessTermID && query.Get("relationTypeId") == clients.BusinessAssetRepresentsDataAssetRelID:
+			return http.StatusOK, map[string]any{"results": []map[string]any{
+				{"source": map[string]any{"id": businessTermID}, "target": map[string]any{"id": dataAttributeID, "name": "Amount", "typeName": "Data Attribute"}},
+			}}
+		case query.Get("sourceId") == dataAttributeID && query.Get("relationTypeId") == clients.DataAttributeRepresentsColumnRelID:
+			return http.StatusOK, map[string]any{"results": []map[string]any{
+				{"source": map[string]any{"id": dataAttributeID}, "target": map[string]any{"id": columnID, "name": "amount", "typeName": "Column"}},
+			}}
+		}
+		return http.StatusOK, map[string]any{"results": []map[string]any{}}
+	}))
+	handler.Handle("/rest/2.0/attributes", testutil.JsonHandlerOut(func(r *http.Request) (int, map[string]any) {
+		return http.StatusOK, map[string]any{"results": []map[string]any{}}
+	}))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// The client cancels the call once the columns of the data attribute
+	// have been looked up, before their tables are.
+	ctx, cancel := context.WithCancel(t.Context())
+	client, requestsAfterCancel := testutil.NewCancellingClient(server, cancel, func(r *http.Request) bool {
+		query := r.URL.Query()
+		return query.Get("targetId") == dataAttributeID && query.Get("relationTypeId") == clients.ColumnIsSourceForDataAttributeRelID
+	})
+
+	_, err := get_business_term_data.NewTool(client).Handler(ctx, get_business_term_data.Input{BusinessTermID: businessTermID})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the call to fail as cancelled, got %v", err)
+	}
+	if n := requestsAfterCancel(); n != 0 {
+		t.Fatalf("expected no request after cancellation, got %d", n)
+	}
+}
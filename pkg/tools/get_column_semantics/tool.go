@@ -24,7 +24,6 @@ type Input struct {
 
 type Output struct {
 	Semantics []DataAttributeSemantics `json:"semantics" jsonschema:"The list of data attributes with their connected measures and business assets."`
-	Error     string                   `json:"error,omitempty" jsonschema:"Error message if the operation failed."`
 }
 
 type DataAttributeSemantics struct {
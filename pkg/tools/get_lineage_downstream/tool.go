@@ -46,7 +46,7 @@ func handleLineageDirectional(
 	fetch func(context.Context, *http.Client, string, string, int, string) (*clients.GetLineageDirectionalOutput, error),
 ) (clients.GetLineageDirectionalOutput, error) {
 	if entityId == "" {
-		return clients.GetLineageDirectionalOutput{Error: "entityId is required"}, nil
+		return clients.GetLineageDirectionalOutput{}, chip.Errorf(chip.ErrorValidation, "entityId is required")
 	}
 	result, err := fetch(ctx, collibraClient, entityId, entityType, limit, cursor)
 	if err != nil {
@@ -30,7 +30,7 @@ func NewTool(collibraClient *http.Client) *chip.Tool[Input, clients.GetLineageEn
 func handler(collibraClient *http.Client) chip.ToolHandlerFunc[Input, clients.GetLineageEntityOutput] {
 	return func(ctx context.Context, input Input) (clients.GetLineageEntityOutput, error) {
 		if input.EntityId == "" {
-			return clients.GetLineageEntityOutput{Found: false, Error: "entityId is required"}, nil
+			return clients.GetLineageEntityOutput{}, chip.Errorf(chip.ErrorValidation, "entityId is required")
 		}
 
 		result, err := clients.GetLineageEntity(ctx, collibraClient, input.EntityId)
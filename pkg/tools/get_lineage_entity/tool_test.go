@@ -5,6 +5,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/collibra/chip/pkg/chip"
 	"github.com/collibra/chip/pkg/clients"
 	tools "github.com/collibra/chip/pkg/tools/get_lineage_entity"
 	"github.com/collibra/chip/pkg/tools/testutil"
@@ -58,19 +59,11 @@ func TestGetLineageEntityNotFound(t *testing.T) {
 	defer server.Close()
 
 	client := testutil.NewClient(server)
-	output, err := tools.NewTool(client).Handler(t.Context(), tools.Input{
+	_, err := tools.NewTool(client).Handler(t.Context(), tools.Input{
 		EntityId: "entity-unknown",
 	})
-	if err != nil {
-		t.Fatalf("Expected no error, got: %v", err)
-	}
-
-	if output.Found {
-		t.Fatalf("Expected entity not to be found")
-	}
-
-	if output.Error == "" {
-		t.Fatalf("Expected an error message")
+	if chip.ErrorCodeOf(err) != chip.ErrorNotFound {
+		t.Errorf("expected a not found error, got %v", err)
 	}
 }
 
@@ -79,16 +72,8 @@ func TestGetLineageEntityMissingId(t *testing.T) {
 	defer server.Close()
 
 	client := testutil.NewClient(server)
-	output, err := tools.NewTool(client).Handler(t.Context(), tools.Input{})
-	if err != nil {
-		t.Fatalf("Expected no error, got: %v", err)
-	}
-
-	if output.Found {
-		t.Fatalf("Expected entity not to be found")
-	}
-
-	if output.Error == "" {
-		t.Fatalf("Expected an error message")
+	_, err := tools.NewTool(client).Handler(t.Context(), tools.Input{})
+	if chip.ErrorCodeOf(err) != chip.ErrorValidation {
+		t.Errorf("expected a validation error, got %v", err)
 	}
 }
@@ -30,7 +30,7 @@ func NewTool(collibraClient *http.Client) *chip.Tool[Input, clients.GetLineageTr
 func handler(collibraClient *http.Client) chip.ToolHandlerFunc[Input, clients.GetLineageTransformationOutput] {
 	return func(ctx context.Context, input Input) (clients.GetLineageTransformationOutput, error) {
 		if input.TransformationId == "" {
-			return clients.GetLineageTransformationOutput{Found: false, Error: "transformationId is required"}, nil
+			return clients.GetLineageTransformationOutput{}, chip.Errorf(chip.ErrorValidation, "transformationId is required")
 		}
 
 		result, err := clients.GetLineageTransformation(ctx, collibraClient, input.TransformationId)
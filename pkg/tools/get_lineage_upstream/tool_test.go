@@ -5,6 +5,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/collibra/chip/pkg/chip"
 	"github.com/collibra/chip/pkg/clients"
 	tools "github.com/collibra/chip/pkg/tools/get_lineage_upstream"
 	"github.com/collibra/chip/pkg/tools/testutil"
@@ -36,10 +37,6 @@ func TestGetLineageUpstream(t *testing.T) {
 		t.Fatalf("Expected no error, got: %v", err)
 	}
 
-	if output.Error != "" {
-		t.Fatalf("Expected no error in output, got: %s", output.Error)
-	}
-
 	if output.EntityId != "entity-1" {
 		t.Fatalf("Expected entityId 'entity-1', got: '%s'", output.EntityId)
 	}
@@ -72,19 +69,11 @@ func TestGetLineageUpstreamNotFound(t *testing.T) {
 	defer server.Close()
 
 	client := testutil.NewClient(server)
-	output, err := tools.NewTool(client).Handler(t.Context(), tools.Input{
+	_, err := tools.NewTool(client).Handler(t.Context(), tools.Input{
 		EntityId: "entity-unknown",
 	})
-	if err != nil {
-		t.Fatalf("Expected no error, got: %v", err)
-	}
-
-	if output.Error == "" {
-		t.Fatalf("Expected an error message")
-	}
-
-	if output.Relations == nil {
-		t.Fatalf("Expected Relations to be a non-nil slice, got nil")
+	if chip.ErrorCodeOf(err) != chip.ErrorNotFound {
+		t.Errorf("expected a not found error, got %v", err)
 	}
 }
 
@@ -93,12 +82,8 @@ func TestGetLineageUpstreamMissingId(t *testing.T) {
 	defer server.Close()
 
 	client := testutil.NewClient(server)
-	output, err := tools.NewTool(client).Handler(t.Context(), tools.Input{})
-	if err != nil {
-		t.Fatalf("Expected no error, got: %v", err)
-	}
-
-	if output.Error == "" {
-		t.Fatalf("Expected an error message")
+	_, err := tools.NewTool(client).Handler(t.Context(), tools.Input{})
+	if chip.ErrorCodeOf(err) != chip.ErrorValidation {
+		t.Errorf("expected a validation error, got %v", err)
 	}
 }
@@ -32,7 +32,6 @@ type Input struct {
 
 type Output struct {
 	DataHierarchy []Attribute `json:"dataHierarchy" jsonschema:"The list of data attributes with their connected columns and tables."`
-	Error         string      `json:"error,omitempty" jsonschema:"Error message if the operation failed."`
 }
 
 type Attribute struct {
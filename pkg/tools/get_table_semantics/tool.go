@@ -2,6 +2,7 @@ package get_table_semantics
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
 	"github.com/collibra/chip/pkg/chip"
@@ -24,7 +25,6 @@ type Input struct {
 type Output struct {
 	TableID           string                `json:"tableId" jsonschema:"The Table asset ID."`
 	SemanticHierarchy []ColumnWithSemantics `json:"semanticHierarchy" jsonschema:"The semantic hierarchy of columns with their data attributes and measures."`
-	Error             string                `json:"error,omitempty" jsonschema:"Error message if the operation failed."`
 }
 
 type ColumnWithSemantics struct {
@@ -65,8 +65,14 @@ func handler(collibraClient *http.Client) chip.ToolHandlerFunc[Input, Output] {
 			return Output{}, err
 		}
 
+		// Each column costs several upstream calls, so report progress per
+		// column and stop as soon as the client cancels the call.
 		columns := make([]ColumnWithSemantics, 0, len(rawColumns))
-		for _, col := range rawColumns {
+		for i, col := range rawColumns {
+			if err := ctx.Err(); err != nil {
+				return Output{}, err
+			}
+			chip.NotifyProgress(ctx, i, len(rawColumns), fmt.Sprintf("Column %s", col.Name))
 			colDescription := clients.FetchDescription(ctx, collibraClient, col.ID)
 
 			dataAttributes, err := clients.FindColumnsForDataAttribute(ctx, collibraClient, col.ID)
@@ -76,6 +82,9 @@ func handler(collibraClient *http.Client) chip.ToolHandlerFunc[Input, Output] {
 
 			das := make([]DataAttributeWithMeasures, 0, len(dataAttributes))
 			for _, da := range dataAttributes {
+				if err := ctx.Err(); err != nil {
+					return Output{}, err
+				}
 				daDescription := clients.FetchDescription(ctx, collibraClient, da.ID)
 
 				rawMeasures, err := clients.FindConnectedAssets(ctx, collibraClient, da.ID, clients.MeasureIsCalculatedUsingDataElementRelID)
@@ -85,6 +94,9 @@ func handler(collibraClient *http.Client) chip.ToolHandlerFunc[Input, Output] {
 
 				measures := make([]AssetWithDescription, 0, len(rawMeasures))
 				for _, m := range rawMeasures {
+					if err := ctx.Err(); err != nil {
+						return Output{}, err
+					}
 					measures = append(measures, AssetWithDescription{
 						ID:          m.ID,
 						Name:        m.Name,
@@ -110,6 +122,7 @@ func handler(collibraClient *http.Client) chip.ToolHandlerFunc[Input, Output] {
 				ConnectedDataAttributes: das,
 			})
 		}
+		chip.NotifyProgress(ctx, len(rawColumns), len(rawColumns), "Done")
 
 		return Output{
 			TableID:           input.TableID,
@@ -0,0 +1,54 @@
+package get_table_semantics_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/collibra/chip/pkg/clients"
+	"github.com/collibra/chip/pkg/tools/get_table_semantics"
+	"github.com/collibra/chip/pkg/tools/testutil"
+)
+
+const (
+	tableID   = "00000000-0000-0000-0000-00000000a001"
+	column1ID = "00000000-0000-0000-0000-00000000c001"
+	column2ID = "00000000-0000-0000-0000-00000000c002"
+)
+
+func TestGetTableSemantics_CancelledCallSendsNoFurtherRequests(t *testing.T) {
+	handler := http.NewServeMux()
+	handler.Handle("/rest/2.0/relations", testutil.JsonHandlerOut(func(r *http.Request) (int, map[string]any) {
+		query := r.URL.Query()
+		if query.Get("sourceId") == tableID && query.Get("relationTypeId") == clients.ColumnIsPartOfTableRelID {
+			return http.StatusOK, map[string]any{"results": []map[string]any{
+				{"source": map[string]any{"id": tableID}, "target": map[string]any{"id": column1ID, "name": "id", "typeName": "Column"}},
+				{"source": map[string]any{"id": tableID}, "target": map[string]any{"id": column2ID, "name": "amount", "typeName": "Column"}},
+			}}
+		}
+		return http.StatusOK, map[string]any{"results": []map[string]any{}}
+	}))
+	handler.Handle("/rest/2.0/attributes", testutil.JsonHandlerOut(func(r *http.Request) (int, map[string]any) {
+		return http.StatusOK, map[string]any{"results": []map[string]any{}}
+	}))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// The client cancels the call once the data attributes of the first
+	// column have been looked up, before the second column is.
+	ctx, cancel := context.WithCancel(t.Context())
+	client, requestsAfterCancel := testutil.NewCancellingClient(server, cancel, func(r *http.Request) bool {
+		query := r.URL.Query()
+		return query.Get("targetId") == column1ID && query.Get("relationTypeId") == clients.ColumnIsSourceForDataAttributeRelID
+	})
+
+	_, err := get_table_semantics.NewTool(client).Handler(ctx, get_table_semantics.Input{TableID: tableID})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the call to fail as cancelled, got %v", err)
+	}
+	if n := requestsAfterCancel(); n != 0 {
+		t.Fatalf("expected no request after cancellation, got %d", n)
+	}
+}
@@ -28,7 +28,6 @@ type Output struct {
 	DomainID      string `json:"domainId,omitempty" jsonschema:"The UUID of the domain where the data contract asset is located"`
 	ActiveVersion string `json:"activeVersion,omitempty" jsonschema:"The version value of the currently active data contract manifest"`
 	Format        string `json:"format,omitempty" jsonschema:"The format type of the active data contract manifest version. Possible values: ODCS, DCS, CUSTOM."`
-	Error         string `json:"error,omitempty" jsonschema:"Error message if the data contract could not be initialized"`
 	Success       bool   `json:"success" jsonschema:"Whether the data contract was successfully initialized"`
 }
 
@@ -63,10 +62,7 @@ func handler(collibraClient *http.Client) chip.ToolHandlerFunc[Input, Output] {
 
 		response, err := clients.InitDataContract(ctx, collibraClient, req)
 		if err != nil {
-			return Output{
-				Error:   fmt.Sprintf("Failed to initialize data contract: %s", err.Error()),
-				Success: false,
-			}, nil
+			return Output{}, fmt.Errorf("failed to initialize data contract: %w", err)
 		}
 
 		return Output{
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/collibra/chip/pkg/chip"
 	tools "github.com/collibra/chip/pkg/tools/init_data_contract"
 	"github.com/collibra/chip/pkg/tools/testutil"
 )
@@ -100,7 +101,7 @@ title: Sample Data Contract`
 	}
 
 	if !output.Success {
-		t.Fatalf("Expected success to be true, error: %s", output.Error)
+		t.Fatal("Expected success to be true")
 	}
 
 	if output.ID != "00000000-0000-0000-0000-000000000001" {
@@ -189,7 +190,7 @@ func TestInitDataContractWithoutManifest(t *testing.T) {
 	}
 
 	if !output.Success {
-		t.Fatalf("Expected success to be true, error: %s", output.Error)
+		t.Fatal("Expected success to be true")
 	}
 
 	if output.ID != "00000000-0000-0000-0000-000000000003" {
@@ -240,18 +241,10 @@ func TestInitDataContractServerError(t *testing.T) {
 	defer server.Close()
 
 	client := testutil.NewClient(server)
-	output, err := tools.NewTool(client).Handler(t.Context(), tools.Input{
+	_, err := tools.NewTool(client).Handler(t.Context(), tools.Input{
 		GovernedAssetID: governedAssetID,
 	})
-	if err != nil {
-		t.Fatalf("Expected no error, got: %v", err)
-	}
-
-	if output.Success {
-		t.Fatal("Expected success to be false")
-	}
-
-	if output.Error == "" {
-		t.Fatal("Expected error message for server error")
+	if chip.ErrorCodeOf(err) != chip.ErrorUpstream {
+		t.Errorf("expected an upstream error, got %v", err)
 	}
 }
@@ -586,3 +586,20 @@ func TestPrepare_AvailableStatusesAlwaysIncluded(t *testing.T) {
 		t.Errorf("expected availableStatuses to be populated")
 	}
 }
+
+func TestPrepare_BothResolved_ReplaysCassette(t *testing.T) {
+	c := testutil.NewCassetteClient(t, "business_term_in_glossary", testutil.Strict, testutil.Scrubber{})
+	out, err := prepare_create_asset.NewTool(c).Handler(t.Context(), prepare_create_asset.Input{
+		AssetType: btTypeName,
+		Domain:    glossaryDomain,
+	})
+	if err != nil || out.Status != prepare_create_asset.StatusReady {
+		t.Fatalf("want ready, got %q (%s) %v", out.Status, out.Message, err)
+	}
+	if out.Resolved.AssetTypeID != btTypeID || out.Resolved.DomainID != glossaryDomainID {
+		t.Errorf("resolved IDs: %#v", out.Resolved)
+	}
+	if len(out.AttributeSchema) != 2 || len(out.RelationTypes) != 2 {
+		t.Errorf("expected 2 attribute and 2 relation slots, got %d and %d", len(out.AttributeSchema), len(out.RelationTypes))
+	}
+}
@@ -18,7 +18,6 @@ type Input struct {
 
 type Output struct {
 	Manifest string `json:"manifest,omitempty" jsonschema:"The content of the active data contract manifest file"`
-	Error    string `json:"error,omitempty" jsonschema:"Error message if the manifest could not be retrieved"`
 	Found    bool   `json:"found" jsonschema:"Whether the manifest was found"`
 }
 
@@ -42,10 +41,7 @@ func handler(collibraClient *http.Client) chip.ToolHandlerFunc[Input, Output] {
 
 		manifest, err := clients.PullActiveDataContractManifest(ctx, collibraClient, dataContractUUID.String())
 		if err != nil {
-			return Output{
-				Error: fmt.Sprintf("Failed to download manifest: %s", err.Error()),
-				Found: false,
-			}, nil
+			return Output{}, fmt.Errorf("failed to download manifest: %w", err)
 		}
 
 		return Output{
@@ -5,6 +5,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/collibra/chip/pkg/chip"
 	tools "github.com/collibra/chip/pkg/tools/pull_data_contract_manifest"
 	"github.com/collibra/chip/pkg/tools/testutil"
 	"github.com/google/uuid"
@@ -40,10 +41,6 @@ func TestPullDataContractManifest(t *testing.T) {
 		t.Fatal("Expected manifest to be found")
 	}
 
-	if output.Error != "" {
-		t.Fatalf("Expected no error, got: %s", output.Error)
-	}
-
 	if output.Manifest != manifestContent {
 		t.Fatalf("Expected manifest content '%s', got: '%s'", manifestContent, output.Manifest)
 	}
@@ -72,18 +69,10 @@ func TestPullDataContractManifestNotFound(t *testing.T) {
 	defer server.Close()
 
 	client := testutil.NewClient(server)
-	output, err := tools.NewTool(client).Handler(t.Context(), tools.Input{
+	_, err := tools.NewTool(client).Handler(t.Context(), tools.Input{
 		DataContractID: contractId.String(),
 	})
-	if err != nil {
-		t.Fatalf("Expected no error, got: %v", err)
-	}
-
-	if output.Found {
-		t.Fatal("Expected manifest not to be found")
-	}
-
-	if output.Error == "" {
-		t.Fatal("Expected error message for not found")
+	if chip.ErrorCodeOf(err) != chip.ErrorNotFound {
+		t.Errorf("expected a not found error, got %v", err)
 	}
 }
@@ -22,7 +22,6 @@ type Output struct {
 	ID         string `json:"id,omitempty" jsonschema:"The UUID of the data contract asset"`
 	DomainID   string `json:"domainId,omitempty" jsonschema:"The UUID of the domain where the data contract asset is located"`
 	ManifestID string `json:"manifestId,omitempty" jsonschema:"The unique identifier of the data contract manifest"`
-	Error      string `json:"error,omitempty" jsonschema:"Error message if the manifest could not be uploaded"`
 	Success    bool   `json:"success" jsonschema:"Whether the manifest was successfully uploaded"`
 }
 
@@ -40,10 +39,7 @@ func NewTool(collibraClient *http.Client) *chip.Tool[Input, Output] {
 func handler(collibraClient *http.Client) chip.ToolHandlerFunc[Input, Output] {
 	return func(ctx context.Context, input Input) (Output, error) {
 		if input.Manifest == "" {
-			return Output{
-				Error:   "Manifest content is required",
-				Success: false,
-			}, nil
+			return Output{}, chip.Errorf(chip.ErrorValidation, "manifest content is required")
 		}
 
 		req := clients.PushDataContractManifestRequest{
@@ -56,10 +52,7 @@ func handler(collibraClient *http.Client) chip.ToolHandlerFunc[Input, Output] {
 
 		response, err := clients.PushDataContractManifest(ctx, collibraClient, req)
 		if err != nil {
-			return Output{
-				Error:   fmt.Sprintf("Failed to upload manifest: %s", err.Error()),
-				Success: false,
-			}, nil
+			return Output{}, fmt.Errorf("failed to upload manifest: %w", err)
 		}
 
 		return Output{
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/collibra/chip/pkg/chip"
 	tools "github.com/collibra/chip/pkg/tools/push_data_contract_manifest"
 	"github.com/collibra/chip/pkg/tools/testutil"
 )
@@ -82,10 +83,6 @@ description: This is a sample data contract manifest`
 		t.Fatal("Expected success to be true")
 	}
 
-	if output.Error != "" {
-		t.Fatalf("Expected no error, got: %s", output.Error)
-	}
-
 	if output.ID != "00000000-0000-0000-0000-000000000001" {
 		t.Fatalf("Expected ID '00000000-0000-0000-0000-000000000001', got: '%s'", output.ID)
 	}
@@ -160,10 +157,6 @@ func TestPushDataContractManifestWithOptionalParams(t *testing.T) {
 	if !output.Success {
 		t.Fatal("Expected success to be true")
 	}
-
-	if output.Error != "" {
-		t.Fatalf("Expected no error, got: %s", output.Error)
-	}
 }
 
 func TestPushDataContractManifestEmptyManifest(t *testing.T) {
@@ -171,19 +164,11 @@ func TestPushDataContractManifestEmptyManifest(t *testing.T) {
 	defer server.Close()
 
 	client := testutil.NewClient(server)
-	output, err := tools.NewTool(client).Handler(t.Context(), tools.Input{
+	_, err := tools.NewTool(client).Handler(t.Context(), tools.Input{
 		Manifest: "",
 	})
-	if err != nil {
-		t.Fatalf("Expected no error, got: %v", err)
-	}
-
-	if output.Success {
-		t.Fatal("Expected success to be false")
-	}
-
-	if output.Error == "" {
-		t.Fatal("Expected error message for empty manifest")
+	if chip.ErrorCodeOf(err) != chip.ErrorValidation {
+		t.Errorf("expected a validation error, got %v", err)
 	}
 }
 
@@ -200,18 +185,10 @@ kind: DataContract`
 	defer server.Close()
 
 	client := testutil.NewClient(server)
-	output, err := tools.NewTool(client).Handler(t.Context(), tools.Input{
+	_, err := tools.NewTool(client).Handler(t.Context(), tools.Input{
 		Manifest: manifestContent,
 	})
-	if err != nil {
-		t.Fatalf("Expected no error, got: %v", err)
-	}
-
-	if output.Success {
-		t.Fatal("Expected success to be false")
-	}
-
-	if output.Error == "" {
-		t.Fatal("Expected error message for server error")
+	if chip.ErrorCodeOf(err) != chip.ErrorUpstream {
+		t.Errorf("expected an upstream error, got %v", err)
 	}
 }
@@ -3,8 +3,11 @@ package tools
 import (
 	"fmt"
 	"net/http"
+	"slices"
 
 	"github.com/collibra/chip/pkg/chip"
+	"github.com/collibra/chip/pkg/completions"
+	"github.com/collibra/chip/pkg/resources"
 	"github.com/collibra/chip/pkg/skills"
 	"github.com/collibra/chip/pkg/tools/add_data_classification_match"
 	"github.com/collibra/chip/pkg/tools/cancel_dq_job_run"
@@ -53,6 +56,7 @@ import (
 	"github.com/collibra/chip/pkg/tools/search_lineage_transformations"
 	"github.com/collibra/chip/pkg/tools/update_dq_job"
 	"github.com/collibra/chip/pkg/tools/validate_dq_rule"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
 // ContextSpecificationsFeature is the experimental-feature identifier used to
@@ -73,6 +77,71 @@ var CopilotToolNames = []string{
 	"discover_business_glossary",
 }
 
+// WritesGroup is the tool group of every tool not annotated as read-only. Its
+// members follow from the tools' annotations instead of ToolGroups, so a new
+// write tool joins it without a configuration change.
+const WritesGroup = "writes"
+
+// ToolGroups names sets of tools that enabled-tools and disabled-tools accept
+// in place of the tool names. Add a new tool to the groups it belongs to.
+var ToolGroups = map[string][]string{
+	"discovery": {
+		"discover_data_assets",
+		"discover_business_glossary",
+		"get_asset_details",
+		"search_asset_keyword",
+		"list_asset_types",
+		"get_business_term_data",
+		"get_column_semantics",
+		"get_measure_data",
+		"get_table_semantics",
+		"list_context_specifications",
+		"get_context_specification",
+	},
+	"lineage": {
+		"get_lineage_downstream",
+		"get_lineage_entity",
+		"get_lineage_transformation",
+		"get_lineage_upstream",
+		"search_lineage_entities",
+		"search_lineage_transformations",
+	},
+	"classification": {
+		"search_data_class",
+		"add_data_classification_match",
+		"search_data_classification_match",
+		"remove_data_classification_match",
+	},
+	"data-contracts": {
+		"list_data_contract",
+		"init_data_contract",
+		"push_data_contract_manifest",
+		"pull_data_contract_manifest",
+	},
+	"assessments": {
+		"get_assessment",
+		"create_assessment",
+		"edit_assessment",
+	},
+	"data-quality": {
+		"create_data_quality_job",
+		"create_data_quality_rule",
+		"get_data_quality_rule",
+		"get_data_quality_rule_results",
+		"validate_data_quality_rule",
+		"list_data_quality_rule_templates",
+		"get_data_quality_rule_template",
+		"deploy_data_quality_rule_template",
+		"generate_data_quality_rule_sql",
+		"find_data_quality_rules",
+		"search_catalog_columns",
+		"dq_cancel_job_run",
+		"dq_delete_job_run",
+		"dq_delete_job",
+		"dq_update_job",
+	},
+}
+
 func RegisterAll(server *chip.Server, client *http.Client, toolConfig *chip.ServerToolConfig) error {
 	toolRegister(server, toolConfig, discover_data_assets.NewTool(client))
 	toolRegister(server, toolConfig, discover_business_glossary.NewTool(client))
@@ -129,16 +198,42 @@ func RegisterAll(server *chip.Server, client *http.Client, toolConfig *chip.Serv
 		toolRegister(server, toolConfig, get_debug_mcp_init_request.NewTool(client))
 	}
 
+	// Resource templates expose the data of read-only tools, and follow
+	// whether those tools are enabled.
+	resources.RegisterAll(server, client, func(toolName string) bool {
+		return toolConfig.IsToolEnabled(toolName, toolGroups(toolName, &mcp.ToolAnnotations{ReadOnlyHint: true})...)
+	})
+
 	if skills.Enabled(toolConfig) {
 		if err := skills.RegisterAll(server, toolConfig.SkillsDir); err != nil {
 			return fmt.Errorf("register skills: %w", err)
 		}
+	} else {
+		skills.RemovePrompts(server)
 	}
+
+	// Completions expose the data of the registered tools, and follow which
+	// tools are registered.
+	completions.RegisterAll(server, client)
 	return nil
 }
 
 func toolRegister[In, Out any](server *chip.Server, toolConfig *chip.ServerToolConfig, tool *chip.Tool[In, Out]) {
-	if toolConfig.IsToolEnabled(tool.Name) {
+	if toolConfig.IsToolEnabled(tool.Name, toolGroups(tool.Name, tool.Annotations)...) {
 		chip.RegisterTool(server, tool)
 	}
 }
+
+// toolGroups returns the names of the groups a tool belongs to.
+func toolGroups(toolName string, annotations *mcp.ToolAnnotations) []string {
+	var groups []string
+	for group, toolNames := range ToolGroups {
+		if slices.Contains(toolNames, toolName) {
+			groups = append(groups, group)
+		}
+	}
+	if annotations == nil || !annotations.ReadOnlyHint {
+		groups = append(groups, WritesGroup)
+	}
+	return groups
+}
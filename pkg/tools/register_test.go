@@ -130,3 +130,31 @@ func listTools(t *testing.T, cfg *chip.ServerToolConfig) []*mcp.Tool {
 	}
 	return result
 }
+
+func TestToolGroups_OnlyNameRegisteredTools(t *testing.T) {
+	names := listToolNames(t, &chip.ServerToolConfig{
+		Experimental: []string{tools.ContextSpecificationsFeature, tools.DataQualityFeatureName},
+	})
+	for group, toolNames := range tools.ToolGroups {
+		for _, name := range toolNames {
+			if !slices.Contains(names, name) {
+				t.Errorf("group %q lists %q, which is not a registered tool", group, name)
+			}
+		}
+	}
+}
+
+func TestRegisterAll_SelectsToolsByGroupAndPattern(t *testing.T) {
+	names := listToolNames(t, &chip.ServerToolConfig{EnabledTools: []string{"assessments", "get_lineage_*"}})
+	want := []string{"create_assessment", "edit_assessment", "get_assessment", "get_lineage_downstream", "get_lineage_entity", "get_lineage_transformation", "get_lineage_upstream"}
+	slices.Sort(names)
+	if !slices.Equal(names, want) {
+		t.Errorf("expected %v, got %v", want, names)
+	}
+
+	for _, tool := range listTools(t, &chip.ServerToolConfig{DisabledTools: []string{tools.WritesGroup}}) {
+		if !tool.Annotations.ReadOnlyHint {
+			t.Errorf("expected the writes group to disable %q", tool.Name)
+		}
+	}
+}
@@ -16,8 +16,7 @@ type Input struct {
 }
 
 type Output struct {
-	Success bool   `json:"success" jsonschema:"Whether the classification match was successfully removed"`
-	Error   string `json:"error,omitempty" jsonschema:"Error message if the operation failed"`
+	Success bool `json:"success" jsonschema:"Whether the classification match was successfully removed"`
 }
 
 func NewTool(collibraClient *http.Client) *chip.Tool[Input, Output] {
@@ -39,10 +38,7 @@ func handler(collibraClient *http.Client) chip.ToolHandlerFunc[Input, Output] {
 
 		err := clients.RemoveDataClassificationMatch(ctx, collibraClient, input.ClassificationMatchID)
 		if err != nil {
-			return Output{
-				Success: false,
-				Error:   fmt.Sprintf("Failed to remove classification match: %s", err.Error()),
-			}, nil
+			return Output{}, fmt.Errorf("failed to remove classification match: %w", err)
 		}
 
 		return Output{
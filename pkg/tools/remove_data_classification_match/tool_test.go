@@ -5,6 +5,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/collibra/chip/pkg/chip"
 	tools "github.com/collibra/chip/pkg/tools/remove_data_classification_match"
 	"github.com/collibra/chip/pkg/tools/testutil"
 )
@@ -34,7 +35,7 @@ func TestRemoveClassificationMatch_Success(t *testing.T) {
 	}
 
 	if !output.Success {
-		t.Errorf("Expected success=true, got false. Error: %s", output.Error)
+		t.Error("Expected success=true, got false")
 	}
 }
 
@@ -62,18 +63,9 @@ func TestRemoveClassificationMatch_NotFound(t *testing.T) {
 		ClassificationMatchID: "00000000-0000-0000-0000-000000000000",
 	}
 
-	output, err := tools.NewTool(client).Handler(t.Context(), input)
-
-	if err != nil {
-		t.Fatalf("Expected no error, got %v", err)
-	}
-
-	if output.Success {
-		t.Error("Expected success=false for classification match not found")
-	}
-
-	if output.Error == "" {
-		t.Error("Expected error message for classification match not found")
+	_, err := tools.NewTool(client).Handler(t.Context(), input)
+	if chip.ErrorCodeOf(err) != chip.ErrorNotFound {
+		t.Errorf("expected a not found error, got %v", err)
 	}
 }
 
@@ -93,17 +85,8 @@ func TestRemoveClassificationMatch_ServerError(t *testing.T) {
 		ClassificationMatchID: "12345678-1234-1234-1234-123456789abc",
 	}
 
-	output, err := tools.NewTool(client).Handler(t.Context(), input)
-
-	if err != nil {
-		t.Fatalf("Expected no error, got %v", err)
-	}
-
-	if output.Success {
-		t.Error("Expected success=false for server error")
-	}
-
-	if output.Error == "" {
-		t.Error("Expected error message for server error")
+	_, err := tools.NewTool(client).Handler(t.Context(), input)
+	if chip.ErrorCodeOf(err) != chip.ErrorUpstream {
+		t.Errorf("expected an upstream error, got %v", err)
 	}
 }
@@ -22,7 +22,6 @@ type Output struct {
 	Total       int                 `json:"total" jsonschema:"Total number of matching data classes"`
 	Count       int                 `json:"count" jsonschema:"Number of data classes returned in this response"`
 	DataClasses []clients.DataClass `json:"dataClasses" jsonschema:"List of data classes"`
-	Error       string              `json:"error,omitempty" jsonschema:"HTTP or other error message if the request failed"`
 }
 
 func NewTool(collibraClient *http.Client) *chip.Tool[Input, Output] {
@@ -43,7 +42,7 @@ func handler(collibraClient *http.Client) chip.ToolHandlerFunc[Input, Output] {
 		params := buildQueryParams(input)
 		results, total, err := clients.SearchDataClasses(ctx, collibraClient, params)
 		if err != nil {
-			return Output{Error: err.Error(), Total: total, Count: 0, DataClasses: results}, nil
+			return Output{}, err
 		}
 
 		if len(results) == 0 {
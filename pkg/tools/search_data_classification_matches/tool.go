@@ -23,7 +23,6 @@ type Output struct {
 	Total                 int                               `json:"total" jsonschema:"Total number of matching classification matches"`
 	Count                 int                               `json:"count" jsonschema:"Number of classification matches returned in this response"`
 	ClassificationMatches []clients.DataClassificationMatch `json:"classificationMatches" jsonschema:"List of classification matches"`
-	Error                 string                            `json:"error,omitempty" jsonschema:"HTTP or other error message if the request failed"`
 }
 
 func NewTool(collibraClient *http.Client) *chip.Tool[Input, Output] {
@@ -44,7 +43,7 @@ func handler(collibraClient *http.Client) chip.ToolHandlerFunc[Input, Output] {
 		params := buildQueryParams(input)
 		results, total, err := clients.SearchDataClassificationMatches(ctx, collibraClient, params)
 		if err != nil {
-			return Output{Error: err.Error(), Total: int(total), Count: 0, ClassificationMatches: results}, nil
+			return Output{}, err
 		}
 
 		if len(results) == 0 {
@@ -0,0 +1,435 @@
+package testutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// RecordEnv selects recording in NewCassetteClient: set it to 1 to capture
+// the cassettes from the Collibra instance at COLLIBRA_MCP_API_URL,
+// authenticated with COLLIBRA_MCP_API_USR and COLLIBRA_MCP_API_PWD.
+const RecordEnv = "CHIP_RECORD"
+
+const redacted = "[REDACTED]"
+
+// DefaultScrubbedHeaders are never written to a cassette.
+var DefaultScrubbedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Api-Key"}
+
+// DefaultScrubbedQuery are the query parameters whose values are never
+// written to a cassette.
+var DefaultScrubbedQuery = []string{"access_token", "api_key", "apikey", "token", "password"}
+
+// volatileHeaders change on every request and would only add noise to
+// cassette diffs.
+var volatileHeaders = []string{"Date", "Content-Length"}
+
+// Cassette holds the request/response pairs captured from Collibra, in the
+// order they were sent. Source says where they come from: "recorded" for
+// cassettes captured by NewCassetteClient, or a note starting with
+// "synthetic" for cassettes that were not captured from a Collibra instance.
+type Cassette struct {
+	Source       string        `json:"source,omitempty"`
+	Interactions []Interaction `json:"interactions"`
+}
+
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Header http.Header `json:"header,omitempty"`
+	Body   Body        `json:"body"`
+}
+
+type RecordedResponse struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   Body        `json:"body"`
+}
+
+// Body is a recorded body: JSON bodies are kept as JSON so cassettes are easy
+// to read and review, other bodies as text.
+type Body struct {
+	JSON json.RawMessage `json:"json,omitempty"`
+	Text string          `json:"text,omitempty"`
+}
+
+func newBody(data []byte) Body {
+	if len(data) > 0 && json.Valid(data) {
+		var compact bytes.Buffer
+		if json.Compact(&compact, data) == nil {
+			return Body{JSON: compact.Bytes()}
+		}
+	}
+	return Body{Text: string(data)}
+}
+
+func (b Body) bytes() []byte {
+	if b.JSON != nil {
+		return b.JSON
+	}
+	return []byte(b.Text)
+}
+
+// LoadCassette reads the cassette at path.
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cassette Cassette
+	if err := json.Unmarshal(data, &cassette); err != nil {
+		return nil, fmt.Errorf("failed to parse cassette %s: %w", path, err)
+	}
+	// Bodies are indented in the file; replay them compact, as recorded.
+	for i := range cassette.Interactions {
+		interaction := &cassette.Interactions[i]
+		interaction.Request.Body = newBody(interaction.Request.Body.bytes())
+		interaction.Response.Body = newBody(interaction.Response.Body.bytes())
+	}
+	return &cassette, nil
+}
+
+// Save writes the cassette to path, creating its directory.
+func (c *Cassette) Save(path string) error {
+	var data bytes.Buffer
+	encoder := json.NewEncoder(&data)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(c); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data.Bytes(), 0o644)
+}
+
+// Scrubber keeps secrets out of cassettes: it drops sensitive headers and
+// replaces the values of the listed query parameters and JSON fields, matched
+// case-insensitively (JSON fields at any depth), by "[REDACTED]". Replay
+// scrubs live requests the same way before comparing them with the cassette.
+type Scrubber struct {
+	Headers []string
+	Query   []string
+	Fields  []string
+}
+
+// path scrubs the query of a request URI, keeping the order and encoding of
+// the other parameters.
+func (s Scrubber) path(uri string) string {
+	path, query, found := strings.Cut(uri, "?")
+	if !found {
+		return uri
+	}
+	params := strings.Split(query, "&")
+	for i, param := range params {
+		key, _, _ := strings.Cut(param, "=")
+		if name, err := url.QueryUnescape(key); err == nil && matches(slices.Concat(DefaultScrubbedQuery, s.Query), name) {
+			params[i] = key + "=" + redacted
+		}
+	}
+	return path + "?" + strings.Join(params, "&")
+}
+
+func (s Scrubber) header(header http.Header) http.Header {
+	scrubbed := header.Clone()
+	for _, name := range slices.Concat(DefaultScrubbedHeaders, volatileHeaders, s.Headers) {
+		scrubbed.Del(name)
+	}
+	if len(scrubbed) == 0 {
+		return nil
+	}
+	return scrubbed
+}
+
+func (s Scrubber) body(data []byte) Body {
+	body := newBody(data)
+	if body.JSON == nil || len(s.Fields) == 0 {
+		return body
+	}
+	var value any
+	if json.Unmarshal(body.JSON, &value) != nil {
+		return body
+	}
+	scrubbed, err := json.Marshal(s.value(value))
+	if err != nil {
+		return body
+	}
+	return Body{JSON: scrubbed}
+}
+
+func (s Scrubber) value(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if matches(s.Fields, key) {
+				v[key] = redacted
+			} else {
+				v[key] = s.value(field)
+			}
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = s.value(item)
+		}
+	}
+	return value
+}
+
+func matches(names []string, name string) bool {
+	for _, scrubbed := range names {
+		if strings.EqualFold(scrubbed, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Recorder is a RoundTripper that sends requests upstream and captures them,
+// scrubbed, into a cassette.
+type Recorder struct {
+	next     http.RoundTripper
+	scrubber Scrubber
+
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+func NewRecorder(next http.RoundTripper, scrubber Scrubber) *Recorder {
+	return &Recorder{next: next, scrubber: scrubber}
+}
+
+func (r *Recorder) RoundTrip(request *http.Request) (*http.Response, error) {
+	requestBody, err := readBody(&request.Body)
+	if err != nil {
+		return nil, err
+	}
+	recorded := RecordedRequest{
+		Method: request.Method,
+		Path:   r.scrubber.path(request.URL.RequestURI()),
+		Header: r.scrubber.header(request.Header),
+		Body:   r.scrubber.body(requestBody),
+	}
+	response, err := r.next.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+	responseBody, err := readBody(&response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			Status: response.StatusCode,
+			Header: r.scrubber.header(response.Header),
+			Body:   r.scrubber.body(responseBody),
+		},
+	})
+	return response, nil
+}
+
+// Cassette returns what was recorded so far.
+func (r *Recorder) Cassette() *Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return &Cassette{Source: "recorded", Interactions: append([]Interaction(nil), r.cassette.Interactions...)}
+}
+
+// ReplayMode selects how a Replayer matches requests with the cassette.
+type ReplayMode int
+
+const (
+	// Strict expects the requests of the cassette in order, with the same
+	// method, path, query and body, and fails the test if some are not sent.
+	Strict ReplayMode = iota
+	// Lenient answers each request with the first unused interaction of the
+	// same method and path, whatever its body; once they are all used, the
+	// last one is answered again. Unused interactions are fine.
+	Lenient
+)
+
+// Replayer is a RoundTripper that answers requests from a cassette without
+// sending them. A request the cassette has no answer for fails the test.
+type Replayer struct {
+	t        testing.TB
+	cassette *Cassette
+	mode     ReplayMode
+	scrubber Scrubber
+
+	mu   sync.Mutex
+	used []bool
+	next int
+}
+
+// NewReplayer replays cassette. In Strict mode, the test fails at cleanup
+// when some interactions were not replayed.
+func NewReplayer(t testing.TB, cassette *Cassette, mode ReplayMode, scrubber Scrubber) *Replayer {
+	r := &Replayer{t: t, cassette: cassette, mode: mode, scrubber: scrubber, used: make([]bool, len(cassette.Interactions))}
+	if mode == Strict {
+		t.Cleanup(func() {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			if r.next < len(cassette.Interactions) {
+				next := cassette.Interactions[r.next].Request
+				t.Errorf("cassette: %d requests were not sent, starting with %s %s", len(cassette.Interactions)-r.next, next.Method, next.Path)
+			}
+		})
+	}
+	return r
+}
+
+func (r *Replayer) RoundTrip(request *http.Request) (*http.Response, error) {
+	body, err := readBody(&request.Body)
+	if err != nil {
+		return nil, err
+	}
+	path := r.scrubber.path(request.URL.RequestURI())
+	scrubbed := r.scrubber.body(body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	interaction, err := r.match(request.Method, path, scrubbed)
+	if err != nil {
+		r.t.Errorf("cassette: %v", err)
+		return nil, err
+	}
+	recorder := httptest.NewRecorder()
+	for name, values := range interaction.Response.Header {
+		recorder.Header()[name] = values
+	}
+	recorder.WriteHeader(interaction.Response.Status)
+	_, _ = recorder.Write(interaction.Response.Body.bytes())
+	response := recorder.Result()
+	response.Request = request
+	return response, nil
+}
+
+func (r *Replayer) match(method, path string, body Body) (*Interaction, error) {
+	if r.mode == Strict {
+		if r.next >= len(r.cassette.Interactions) {
+			return nil, fmt.Errorf("unexpected request %s %s after the last interaction", method, path)
+		}
+		interaction := &r.cassette.Interactions[r.next]
+		recorded := interaction.Request
+		if recorded.Method != method || recorded.Path != path {
+			return nil, fmt.Errorf("expected %s %s, got %s %s", recorded.Method, recorded.Path, method, path)
+		}
+		if !sameBody(recorded.Body, body) {
+			return nil, fmt.Errorf("%s %s: expected body %s, got %s", method, path, recorded.Body.bytes(), body.bytes())
+		}
+		r.used[r.next] = true
+		r.next++
+		return interaction, nil
+	}
+
+	last := -1
+	for i := range r.cassette.Interactions {
+		recorded := r.cassette.Interactions[i].Request
+		if recorded.Method != method || strings.Split(recorded.Path, "?")[0] != strings.Split(path, "?")[0] {
+			continue
+		}
+		last = i
+		if !r.used[i] {
+			r.used[i] = true
+			return &r.cassette.Interactions[i], nil
+		}
+	}
+	if last < 0 {
+		return nil, fmt.Errorf("no interaction for %s %s", method, path)
+	}
+	return &r.cassette.Interactions[last], nil
+}
+
+// sameBody compares JSON bodies by value, so formatting and key order do not
+// matter.
+func sameBody(recorded, live Body) bool {
+	if recorded.JSON == nil || live.JSON == nil {
+		return bytes.Equal(recorded.bytes(), live.bytes())
+	}
+	var a, b any
+	if json.Unmarshal(recorded.JSON, &a) != nil || json.Unmarshal(live.JSON, &b) != nil {
+		return false
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// readBody reads a request or response body and puts back a fresh reader.
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	data, err := io.ReadAll(*body)
+	_ = (*body).Close()
+	if err != nil {
+		return nil, err
+	}
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+// NewCassetteClient returns a client for tool tests that replays the
+// cassette testdata/cassettes/<name>.json of the test's package. With
+// CHIP_RECORD=1, it records the cassette from a live Collibra instance
+// instead and saves it when the test ends.
+func NewCassetteClient(t testing.TB, name string, mode ReplayMode, scrubber Scrubber) *http.Client {
+	t.Helper()
+	path := filepath.Join("testdata", "cassettes", name+".json")
+	if os.Getenv(RecordEnv) != "1" {
+		cassette, err := LoadCassette(path)
+		if err != nil {
+			t.Fatalf("cassette: %v (set %s=1 to record it)", err, RecordEnv)
+		}
+		return &http.Client{Transport: NewReplayer(t, cassette, mode, scrubber)}
+	}
+
+	baseURL := os.Getenv("COLLIBRA_MCP_API_URL")
+	if baseURL == "" {
+		t.Fatalf("cassette: recording needs COLLIBRA_MCP_API_URL")
+	}
+	recorder := NewRecorder(&testClient{baseURL: baseURL, next: basicAuth{next: http.DefaultTransport}}, scrubber)
+	t.Cleanup(func() {
+		if t.Failed() {
+			t.Logf("cassette: not saving %s, the test failed", path)
+			return
+		}
+		if err := recorder.Cassette().Save(path); err != nil {
+			t.Errorf("cassette: %v", err)
+		}
+	})
+	return &http.Client{Transport: recorder}
+}
+
+// basicAuth authenticates recorded requests with the account in
+// COLLIBRA_MCP_API_USR and COLLIBRA_MCP_API_PWD. It runs below the recorder,
+// so the credentials never reach the cassette.
+type basicAuth struct {
+	next http.RoundTripper
+}
+
+func (b basicAuth) RoundTrip(request *http.Request) (*http.Response, error) {
+	if username := os.Getenv("COLLIBRA_MCP_API_USR"); username != "" {
+		request = request.Clone(request.Context())
+		request.SetBasicAuth(username, os.Getenv("COLLIBRA_MCP_API_PWD"))
+	}
+	return b.next.RoundTrip(request)
+}
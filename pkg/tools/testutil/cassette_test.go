@@ -0,0 +1,136 @@
+package testutil
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// failures collects the errors a Replayer reports instead of failing the
+// test, and runs cleanups on demand.
+type failures struct {
+	testing.TB
+	errors   []string
+	cleanups []func()
+}
+
+func (f *failures) Errorf(format string, args ...any) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func (f *failures) Cleanup(cleanup func()) {
+	f.cleanups = append(f.cleanups, cleanup)
+}
+
+func (f *failures) cleanup() {
+	for _, cleanup := range f.cleanups {
+		cleanup()
+	}
+}
+
+func send(client *http.Client, method, path, body string) (int, string, error) {
+	request, _ := http.NewRequest(method, path, strings.NewReader(body))
+	request.Header.Set("Authorization", "Bearer secret")
+	response, err := client.Do(request)
+	if err != nil {
+		return 0, "", err
+	}
+	defer func() { _ = response.Body.Close() }()
+	data, _ := io.ReadAll(response.Body)
+	return response.StatusCode, string(data), nil
+}
+
+func recordCassette(t *testing.T) *Cassette {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprintf(w, `{"path":%q,"request":%s,"token":"t0k3n"}`, r.URL.Path, body)
+	}))
+	defer server.Close()
+
+	recorder := NewRecorder(NewClient(server).Transport, Scrubber{Query: []string{"signature"}, Fields: []string{"password", "token"}})
+	client := &http.Client{Transport: recorder}
+	for _, name := range []string{"a", "b"} {
+		if _, _, err := send(client, http.MethodPost, "/rest/2.0/users?dryRun=false&Signature=s1gn3d&access_token=s3cr3t", `{"name":"`+name+`","Password":"hunter2"}`); err != nil {
+			t.Fatal(err)
+		}
+	}
+	path := filepath.Join(t.TempDir(), "users.json")
+	if err := recorder.Cassette().Save(path); err != nil {
+		t.Fatal(err)
+	}
+	cassette, err := LoadCassette(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cassette
+}
+
+func TestRecorder_ScrubsSecrets(t *testing.T) {
+	cassette := recordCassette(t)
+	if len(cassette.Interactions) != 2 {
+		t.Fatalf("expected 2 interactions, got %d", len(cassette.Interactions))
+	}
+	interaction := cassette.Interactions[0]
+	if cassette.Source != "recorded" {
+		t.Errorf("expected the cassette to be labelled as recorded, got %q", cassette.Source)
+	}
+	if interaction.Request.Path != "/rest/2.0/users?dryRun=false&Signature=[REDACTED]&access_token=[REDACTED]" || interaction.Request.Header.Get("Authorization") != "" {
+		t.Errorf("unexpected request %+v", interaction.Request)
+	}
+	if body := string(interaction.Request.Body.JSON); body != `{"Password":"[REDACTED]","name":"a"}` {
+		t.Errorf("expected the password to be scrubbed, got %s", body)
+	}
+	if body := string(interaction.Response.Body.JSON); strings.Contains(body, "t0k3n") || !strings.Contains(body, `"path":"/rest/2.0/users"`) {
+		t.Errorf("expected the token to be scrubbed, got %s", body)
+	}
+}
+
+func TestReplayer_Strict(t *testing.T) {
+	cassette := recordCassette(t)
+	tb := &failures{TB: t}
+	client := &http.Client{Transport: NewReplayer(tb, cassette, Strict, Scrubber{Query: []string{"signature"}, Fields: []string{"password"}})}
+
+	status, body, err := send(client, http.MethodPost, "/rest/2.0/users?dryRun=false&Signature=other&access_token=other", `{"Password":"other","name":"a"}`)
+	if err != nil || status != http.StatusCreated || !strings.Contains(body, `"name":"a"`) {
+		t.Errorf("expected the first interaction, got %d %s %v", status, body, err)
+	}
+	if _, _, err := send(client, http.MethodPost, "/rest/2.0/users?dryRun=false&Signature=other&access_token=other", `{"name":"c"}`); err == nil {
+		t.Error("expected a request with another body to fail")
+	}
+	tb.cleanup()
+	if len(tb.errors) != 2 || !strings.Contains(tb.errors[1], "1 requests were not sent") {
+		t.Errorf("expected a mismatch and an unused interaction, got %v", tb.errors)
+	}
+}
+
+func TestReplayer_Lenient(t *testing.T) {
+	cassette := recordCassette(t)
+	tb := &failures{TB: t}
+	client := &http.Client{Transport: NewReplayer(tb, cassette, Lenient, Scrubber{})}
+
+	var names []string
+	for range 3 {
+		_, body, err := send(client, http.MethodPost, "/rest/2.0/users", `{"name":"z"}`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, body[strings.Index(body, `"name":`)+8:][:1])
+	}
+	if strings.Join(names, "") != "abb" {
+		t.Errorf("expected the interactions in order and then the last one again, got %v", names)
+	}
+	if _, _, err := send(client, http.MethodGet, "/rest/2.0/users", ""); err == nil {
+		t.Error("expected a request without interaction to fail")
+	}
+	tb.cleanup()
+	if len(tb.errors) != 1 {
+		t.Errorf("expected only the unknown request to be reported, got %v", tb.errors)
+	}
+}
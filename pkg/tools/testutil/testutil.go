@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"path"
+	"sync"
 )
 
 type testClient struct {
@@ -31,6 +32,47 @@ func NewClient(server *httptest.Server) *http.Client {
 	return &http.Client{Transport: &testClient{baseURL: server.URL, next: http.DefaultTransport}}
 }
 
+// cancellingClient cancels a tool call once the request it is waiting for
+// has been answered, and counts the requests the tool attempts after that.
+type cancellingClient struct {
+	next     http.RoundTripper
+	cancel   func()
+	cancelOn func(*http.Request) bool
+
+	mu        sync.Mutex
+	cancelled bool
+	attempts  int
+}
+
+func (c *cancellingClient) RoundTrip(request *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	if c.cancelled {
+		c.attempts++
+	}
+	c.mu.Unlock()
+	response, err := c.next.RoundTrip(request)
+	if c.cancelOn(request) {
+		c.mu.Lock()
+		c.cancelled = true
+		c.mu.Unlock()
+		c.cancel()
+	}
+	return response, err
+}
+
+// NewCancellingClient returns a client for server that calls cancel, which
+// stands for the client of the MCP call cancelling it, as soon as the request
+// matched by cancelOn has been answered. requestsAfterCancel reports how many
+// requests were attempted after that, whether or not they reached server.
+func NewCancellingClient(server *httptest.Server, cancel func(), cancelOn func(*http.Request) bool) (client *http.Client, requestsAfterCancel func() int) {
+	transport := &cancellingClient{next: NewClient(server).Transport, cancel: cancel, cancelOn: cancelOn}
+	return &http.Client{Transport: transport}, func() int {
+		transport.mu.Lock()
+		defer transport.mu.Unlock()
+		return transport.attempts
+	}
+}
+
 type Marshaller[Type any] interface {
 	Marshall(v Type) ([]byte, error)
 }
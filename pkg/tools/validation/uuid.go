@@ -1,11 +1,11 @@
 // Package validation provides shared input validation helpers for CHIP tools.
-// Helpers return Go errors that the MCP SDK wraps as tool execution errors
-// (isError: true), letting the calling model see the problem and self-correct.
+// Helpers return validation errors that the MCP SDK wraps as tool execution
+// errors (isError: true), letting the calling model see the problem and
+// self-correct.
 package validation
 
 import (
-	"fmt"
-
+	"github.com/collibra/chip/pkg/chip"
 	"github.com/google/uuid"
 )
 
@@ -13,7 +13,7 @@ import (
 // returned error so the calling model can identify which field failed.
 func UUID(fieldName, value string) error {
 	if _, err := uuid.Parse(value); err != nil {
-		return fmt.Errorf("invalid UUID for %q: %s", fieldName, err.Error())
+		return chip.Errorf(chip.ErrorValidation, "invalid UUID for %q: %s", fieldName, err.Error())
 	}
 	return nil
 }
@@ -32,7 +32,7 @@ func UUIDOptional(fieldName, value string) error {
 func UUIDs(fieldName string, values []string) error {
 	for i, v := range values {
 		if _, err := uuid.Parse(v); err != nil {
-			return fmt.Errorf("invalid UUID for %q[%d]: %s", fieldName, i, err.Error())
+			return chip.Errorf(chip.ErrorValidation, "invalid UUID for %q[%d]: %s", fieldName, i, err.Error())
 		}
 	}
 	return nil
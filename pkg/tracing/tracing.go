@@ -0,0 +1,205 @@
+// Package tracing integrates chip with OpenTelemetry. Every MCP tool call
+// gets a server span, started in the tool middleware chain and parented to
+// the caller's W3C trace context when the MCP client sends one, and every
+// upstream Collibra request made while serving it gets a child client span
+// whose context is propagated to Collibra in the traceparent header. A
+// single tool call that fans out to many Collibra requests therefore shows
+// up as one trace.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/collibra/chip/pkg/chip"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/collibra/chip"
+
+// Exporter names accepted in Config.Exporter.
+const (
+	ExporterNone = "none"
+	ExporterOTLP = "otlp"
+	ExporterFile = "file"
+)
+
+// Config selects where finished spans are sent.
+type Config struct {
+	// Exporter is one of ExporterNone (default), ExporterOTLP or ExporterFile.
+	Exporter string
+	// Endpoint is the OTLP/HTTP traces URL (e.g.
+	// "http://localhost:4318/v1/traces"). When empty, the standard
+	// OTEL_EXPORTER_OTLP_* environment variables and their defaults apply.
+	Endpoint string
+	// File is the path spans are appended to as JSON with ExporterFile.
+	File string
+}
+
+// Tracing owns chip's tracer provider. With ExporterNone spans are still
+// created, so upstream requests carry a real trace context that ties them
+// to their tool call, but nothing is exported.
+type Tracing struct {
+	provider   *sdktrace.TracerProvider
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+	closeFile  func() error
+}
+
+func New(ctx context.Context, config Config) (*Tracing, error) {
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName("chip"),
+		semconv.ServiceVersion(chip.Version),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build trace resource: %w", err)
+	}
+
+	t := &Tracing{propagator: propagation.TraceContext{}}
+	opts := []sdktrace.TracerProviderOption{sdktrace.WithResource(res)}
+	switch config.Exporter {
+	case "", ExporterNone:
+	case ExporterOTLP:
+		var exporterOpts []otlptracehttp.Option
+		if config.Endpoint != "" {
+			exporterOpts = append(exporterOpts, otlptracehttp.WithEndpointURL(config.Endpoint))
+		}
+		exporter, err := otlptracehttp.New(ctx, exporterOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+		}
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	case ExporterFile:
+		f, err := os.OpenFile(config.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open trace file: %w", err)
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("failed to create file trace exporter: %w", err)
+		}
+		t.closeFile = f.Close
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q (must be %q, %q or %q)", config.Exporter, ExporterNone, ExporterOTLP, ExporterFile)
+	}
+
+	t.provider = sdktrace.NewTracerProvider(opts...)
+	t.tracer = t.provider.Tracer(instrumentationName, trace.WithInstrumentationVersion(chip.Version))
+	return t, nil
+}
+
+// Shutdown flushes pending spans and releases the exporter.
+func (t *Tracing) Shutdown(ctx context.Context) error {
+	err := t.provider.Shutdown(ctx)
+	if t.closeFile != nil {
+		if closeErr := t.closeFile(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// ToolMiddleware starts a server span around each tool call. The parent
+// context is taken from the traceparent in the request's _meta (the MCP
+// convention, which works over every transport) or, failing that, from the
+// traceparent header of the HTTP request that carried the call.
+func (t *Tracing) ToolMiddleware() chip.ToolMiddleware {
+	return chip.ToolMiddlewareFunc(func(ctx context.Context, toolRequest *mcp.CallToolRequest, next chip.CallToolFunc) (*mcp.CallToolResult, error) {
+		ctx = t.propagator.Extract(ctx, incomingCarrier(toolRequest))
+
+		tool := toolRequest.Params.Name
+		attrs := []attribute.KeyValue{attribute.String("mcp.tool.name", tool)}
+		if toolRequest.Session != nil {
+			attrs = append(attrs, attribute.String("mcp.session.id", toolRequest.Session.ID()))
+		}
+		ctx, span := t.tracer.Start(ctx, "tools/call "+tool,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(attrs...),
+		)
+		defer span.End()
+
+		res, err := next(ctx, toolRequest)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		} else if res != nil && res.IsError {
+			span.SetStatus(codes.Error, "tool returned an error result")
+		}
+		return res, err
+	})
+}
+
+// incomingCarrier exposes the trace context sent by the MCP client.
+func incomingCarrier(toolRequest *mcp.CallToolRequest) propagation.TextMapCarrier {
+	carrier := propagation.MapCarrier{}
+	if extra := toolRequest.GetExtra(); extra != nil && extra.Header != nil {
+		for _, key := range []string{"traceparent", "tracestate"} {
+			if v := extra.Header.Get(key); v != "" {
+				carrier[key] = v
+			}
+		}
+	}
+	if meta := toolRequest.Params.GetMeta(); meta != nil {
+		if v, ok := meta["traceparent"].(string); ok && v != "" {
+			carrier["traceparent"] = v
+			delete(carrier, "tracestate")
+			if state, ok := meta["tracestate"].(string); ok {
+				carrier["tracestate"] = state
+			}
+		}
+	}
+	return carrier
+}
+
+// Transport wraps an upstream RoundTripper with a client span per request
+// and injects the span's context into the outgoing traceparent header.
+func (t *Tracing) Transport(next http.RoundTripper) http.RoundTripper {
+	return &transport{tracing: t, next: next}
+}
+
+type transport struct {
+	tracing *Tracing
+	next    http.RoundTripper
+}
+
+func (rt *transport) RoundTrip(request *http.Request) (*http.Response, error) {
+	family := chip.APIFamily(request.URL.Path)
+	ctx, span := rt.tracing.tracer.Start(request.Context(), request.Method+" "+family,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(request.Method),
+			semconv.URLPath(request.URL.Path),
+			attribute.String("collibra.api_family", family),
+		),
+	)
+	defer span.End()
+
+	reqClone := request.Clone(ctx)
+	rt.tracing.propagator.Inject(ctx, propagation.HeaderCarrier(reqClone.Header))
+	response, err := rt.next.RoundTrip(reqClone)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	span.SetAttributes(semconv.HTTPResponseStatusCode(response.StatusCode))
+	if response.StatusCode >= 400 {
+		span.SetStatus(codes.Error, "HTTP "+strconv.Itoa(response.StatusCode))
+	}
+	return response, nil
+}
@@ -0,0 +1,107 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+const incomingTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+
+func newRecordingTracing() (*Tracing, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	return &Tracing{
+		provider:   provider,
+		tracer:     provider.Tracer(instrumentationName),
+		propagator: propagation.TraceContext{},
+	}, recorder
+}
+
+func TestToolCallAndUpstreamRequestsShareOneTrace(t *testing.T) {
+	tr, recorder := newRecordingTracing()
+
+	var traceparents []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparents = append(traceparents, r.Header.Get("traceparent"))
+	}))
+	defer server.Close()
+	client := &http.Client{Transport: tr.Transport(http.DefaultTransport)}
+
+	request := &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{
+		Name: "get_table_semantics",
+		Meta: mcp.Meta{"traceparent": "00-" + incomingTraceID + "-00f067aa0ba902b7-01"},
+	}}
+	_, err := tr.ToolMiddleware().ToolHandle(t.Context(), request, func(ctx context.Context, _ *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		for _, path := range []string{"/rest/2.0/assets", "/graphql/knowledgeGraph/v1"} {
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+path, nil)
+			resp, err := client.Do(req)
+			if err != nil {
+				return nil, err
+			}
+			_ = resp.Body.Close()
+		}
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(traceparents) != 2 {
+		t.Fatalf("expected 2 upstream requests, got %d", len(traceparents))
+	}
+	for _, tp := range traceparents {
+		if !strings.HasPrefix(tp, "00-"+incomingTraceID+"-") {
+			t.Errorf("upstream traceparent %q does not continue the incoming trace", tp)
+		}
+	}
+	if traceparents[0] == traceparents[1] {
+		t.Error("expected each upstream request to get its own span id")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("expected 3 spans (tool call + 2 upstream), got %d", len(spans))
+	}
+	toolSpan := spans[2]
+	if toolSpan.Name() != "tools/call get_table_semantics" {
+		t.Fatalf("unexpected tool span name %q", toolSpan.Name())
+	}
+	if got := toolSpan.Parent().SpanID().String(); got != "00f067aa0ba902b7" {
+		t.Errorf("tool span parent = %s, want the incoming span", got)
+	}
+	for _, upstream := range spans[:2] {
+		if upstream.Parent().SpanID() != toolSpan.SpanContext().SpanID() {
+			t.Errorf("upstream span %q is not a child of the tool span", upstream.Name())
+		}
+	}
+	if spans[0].Name() != "GET /rest/2.0" || spans[1].Name() != "GET /graphql/knowledgeGraph" {
+		t.Errorf("unexpected upstream span names %q, %q", spans[0].Name(), spans[1].Name())
+	}
+}
+
+func TestToolMiddleware_StartsNewTraceWithoutIncomingContext(t *testing.T) {
+	tr, recorder := newRecordingTracing()
+	request := &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: "list_asset_types"}}
+	_, _ = tr.ToolMiddleware().ToolHandle(t.Context(), request, func(context.Context, *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return &mcp.CallToolResult{IsError: true}, nil
+	})
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	if spans[0].Parent().IsValid() {
+		t.Error("expected a root span when the client sends no trace context")
+	}
+	if spans[0].Status().Code.String() != "Error" {
+		t.Errorf("expected an isError result to mark the span as failed, got %s", spans[0].Status().Code)
+	}
+}